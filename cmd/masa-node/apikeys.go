@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
+	"github.com/masa-finance/masa-oracle/pkg/config"
+)
+
+// handleAPIKeys issues, revokes or lists API keys as requested on the command line.
+// The keys are kept in the node datastore, which can't be opened while the node is running; use the /api/v1/apikeys endpoints then.
+func handleAPIKeys(cfg *config.AppConfig) error {
+	store, err := apikey.Open(cfg.NodeDatastorePath())
+	if err != nil {
+		return fmt.Errorf("%w (is the node running? use the /api/v1/apikeys endpoints instead)", err)
+	}
+	defer store.Close()
+
+	switch {
+	case cfg.APIKeyCreate != "":
+		scopes, err := apikey.ParseScopes(cfg.APIKeyScopes)
+		if err != nil {
+			return err
+		}
+		var ttl time.Duration
+		if cfg.APIKeyExpires != "" {
+			ttl, err = time.ParseDuration(cfg.APIKeyExpires)
+			if err != nil || ttl <= 0 {
+				return fmt.Errorf("invalid API key lifetime %q", cfg.APIKeyExpires)
+			}
+		}
+		key, token, err := store.Issue(cfg.APIKeyCreate, scopes, ttl)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Issued API key %s (%s) with scopes %s\n", key.ID, key.Label, formatScopes(key.Scopes))
+		fmt.Println("Store it now, it cannot be shown again:")
+		fmt.Println(token)
+
+	case cfg.APIKeyRevoke != "":
+		key, err := store.Revoke(cfg.APIKeyRevoke)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Revoked API key %s (%s)\n", key.ID, key.Label)
+
+	case cfg.APIKeyList:
+		for _, key := range store.List() {
+			status := "active"
+			switch {
+			case key.RevokedAt != nil:
+				status = "revoked"
+			case key.Expired(time.Now()):
+				status = "expired"
+			}
+			expires := "never"
+			if key.ExpiresAt != nil {
+				expires = key.ExpiresAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\texpires %s\n", key.ID, key.Label, formatScopes(key.Scopes), status, expires)
+		}
+	}
+	return nil
+}
+
+// bootstrapKeyFile is the file in the masa directory the bootstrap admin key is written to.
+const bootstrapKeyFile = "bootstrap_admin_key"
+
+// bootstrapAdminKey issues an admin key when no key was ever issued, so that a node started with
+// API authentication can be managed without stopping it. The key is written to a file only
+// readable by the node's user, and the path of the file is returned. It returns an empty path
+// if keys were already issued.
+func bootstrapAdminKey(cfg *config.AppConfig, store *apikey.Store) (string, error) {
+	if len(store.List()) > 0 {
+		return "", nil
+	}
+	key, token, err := store.Issue("bootstrap-admin", []apikey.Scope{apikey.ScopeAdmin}, 0)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(cfg.MasaDir, bootstrapKeyFile)
+	if err := writeSecretFile(path, token+"\n"); err != nil {
+		if _, revokeErr := store.Revoke(key.ID); revokeErr != nil {
+			return "", fmt.Errorf("error writing the bootstrap admin key: %w (revoking it: %v)", err, revokeErr)
+		}
+		return "", fmt.Errorf("error writing the bootstrap admin key: %w", err)
+	}
+	return path, nil
+}
+
+// writeSecretFile writes the content to a file only readable by its owner, replacing an existing file.
+func writeSecretFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	// An existing file keeps its mode, so restrict it as well
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func formatScopes(scopes []apikey.Scope) string {
+	names := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		names = append(names, string(s))
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/masa-finance/masa-oracle/pkg/chain"
+	"github.com/masa-finance/masa-oracle/pkg/config"
+)
+
+// handleChainMigration converts the chain in the masa directory to the current block encoding
+// and verifies it. The chain can't be open while the node is running.
+func handleChainMigration(cfg *config.AppConfig) error {
+	report, err := chain.Migrate(cfg.MasaDir)
+	if err != nil {
+		return fmt.Errorf("chain migration failed (is the node running?): %w", err)
+	}
+	fmt.Printf("Converted %d of %d stored blocks to encoding version %d\n", report.Converted, report.Blocks, chain.EncodingVersion)
+	fmt.Printf("Verified %d stored blocks and a chain of %d blocks\n", report.Blocks, report.Chain)
+	return nil
+}
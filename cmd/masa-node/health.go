@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/masa-finance/masa-oracle/node"
+	"github.com/masa-finance/masa-oracle/pkg/config"
+	"github.com/masa-finance/masa-oracle/pkg/db"
+	"github.com/masa-finance/masa-oracle/pkg/health"
+	"github.com/masa-finance/masa-oracle/pkg/tee"
+)
+
+// healthCheckers returns the liveness checks, which only fail when the node has to be restarted,
+// and the readiness checks, which fail while the node can't serve data requests.
+// The staking status is the result of the staking verification done on startup.
+func healthCheckers(cfg *config.AppConfig, masaNode *node.OracleNode, isStaked bool, stakingErr error) (*health.Checker, *health.Checker) {
+	liveness := health.NewChecker(health.DefaultTimeout)
+	liveness.Register("libp2p", true, func(context.Context) error {
+		return masaNode.CheckListening()
+	})
+
+	readiness := health.NewChecker(health.DefaultTimeout)
+	readiness.Register("libp2p", true, func(context.Context) error {
+		return masaNode.CheckListening()
+	})
+	readiness.Register("dht", true, func(context.Context) error {
+		return masaNode.CheckRoutingTable()
+	})
+	// The node reconnects to the bootnodes on its own and can serve requests through the peers
+	// it already knows, so losing the bootnodes is only reported.
+	readiness.Register("bootnodes", false, func(context.Context) error {
+		if !masaNode.Options.HasBootnodes() {
+			return fmt.Errorf("%w: no bootnodes configured", health.ErrSkipped)
+		}
+		return masaNode.CheckBootnodes()
+	})
+	readiness.Register("pubsub", true, func(context.Context) error {
+		return masaNode.CheckSubscriptions()
+	})
+	readiness.Register("tee-worker", true, func(ctx context.Context) error {
+		if !masaNode.IsWorker() {
+			return fmt.Errorf("%w: not a worker", health.ErrSkipped)
+		}
+		return tee.CheckReachable(ctx)
+	})
+	readiness.Register("chain", true, func(context.Context) error {
+		if !cfg.Validator {
+			return fmt.Errorf("%w: not a validator", health.ErrSkipped)
+		}
+		return masaNode.Blockchain.Check()
+	})
+	readiness.Register("cache", true, db.CheckResolverCache)
+	readiness.Register("staking", true, func(context.Context) error {
+		if stakingErr != nil {
+			return fmt.Errorf("staking verification failed: %w", stakingErr)
+		}
+		if !isStaked {
+			return errors.New("no staking event found for this address")
+		}
+		return nil
+	})
+	return liveness, readiness
+}
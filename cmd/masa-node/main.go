@@ -2,20 +2,27 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
 	"github.com/masa-finance/masa-oracle/internal/versioning"
 
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/masa-finance/masa-oracle/node"
 	"github.com/masa-finance/masa-oracle/pkg/api"
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
 	"github.com/masa-finance/masa-oracle/pkg/config"
 	"github.com/masa-finance/masa-oracle/pkg/db"
+	"github.com/masa-finance/masa-oracle/pkg/grpcapi"
 	"github.com/masa-finance/masa-oracle/pkg/staking"
+	"github.com/masa-finance/masa-oracle/pkg/tracing"
 )
 
 func main() {
@@ -44,6 +51,22 @@ func main() {
 		cfg.LogConfig()
 	}
 
+	if cfg.APIKeyCreate != "" || cfg.APIKeyRevoke != "" || cfg.APIKeyList {
+		if err := handleAPIKeys(cfg); err != nil {
+			logrus.Errorf("[-] %v", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if cfg.MigrateChain {
+		if err := handleChainMigration(cfg); err != nil {
+			logrus.Errorf("[-] %v", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Create a cancellable context
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -69,9 +92,9 @@ func main() {
 	}
 
 	// Verify the staking event
-	isStaked, err := staking.VerifyStakingEvent(cfg.RpcUrl, cfg.KeyManager.EthAddress)
-	if err != nil {
-		logrus.Error(err)
+	isStaked, stakingErr := staking.VerifyStakingEvent(cfg.RpcUrl, cfg.KeyManager.EthAddress)
+	if stakingErr != nil {
+		logrus.Error(stakingErr)
 	}
 
 	if !isStaked {
@@ -79,6 +102,7 @@ func main() {
 	}
 
 	masaNodeOptions, workHandlerManager, pubKeySub := config.InitOptions(cfg)
+	workHandlerManager.AuditStore().StartRetention(ctx, time.Hour)
 	// Create a new OracleNode
 	masaNode, err := node.NewOracleNode(ctx, masaNodeOptions...)
 	if err != nil {
@@ -93,6 +117,7 @@ func main() {
 	if err = masaNode.Start(); err != nil {
 		logrus.Fatal(err)
 	}
+	go workHandlerManager.StartLatencyProbes(ctx, masaNode)
 
 	if cfg.AllowedPeer {
 		cfg.AllowedPeerId = masaNode.Host.ID().String()
@@ -105,13 +130,68 @@ func main() {
 	// Init cache resolver
 	db.InitResolverCache(masaNode, cfg.KeyManager, cfg.AllowedPeerId, cfg.AllowedPeerPublicKey, cfg.Validator)
 
-	// Cancel the context when SIGINT is received
-	go handleSignals(cancel, masaNode, cfg)
+	stop := &shutdown{cfg: cfg, cancel: cancel, node: masaNode, workers: workHandlerManager}
+
+	stop.tracing, err = tracing.Init(ctx, cfg.TracingEndpoint, cfg.TracingInsecure, masaNode.Host.ID().String(), versioning.ApplicationVersion)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	if cfg.TracingEndpoint != "" {
+		logrus.Infof("[+] Exporting trace spans to %s", cfg.TracingEndpoint)
+	}
+
+	liveness, readiness := healthCheckers(cfg, masaNode, isStaked, stakingErr)
+	apiOptions := []api.Option{api.WithHealthCheckers(liveness, readiness)}
+	var apiTLS *tls.Config
+	if cfg.APIEnabled || cfg.GRPCListenAddress != "" {
+		stop.limiter = config.InitRateLimiter(cfg)
+		apiOptions = append(apiOptions, api.WithRateLimiter(stop.limiter))
+		if store := config.InitIdempotency(cfg); store != nil {
+			apiOptions = append(apiOptions, api.WithIdempotency(store))
+		}
+		if cfg.APIAuth {
+			stop.apiKeys, err = apikey.NewStore(db.Datastore())
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			path, err := bootstrapAdminKey(cfg, stop.apiKeys)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			if path != "" {
+				logrus.Warnf("[+] No API keys had been issued, an admin key was written to %s; store it elsewhere and delete the file", path)
+			}
+			apiOptions = append(apiOptions, api.WithAPIKeys(stop.apiKeys))
+		}
+
+		var clientScopes api.ClientCertScopes
+		apiTLS, clientScopes, err = apiTLSConfig(cfg)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		if len(clientScopes) > 0 {
+			apiOptions = append(apiOptions, api.WithClientCertScopes(clientScopes))
+		}
+		if !cfg.APIAuth && len(clientScopes) == 0 {
+			logrus.Warn("[-] API authentication is disabled, every route is open to anyone who can reach the API")
+		}
+		if apiTLS == nil {
+			logrus.Warn("[-] The API is served without TLS, set API_TLS_ENABLED=true to encrypt it")
+		}
+	}
 
 	if cfg.APIEnabled {
-		router := api.SetupRoutes(masaNode, workHandlerManager, pubKeySub)
+		router := api.SetupRoutes(masaNode, workHandlerManager, pubKeySub, apiOptions...)
+		server := &http.Server{Addr: cfg.APIListenAddress, Handler: router, TLSConfig: apiTLS}
+		stop.apiServer = server
 		go func() {
-			if err := router.Run(cfg.APIListenAddress); err != nil {
+			var err error
+			if apiTLS != nil {
+				err = server.ListenAndServeTLS("", "")
+			} else {
+				err = server.ListenAndServe()
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
 				logrus.Fatal(err)
 			}
 		}()
@@ -120,6 +200,20 @@ func main() {
 		logrus.Info("API server is disabled")
 	}
 
+	if cfg.GRPCListenAddress != "" {
+		var grpcOptions []grpc.ServerOption
+		if apiTLS != nil {
+			grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(apiTLS)))
+		}
+		grpcServer := grpcapi.NewServer(api.NewAPI(masaNode, workHandlerManager, pubKeySub, apiOptions...), grpcOptions...)
+		stop.grpcServer = grpcServer
+		go func() {
+			if err := grpcServer.ListenAndServe(cfg.GRPCListenAddress); err != nil {
+				logrus.Fatal(err)
+			}
+		}()
+	}
+
 	// Get the multiaddress and IP address of the node
 	multiAddrs, err := masaNode.GetP2PMultiAddrs()
 	if err != nil {
@@ -128,22 +222,6 @@ func main() {
 		config.DisplayWelcomeMessage(multiAddrs, cfg.KeyManager.EthAddress, isStaked, cfg.Validator, cfg.TwitterScraper, cfg.TelegramScraper, cfg.DiscordScraper, cfg.WebScraper, versioning.ApplicationVersion, versioning.ProtocolVersion)
 	}
 
-	<-ctx.Done()
-}
-
-func handleSignals(cancel context.CancelFunc, masaNode *node.OracleNode, cfg *config.AppConfig) {
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-
-	<-c
-	nodeData := masaNode.NodeTracker.GetNodeData(masaNode.Host.ID().String())
-	if nodeData != nil {
-		nodeData.Left()
-	}
-	cancel()
-	if cfg.TelegramStop != nil {
-		if err := cfg.TelegramStop(); err != nil {
-			logrus.Errorf("Error stopping the background connection: %v", err)
-		}
-	}
+	waitForSignal()
+	stop.run()
 }
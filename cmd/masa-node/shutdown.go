@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/masa-finance/masa-oracle/node"
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
+	"github.com/masa-finance/masa-oracle/pkg/config"
+	"github.com/masa-finance/masa-oracle/pkg/db"
+	"github.com/masa-finance/masa-oracle/pkg/grpcapi"
+	"github.com/masa-finance/masa-oracle/pkg/ratelimit"
+	"github.com/masa-finance/masa-oracle/pkg/tracing"
+	"github.com/masa-finance/masa-oracle/pkg/workers"
+)
+
+const (
+	defaultShutdownTimeout = 30 * time.Second
+	leaveTimeout           = 5 * time.Second
+	tracingFlushTimeout    = 5 * time.Second
+)
+
+// shutdown holds what has to be stopped when the node exits. Nil members are skipped.
+type shutdown struct {
+	cfg        *config.AppConfig
+	cancel     context.CancelFunc
+	node       *node.OracleNode
+	workers    *workers.WorkHandlerManager
+	apiServer  *http.Server
+	grpcServer *grpcapi.Server
+	apiKeys    *apikey.Store
+	limiter    *ratelimit.Limiter
+	tracing    tracing.ShutdownFunc
+}
+
+// waitForSignal blocks until SIGINT or SIGTERM is received. A second signal exits immediately.
+func waitForSignal() {
+	c := make(chan os.Signal, 2)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+
+	sig := <-c
+	logrus.Infof("[+] Received %s, shutting down", sig)
+	go func() {
+		<-c
+		logrus.Warn("[-] Received a second signal, exiting without a clean shutdown")
+		os.Exit(1)
+	}()
+}
+
+// shutdownTimeout returns the configured time given to in-flight requests to complete.
+func shutdownTimeout(cfg *config.AppConfig) time.Duration {
+	if cfg.ShutdownTimeout == "" {
+		return defaultShutdownTimeout
+	}
+	timeout, err := time.ParseDuration(cfg.ShutdownTimeout)
+	if err != nil || timeout <= 0 {
+		logrus.Warnf("[-] Invalid shutdown timeout %q, using %s", cfg.ShutdownTimeout, defaultShutdownTimeout)
+		return defaultShutdownTimeout
+	}
+	return timeout
+}
+
+// run stops the node in order:
+//  1. stops accepting API and worker requests, and drains the requests in flight until the shutdown timeout,
+//  2. broadcasts a signed leave event, so that peers stop sending work to this node,
+//  3. stops the node services and closes the audit, API key, usage, resolver cache and blockchain stores,
+//  4. closes the libp2p host and flushes the trace spans not exported yet.
+func (s *shutdown) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout(s.cfg))
+	defer cancel()
+
+	if s.apiServer != nil {
+		if err := s.apiServer.Shutdown(ctx); err != nil {
+			logrus.Warnf("[-] API requests still in flight after the shutdown timeout: %v", err)
+			_ = s.apiServer.Close()
+		}
+	}
+	if s.grpcServer != nil {
+		if err := s.grpcServer.Shutdown(ctx); err != nil {
+			logrus.Warnf("[-] gRPC calls still in flight after the shutdown timeout: %v", err)
+		}
+	}
+	if err := s.workers.Drain(ctx); err != nil {
+		logrus.Warnf("[-] Work requests still in flight after the shutdown timeout: %v", err)
+	}
+	logrus.Info("[+] Stopped accepting requests")
+
+	leaveCtx, leaveCancel := context.WithTimeout(context.Background(), leaveTimeout)
+	if err := s.node.Leave(leaveCtx); err != nil {
+		logrus.Errorf("[-] Error broadcasting the leave event: %v", err)
+	}
+	leaveCancel()
+
+	s.cancel()
+	if s.cfg.TelegramStop != nil {
+		if err := s.cfg.TelegramStop(); err != nil {
+			logrus.Errorf("Error stopping the background connection: %v", err)
+		}
+	}
+
+	if err := s.workers.Close(); err != nil {
+		logrus.Errorf("[-] Error closing the audit store: %v", err)
+	}
+	if s.apiKeys != nil {
+		if err := s.apiKeys.Close(); err != nil {
+			logrus.Errorf("[-] Error closing the API key store: %v", err)
+		}
+	}
+	if s.limiter != nil {
+		if err := s.limiter.Usage().Close(); err != nil {
+			logrus.Errorf("[-] Error closing the usage store: %v", err)
+		}
+	}
+	if err := db.CloseResolverCache(); err != nil {
+		logrus.Errorf("[-] Error closing the resolver cache: %v", err)
+	}
+	if err := s.node.Close(); err != nil {
+		logrus.Errorf("[-] Error closing the node: %v", err)
+	}
+	if s.tracing != nil {
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+		if err := s.tracing(flushCtx); err != nil {
+			logrus.Errorf("[-] Error exporting the trace spans: %v", err)
+		}
+		flushCancel()
+	}
+	logrus.Info("[+] Node stopped")
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"path/filepath"
+
+	"github.com/masa-finance/masa-oracle/pkg/api"
+	"github.com/masa-finance/masa-oracle/pkg/config"
+)
+
+// apiTLSConfig returns the TLS configuration of the API servers and the scopes granted to client
+// certificates, or a nil configuration if TLS is disabled. Without a configured certificate and key,
+// a self-signed pair is generated in the masa directory.
+func apiTLSConfig(cfg *config.AppConfig) (*tls.Config, api.ClientCertScopes, error) {
+	if !cfg.APITLS {
+		if cfg.APIClientCA != "" || cfg.APIClientScopes != "" {
+			return nil, nil, fmt.Errorf("client certificates require API_TLS_ENABLED=true")
+		}
+		return nil, nil, nil
+	}
+	if cfg.APIClientScopes != "" && cfg.APIClientCA == "" {
+		return nil, nil, fmt.Errorf("mapping client certificates to scopes requires a client CA bundle (API_TLS_CLIENT_CA)")
+	}
+
+	certFile, keyFile := cfg.APITLSCert, cfg.APITLSKey
+	switch {
+	case certFile == "" && keyFile == "":
+		certFile = filepath.Join(cfg.MasaDir, "api_cert.pem")
+		keyFile = filepath.Join(cfg.MasaDir, "api_key.pem")
+		if err := api.EnsureSelfSignedCert(certFile, keyFile); err != nil {
+			return nil, nil, err
+		}
+	case certFile == "" || keyFile == "":
+		return nil, nil, fmt.Errorf("both API_TLS_CERT and API_TLS_KEY must be set")
+	}
+
+	tlsConfig, err := api.TLSConfig(certFile, keyFile, cfg.APIClientCA)
+	if err != nil {
+		return nil, nil, err
+	}
+	scopes, err := api.ParseClientCertScopes(cfg.APIClientScopes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tlsConfig, scopes, nil
+}
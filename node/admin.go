@@ -0,0 +1,77 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sirupsen/logrus"
+
+	myNetwork "github.com/masa-finance/masa-oracle/pkg/network"
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+)
+
+// BanPeer bans a peer at the connection level and closes the connections to it.
+// A zero ttl bans the peer until it is unbanned.
+func (node *OracleNode) BanPeer(id peer.ID, reason string, ttl time.Duration) (myNetwork.Ban, error) {
+	if id == node.Host.ID() {
+		return myNetwork.Ban{}, fmt.Errorf("cannot ban this node")
+	}
+	ban, err := node.Options.BanList.Ban(id, reason, ttl)
+	if err != nil {
+		return ban, err
+	}
+	if err := node.Host.Network().ClosePeer(id); err != nil {
+		logrus.Warnf("[-] Error closing the connections to banned peer %s: %v", id, err)
+	}
+	return ban, nil
+}
+
+// SetWorkerCapability updates whether the node advertises that it can do work of a category,
+// and announces its node data to the network. The work handlers are registered separately.
+// Concurrent updates are serialized, so the announced node data matches the final flags.
+func (node *OracleNode) SetWorkerCapability(category pubsub.WorkerCategory, enabled bool) error {
+	node.capabilitiesMu.Lock()
+	defer node.capabilitiesMu.Unlock()
+
+	switch category {
+	case pubsub.CategoryTwitter:
+		node.Options.IsTwitterScraper = enabled
+	case pubsub.CategoryWeb:
+		node.Options.IsWebScraper = enabled
+	default:
+		return fmt.Errorf("unsupported worker capability: %s", category)
+	}
+
+	if err := node.NodeTracker.UpdateNodeDataCapabilities(node.Host.ID().String(), node.Options.IsTwitterScraper, node.Options.IsWebScraper); err != nil {
+		return err
+	}
+	return node.AnnounceNodeData()
+}
+
+// AnnounceNodeData publishes the node data of this node on the gossip topic.
+func (node *OracleNode) AnnounceNodeData() error {
+	nodeData := node.NodeTracker.GetNodeData(node.Host.ID().String())
+	if nodeData == nil {
+		return fmt.Errorf("no node data for this node")
+	}
+	jsonData, err := json.Marshal(nodeData)
+	if err != nil {
+		return err
+	}
+	return node.PublishTopic(node.Options.NodeGossipTopic, jsonData)
+}
+
+// ResyncNodeData announces the node data of this node and sends the node directory to every
+// connected peer, which reconciles it with theirs. It returns the number of peers synced.
+func (node *OracleNode) ResyncNodeData() (int, error) {
+	if err := node.AnnounceNodeData(); err != nil {
+		return 0, err
+	}
+	peers := node.Host.Network().Peers()
+	for _, p := range peers {
+		go node.SendNodeData(p)
+	}
+	return len(peers), nil
+}
@@ -1,17 +1,15 @@
 package node
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	shell "github.com/ipfs/go-ipfs-api"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/masa-finance/masa-oracle/pkg/chain"
 	"github.com/sirupsen/logrus"
@@ -19,148 +17,139 @@ import (
 
 // Blockchain Implementation
 
-type BlockData struct {
-	Block            uint64      `json:"block"`
-	InputData        interface{} `json:"input_data"`
-	TransactionHash  string      `json:"transaction_hash"`
-	PreviousHash     string      `json:"previous_hash"`
-	TransactionNonce int         `json:"nonce"`
-}
-
-type Blocks struct {
-	BlockData []BlockData `json:"blocks"`
-}
-
-type BlockEvents map[string]interface{}
+const (
+	// blockQueueSize is the number of received blocks waiting to be imported.
+	blockQueueSize = 64
+	// maxOrphanBlocks is the number of blocks kept while their parent block is missing.
+	maxOrphanBlocks = 256
+	// voteQueueSize is the number of received finality votes waiting to be counted.
+	voteQueueSize = 256
+)
 
+// BlockEventTracker imports the blocks published by validators on the block topic, and the
+// validator set records published by the governance peer on the validator set topic. Validators
+// vote for the last block of their chain on the vote topic, and count the votes of the others to
+// finalize blocks.
 type BlockEventTracker struct {
-	BlockEvents []BlockEvents
-	mu          sync.Mutex
-	blocksCh    chan *pubsub.Message
+	blocksCh       chan *chain.Block
+	validatorSetCh chan chain.ValidatorSetRecord
+	votesCh        chan chain.Vote
+	importMu       sync.Mutex                // held while importing blocks, guards the orphans
+	orphans        map[string][]*chain.Block // by hex encoded parent hash
+	orphanCount    int
+	syncRunning    atomic.Bool // whether the chain is being synced from a peer
+	validators     []string
+	governance     string
+	voteTopic      string
 }
 
-func NewBlockChain() *BlockEventTracker {
+// NewBlockChain creates the tracker of the blockchain. Only the given validators may produce
+// blocks, or only this node if there are none, until the governance peer changes them.
+func NewBlockChain(validators []string, governance string) *BlockEventTracker {
 	return &BlockEventTracker{
-		blocksCh: make(chan *pubsub.Message),
+		blocksCh:       make(chan *chain.Block, blockQueueSize),
+		validatorSetCh: make(chan chain.ValidatorSetRecord, 1),
+		votesCh:        make(chan chain.Vote, voteQueueSize),
+		orphans:        make(map[string][]*chain.Block),
+		validators:     validators,
+		governance:     governance,
 	}
 }
 
-// HandleMessage processes incoming pubsub messages containing block events.
-// It unmarshals the message data into a slice of BlockEvents and appends them
-// to the tracker's BlockEvents slice.
+// HandleMessage queues the block carried by a pubsub message for import. Messages that
+// aren't blocks are dropped.
 func (b *BlockEventTracker) HandleMessage(m *pubsub.Message) {
-	var blockEvents any
-
-	// Try to decode as base64 first
-	decodedData, err := base64.StdEncoding.DecodeString(string(m.Data))
-	if err == nil {
-		m.Data = decodedData
-	}
-
-	// Try to unmarshal as JSON
-	err = json.Unmarshal(m.Data, &blockEvents)
-	if err != nil {
-		// If JSON unmarshal fails, try to interpret as string
-		blockEvents = string(m.Data)
+	block := &chain.Block{}
+	if err := json.Unmarshal(m.Data, block); err != nil || len(block.Hash) == 0 {
+		logrus.Debugf("[-] Dropping a block topic message from %s that isn't a block", m.ReceivedFrom)
+		return
 	}
 
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	switch v := blockEvents.(type) {
-	case []BlockEvents:
-		b.BlockEvents = append(b.BlockEvents, v...)
-	case BlockEvents:
-		b.BlockEvents = append(b.BlockEvents, v)
-	case map[string]interface{}:
-		// Convert map to BlockEvents struct
-		newBlockEvent := BlockEvents(v)
-		// You might need to add logic here to properly convert the map to BlockEvents
-		b.BlockEvents = append(b.BlockEvents, newBlockEvent)
-	case []interface{}:
-		// Convert each item in the slice to BlockEvents
-		for _, item := range v {
-			if be, ok := item.(BlockEvents); ok {
-				b.BlockEvents = append(b.BlockEvents, be)
-			}
-		}
-	case string:
-		// Handle string data
-		newBlockEvent := BlockEvents{}
-		// You might need to add logic here to properly convert the string to BlockEvents
-		b.BlockEvents = append(b.BlockEvents, newBlockEvent)
+	select {
+	case b.blocksCh <- block:
 	default:
-		logrus.Warnf("[-] Unexpected data type in message: %v", reflect.TypeOf(v))
+		logrus.Warnf("[-] Block queue full, dropping block %d %x", block.Block, block.Hash)
 	}
-
-	b.blocksCh <- m
 }
 
-func updateBlocks(ctx context.Context, node *OracleNode) error {
-
-	var existingBlocks Blocks
-	blocks := chain.GetBlockchain(node.Blockchain)
+// ValidatorSetHandler returns the handler of the validator set topic, which queues the
+// governance records for the chain.
+func (b *BlockEventTracker) ValidatorSetHandler() *ValidatorSetHandler {
+	return &ValidatorSetHandler{tracker: b}
+}
 
-	for _, block := range blocks {
-		var inputData interface{}
-		err := json.Unmarshal(block.Data, &inputData)
-		if err != nil {
-			inputData = string(block.Data) // Fallback to string if unmarshal fails
-		}
+// ValidatorSetHandler handles the validator set records published on the validator set topic.
+type ValidatorSetHandler struct {
+	tracker *BlockEventTracker
+}
 
-		blockData := BlockData{
-			Block:            block.Block,
-			InputData:        base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%v", inputData))),
-			TransactionHash:  fmt.Sprintf("%x", block.Hash),
-			PreviousHash:     fmt.Sprintf("%x", block.Link),
-			TransactionNonce: int(block.Nonce),
-		}
-		existingBlocks.BlockData = append(existingBlocks.BlockData, blockData)
-	}
-	jsonData, err := json.Marshal(existingBlocks)
-	if err != nil {
-		return err
+// HandleMessage queues the validator set record carried by a pubsub message. Messages that
+// aren't records are dropped; the signature is checked when the record is applied.
+func (h *ValidatorSetHandler) HandleMessage(m *pubsub.Message) {
+	var record chain.ValidatorSetRecord
+	if err := json.Unmarshal(m.Data, &record); err != nil || len(record.Signature) == 0 {
+		logrus.Debugf("[-] Dropping a validator set topic message from %s that isn't a record", m.ReceivedFrom)
+		return
 	}
 
-	err = node.DHT.PutValue(ctx, "/db/blocks", jsonData)
-	if err != nil {
-		logrus.Warningf("[-] Unable to store block on DHT: %v", err)
+	select {
+	case h.tracker.validatorSetCh <- record:
+	default:
+		logrus.Warnf("[-] Validator set queue full, dropping record version %d", record.Version)
 	}
+}
 
-	if os.Getenv("IPFS_URL") != "" {
-
-		infuraURL := fmt.Sprintf("https://%s:%s@%s", os.Getenv("PID"), os.Getenv("PS"), os.Getenv("IPFS_URL"))
-		sh := shell.NewShell(infuraURL)
-
-		jsonBytes, err := json.Marshal(jsonData)
-		if err != nil {
-			logrus.Errorf("[-] Error marshalling JSON: %s", err)
-		}
-
-		reader := bytes.NewReader(jsonBytes)
+// VoteHandler returns the handler of the vote topic, which queues the finality votes of the
+// validators for the chain. The votes of this node are published on the same topic.
+func (b *BlockEventTracker) VoteHandler(topic string) *VoteHandler {
+	b.voteTopic = topic
+	return &VoteHandler{tracker: b}
+}
 
-		hash, err := sh.AddWithOpts(reader, true, true)
-		if err != nil {
-			logrus.Errorf("[-] Error persisting to IPFS: %s", err)
-		} else {
-			logrus.Printf("[+] Ledger persisted with IPFS hash: https://dwn.infura-ipfs.io/ipfs/%s\n", hash)
-			_ = node.DHT.PutValue(ctx, "/db/ipfs", []byte(fmt.Sprintf("https://dwn.infura-ipfs.io/ipfs/%s", hash)))
+// VoteHandler handles the finality votes published on the vote topic.
+type VoteHandler struct {
+	tracker *BlockEventTracker
+}
 
-		}
+// HandleMessage queues the vote carried by a pubsub message. Messages that aren't votes are
+// dropped; the signature is checked when the vote is counted.
+func (h *VoteHandler) HandleMessage(m *pubsub.Message) {
+	var vote chain.Vote
+	if err := json.Unmarshal(m.Data, &vote); err != nil || len(vote.Hash) == 0 {
+		logrus.Debugf("[-] Dropping a vote topic message from %s that isn't a vote", m.ReceivedFrom)
+		return
 	}
 
-	return nil
+	select {
+	case h.tracker.votesCh <- vote:
+	default:
+		logrus.Warnf("[-] Vote queue full, dropping the vote of %s for block %d", vote.Voter, vote.Block)
+	}
 }
 
 func (b *BlockEventTracker) Start(path string) func(ctx context.Context, node *OracleNode) {
 	return func(ctx context.Context, node *OracleNode) {
-		err := node.Blockchain.Init(path)
+		validators := b.validators
+		if len(validators) == 0 {
+			logrus.Warn("[-] No validator set configured, only blocks produced by this node are accepted")
+			validators = []string{node.Host.ID().String()}
+		}
+		validatorSet, err := chain.NewValidatorSet(validators, b.governance)
+		if err != nil {
+			logrus.Errorf("[-] Invalid validator set: %v", err)
+			return
+		}
+		node.Blockchain.SetValidators(validatorSet)
+
+		err = node.Blockchain.Init(path)
 		if err != nil {
 			logrus.Error(err)
 		}
 
-		updateTicker := time.NewTicker(time.Second * 60)
-		defer updateTicker.Stop()
+		b.startSync(ctx, node)
+
+		syncTicker := time.NewTicker(syncInterval)
+		defer syncTicker.Stop()
 
 		for {
 			select {
@@ -169,18 +158,30 @@ func (b *BlockEventTracker) Start(path string) func(ctx context.Context, node *O
 					logrus.Error("[-] Block channel closed")
 					return
 				}
-				if err := processBlock(node, block); err != nil {
+				if err := b.processBlock(node, block); err != nil {
 					logrus.Errorf("[-] Error processing block: %v", err)
 					// Consider adding a retry mechanism or circuit breaker here
 				}
+				if err := b.voteForHead(node); err != nil {
+					logrus.Errorf("[-] Error voting for the last block: %v", err)
+				}
 
-			case <-updateTicker.C:
-				logrus.Info("[+] blockchain tick")
-				if err := updateBlocks(ctx, node); err != nil {
-					logrus.Errorf("[-] Error updating blocks: %v", err)
-					// Consider adding a retry mechanism or circuit breaker here
+			case vote := <-b.votesCh:
+				if _, err := node.Blockchain.AddVote(vote); err != nil {
+					logrus.Debugf("[-] Rejected the vote of %s for block %d: %v", vote.Voter, vote.Block, err)
+				} else if err := b.voteForHead(node); err != nil {
+					// The votes of the other validators can release the last vote of this node
+					logrus.Errorf("[-] Error voting for the last block: %v", err)
 				}
 
+			case record := <-b.validatorSetCh:
+				if err := node.Blockchain.ApplyValidatorSet(record); err != nil {
+					logrus.Warnf("[-] Rejected validator set version %d: %v", record.Version, err)
+				}
+
+			case <-syncTicker.C:
+				b.startSync(ctx, node)
+
 			case <-ctx.Done():
 				logrus.Info("[+] Context cancelled, stopping block subscription")
 				return
@@ -189,27 +190,89 @@ func (b *BlockEventTracker) Start(path string) func(ctx context.Context, node *O
 	}
 }
 
-func processBlock(node *OracleNode, block *pubsub.Message) error {
-	blocks := chain.GetBlockchain(node.Blockchain)
-	for _, b := range blocks {
-		if string(b.Data) == string(block.Data) {
-			return nil // Block already exists
+// startSync syncs the chain in the background, so that blocks and votes are still handled while
+// it runs. Nothing happens if the previous sync is still running.
+func (b *BlockEventTracker) startSync(ctx context.Context, node *OracleNode) {
+	if !b.syncRunning.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer b.syncRunning.Store(false)
+		if err := b.syncChain(ctx, node); err != nil {
+			logrus.Errorf("[-] Error syncing the chain: %v", err)
+		}
+	}()
+}
+
+// processBlock imports a block, and then the blocks received earlier that were waiting for it.
+// A block whose parent is missing is kept until its parent is received.
+func (b *BlockEventTracker) processBlock(node *OracleNode, block *chain.Block) error {
+	b.importMu.Lock()
+	defer b.importMu.Unlock()
+	queue := []*chain.Block{block}
+	for len(queue) > 0 {
+		block, queue = queue[0], queue[1:]
+		result, err := node.Blockchain.ImportBlock(block)
+		if errors.Is(err, chain.ErrUnknownParent) {
+			b.addOrphan(block)
+			logrus.Debugf("[-] Waiting for the parent of block %d %x", block.Block, block.Hash)
+			continue
 		}
+		if err != nil {
+			return fmt.Errorf("[-] failed to import block %d %x: %w", block.Block, block.Hash, err)
+		}
+		if result.Known {
+			continue
+		}
+		if result.Head {
+			block.Print()
+		}
+		queue = append(queue, b.takeOrphans(block.Hash)...)
 	}
+	return nil
+}
 
-	// XXX: We miss here the logic to check if the block is actually valid
-	// and built AFTER our current block (!)
-	if err := node.Blockchain.AddBlock(block.Data); err != nil {
-		return fmt.Errorf("[-] failed to add block: %w", err)
+// voteForHead votes for the last block of the chain if this node is a validator and may vote for
+// it, see chain.VoteForHead.
+func (b *BlockEventTracker) voteForHead(node *OracleNode) error {
+	self := node.Host.ID()
+	if b.voteTopic == "" || !node.Blockchain.Validators().Contains(self.String()) {
+		return nil
+	}
+	vote, err := node.Blockchain.VoteForHead(node.Host.Peerstore().PrivKey(self))
+	if err != nil || vote == nil {
+		return err
+	}
+	if _, err := node.Blockchain.AddVote(*vote); err != nil {
+		return err
+	}
+	data, err := json.Marshal(vote)
+	if err != nil {
+		return err
 	}
+	return node.PublishTopic(b.voteTopic, data)
+}
 
-	if node.Blockchain.LastHash != nil {
-		b, err := node.Blockchain.GetBlock(node.Blockchain.LastHash)
-		if err != nil {
-			return fmt.Errorf("[-] failed to get last block: %w", err)
+// addOrphan keeps a block until its parent is imported. When there are too many, the orphans of
+// another parent are dropped to make room.
+func (b *BlockEventTracker) addOrphan(block *chain.Block) {
+	if b.orphanCount >= maxOrphanBlocks {
+		for parent, blocks := range b.orphans {
+			b.orphanCount -= len(blocks)
+			delete(b.orphans, parent)
+			break
 		}
-		b.Print()
 	}
+	parent := hex.EncodeToString(block.Link)
+	b.orphans[parent] = append(b.orphans[parent], block)
+	b.orphanCount++
+}
 
-	return nil
+// takeOrphans returns and forgets the blocks waiting for the given parent.
+func (b *BlockEventTracker) takeOrphans(parent []byte) []*chain.Block {
+	key := hex.EncodeToString(parent)
+	blocks := b.orphans[key]
+	delete(b.orphans, key)
+	b.orphanCount -= len(blocks)
+	return blocks
 }
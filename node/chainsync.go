@@ -0,0 +1,423 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/masa-finance/masa-oracle/pkg/chain"
+	myNetwork "github.com/masa-finance/masa-oracle/pkg/network"
+)
+
+const (
+	// maxSyncBlocks is the number of blocks returned for a chain sync request.
+	maxSyncBlocks = 128
+	// maxSyncBlockData is the size of the block data returned for a chain sync request, which
+	// keeps the response below the message size limit once encoded.
+	maxSyncBlockData = myNetwork.MaxMessageSize / 2
+	// maxSyncForkDepth is the number of blocks fetched by hash to find where the chain of a peer forks from ours.
+	maxSyncForkDepth = 1024
+	// syncTimeout bounds each chain sync request.
+	syncTimeout = 30 * time.Second
+	// syncInterval is how often validators ask their peers whether they are missing blocks.
+	syncInterval = 30 * time.Second
+)
+
+var (
+	syncHeight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "masa_chain_height",
+		Help: "Number of the last block of the chain.",
+	})
+	syncTargetHeight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "masa_chain_sync_target_height",
+		Help: "Number of the last block of the peer the chain is synced from.",
+	})
+	syncing = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "masa_chain_syncing",
+		Help: "1 while the chain is synced from a peer.",
+	})
+	syncedBlocks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "masa_chain_sync_blocks_total",
+		Help: "Number of blocks fetched from peers while syncing the chain.",
+	})
+	syncErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "masa_chain_sync_errors_total",
+		Help: "Number of chain syncs that failed.",
+	})
+)
+
+// ChainSyncRequest asks a peer for the last block of its chain and, if Count is set, for blocks
+// of its chain starting with the block numbered From, or ending with the block with the given Hash.
+type ChainSyncRequest struct {
+	From  uint64 `json:"from,omitempty"`
+	Hash  []byte `json:"hash,omitempty"`
+	Count int    `json:"count,omitempty"`
+}
+
+// ChainSyncResponse is the last block and the last finalized block of the chain of a peer, with
+// the requested blocks in order and the finality certificates of those that have one. Without
+// requested blocks, it holds the last block itself, so that its height can be checked, and the
+// validator set records applied to the chain, which are needed to check its blocks.
+type ChainSyncResponse struct {
+	Height          uint64                     `json:"height"`
+	Hash            []byte                     `json:"hash"`
+	FinalizedHeight uint64                     `json:"finalizedHeight"`
+	FinalizedHash   []byte                     `json:"finalizedHash"`
+	Head            *chain.Block               `json:"head,omitempty"`
+	ValidatorSets   []chain.ValidatorSetRecord `json:"validatorSets,omitempty"`
+	Blocks          []*chain.Block             `json:"blocks,omitempty"`
+	Certificates    []*chain.Certificate       `json:"certificates,omitempty"`
+	Error           string                     `json:"error,omitempty"`
+}
+
+// SyncStatus describes the progress of the chain sync.
+type SyncStatus struct {
+	Syncing      bool      `json:"syncing"`
+	Peer         string    `json:"peer,omitempty"`
+	Height       uint64    `json:"height"`
+	TargetHeight uint64    `json:"targetHeight"`
+	BlocksSynced uint64    `json:"blocksSynced"`
+	LastSync     time.Time `json:"lastSync,omitempty"`
+	LastError    string    `json:"lastError,omitempty"`
+}
+
+// SyncProgress tracks the progress of the chain sync. It is safe for concurrent use.
+type SyncProgress struct {
+	mu     sync.Mutex
+	status SyncStatus
+}
+
+// Status returns the progress of the chain sync.
+func (p *SyncProgress) Status() SyncStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.status
+}
+
+// start records that the chain is synced from a peer.
+func (p *SyncProgress) start(peerID peer.ID, height, target uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status.Syncing = true
+	p.status.Peer = peerID.String()
+	p.status.Height = height
+	p.status.TargetHeight = target
+	syncing.Set(1)
+	syncHeight.Set(float64(height))
+	syncTargetHeight.Set(float64(target))
+}
+
+// progress records the blocks synced and the new height of the chain.
+func (p *SyncProgress) progress(height uint64, blocks int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status.Height = height
+	p.status.BlocksSynced += uint64(blocks)
+	syncHeight.Set(float64(height))
+	syncedBlocks.Add(float64(blocks))
+}
+
+// finish records the end of a sync, and its error if it failed.
+func (p *SyncProgress) finish(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status.Syncing = false
+	p.status.LastSync = time.Now()
+	p.status.LastError = ""
+	if err != nil {
+		p.status.LastError = err.Error()
+		syncErrors.Inc()
+	}
+	syncing.Set(0)
+}
+
+// HandleChainSyncStream answers the chain sync requests of a peer.
+func (node *OracleNode) HandleChainSyncStream(stream network.Stream) {
+	defer func(stream network.Stream) {
+		if err := stream.Close(); err != nil {
+			logrus.Debugf("[-] Error closing chain sync stream: %v", err)
+		}
+	}(stream)
+	_ = stream.SetDeadline(time.Now().Add(syncTimeout))
+
+	var req ChainSyncRequest
+	if err := myNetwork.ReadMessage(stream, &req); err != nil {
+		logrus.Debugf("[-] Error reading chain sync request from %s: %v", stream.Conn().RemotePeer(), err)
+		return
+	}
+	resp, err := node.chainSyncResponse(req)
+	if err != nil {
+		resp = &ChainSyncResponse{Error: err.Error()}
+	}
+	if err := myNetwork.WriteMessage(stream, resp); err != nil {
+		logrus.Debugf("[-] Error writing chain sync response to %s: %v", stream.Conn().RemotePeer(), err)
+	}
+}
+
+// chainSyncResponse returns the last block of the chain and the requested blocks.
+func (node *OracleNode) chainSyncResponse(req ChainSyncRequest) (*ChainSyncResponse, error) {
+	last, err := node.Blockchain.GetLastBlock()
+	if err != nil {
+		return nil, err
+	}
+	resp := &ChainSyncResponse{Height: last.Block, Hash: last.Hash}
+	resp.FinalizedHeight, resp.FinalizedHash = node.Blockchain.Finalized()
+	if req.Count <= 0 {
+		resp.Head = last
+		resp.ValidatorSets, err = node.Blockchain.ValidatorSetRecords()
+		return resp, err
+	}
+
+	count := min(req.Count, maxSyncBlocks)
+	var blocks []*chain.Block
+	if len(req.Hash) > 0 {
+		blocks, err = node.Blockchain.GetBlocksByHash(req.Hash, count)
+	} else {
+		blocks, err = node.Blockchain.GetBlockRange(req.From, req.From+uint64(count)-1)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Blocks ending with the hash are needed from the last one, others from the first one
+	size := 0
+	for i := range blocks {
+		j := i
+		if len(req.Hash) > 0 {
+			j = len(blocks) - 1 - i
+		}
+		size += blocks[j].PayloadSize()
+		if i > 0 && size > maxSyncBlockData {
+			if len(req.Hash) > 0 {
+				blocks = blocks[j+1:]
+			} else {
+				blocks = blocks[:j]
+			}
+			break
+		}
+	}
+	resp.Blocks = blocks
+	for _, block := range blocks {
+		certificate, err := node.Blockchain.GetCertificate(block.Hash)
+		if err != nil {
+			return nil, err
+		}
+		if certificate != nil {
+			resp.Certificates = append(resp.Certificates, certificate)
+		}
+	}
+	return resp, nil
+}
+
+// requestChainSync sends a chain sync request to a peer.
+func (node *OracleNode) requestChainSync(ctx context.Context, peerID peer.ID, req ChainSyncRequest) (*ChainSyncResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+	stream, err := node.Host.NewStream(ctx, peerID, node.protocolWithVersion(node.Options.ChainSyncProtocol))
+	if err != nil {
+		return nil, err
+	}
+	defer func(stream network.Stream) {
+		if err := stream.Close(); err != nil {
+			logrus.Debugf("[-] Error closing chain sync stream: %v", err)
+		}
+	}(stream)
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = stream.SetDeadline(deadline)
+	}
+
+	if err := myNetwork.WriteMessage(stream, req); err != nil {
+		return nil, err
+	}
+	var resp ChainSyncResponse
+	if err := myNetwork.ReadMessage(stream, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("chain sync request to %s failed: %s", peerID, resp.Error)
+	}
+	return &resp, nil
+}
+
+// chainSyncPeers returns the connected peers that serve their chain.
+func (node *OracleNode) chainSyncPeers() []peer.ID {
+	protocolID := node.protocolWithVersion(node.Options.ChainSyncProtocol)
+	var peers []peer.ID
+	for _, p := range node.Host.Network().Peers() {
+		if supported, err := node.Host.Peerstore().SupportsProtocols(p, protocolID); err == nil && len(supported) > 0 {
+			peers = append(peers, p)
+		}
+	}
+	return peers
+}
+
+// syncTip is the last block of the chain of a peer.
+type syncTip struct {
+	peer peer.ID
+	tip  *ChainSyncResponse
+}
+
+// syncChain fetches the blocks this node is missing from the peer with the longest chain, and
+// imports them in order. The last block each peer claims must be signed by a validator of the set
+// in effect at its height; if syncing from the peer with the longest chain fails, the chain is
+// synced from the next one.
+func (b *BlockEventTracker) syncChain(ctx context.Context, node *OracleNode) error {
+	if node.Options.ChainSyncProtocol == "" {
+		return nil
+	}
+	last, err := node.Blockchain.GetLastBlock()
+	if err != nil {
+		return err
+	}
+	syncHeight.Set(float64(last.Block))
+
+	var tips []syncTip
+	for _, p := range node.chainSyncPeers() {
+		tip, err := node.requestChainSync(ctx, p, ChainSyncRequest{})
+		if err != nil {
+			logrus.Debugf("[-] Failed to get the last block of %s: %v", p, err)
+			continue
+		}
+		applyValidatorSets(node, p, tip.ValidatorSets)
+		if tip.Height <= last.Block {
+			continue
+		}
+		if err := checkTip(node, tip); err != nil {
+			logrus.Warnf("[-] Not syncing from %s: %v", p, err)
+			continue
+		}
+		tips = append(tips, syncTip{peer: p, tip: tip})
+	}
+	sort.SliceStable(tips, func(i, j int) bool { return tips[i].tip.Height > tips[j].tip.Height })
+
+	for _, t := range tips {
+		if err = b.syncFrom(ctx, node, t.peer, t.tip); err == nil {
+			return nil
+		}
+		logrus.Warnf("[-] Failed to sync the chain from %s: %v", t.peer, err)
+	}
+	return err
+}
+
+// checkTip checks that the last block claimed by a peer is the one described by its response,
+// and signed by a validator of the set in effect at its height.
+func checkTip(node *OracleNode, tip *ChainSyncResponse) error {
+	if tip.Head == nil {
+		return fmt.Errorf("no last block")
+	}
+	if tip.Head.Block != tip.Height || !bytes.Equal(tip.Head.Hash, tip.Hash) {
+		return fmt.Errorf("the last block %d %x doesn't match the claimed block %d %x", tip.Head.Block, tip.Head.Hash, tip.Height, tip.Hash)
+	}
+	return node.Blockchain.CheckBlock(tip.Head)
+}
+
+// syncFrom fetches the blocks this node is missing from a peer, up to its last block. Blocks are
+// requested by height from the last block of this chain; if the chain of the peer forks from it,
+// the blocks of the fork are requested by hash until a stored ancestor is found.
+func (b *BlockEventTracker) syncFrom(ctx context.Context, node *OracleNode, peerID peer.ID, tip *ChainSyncResponse) (err error) {
+	last, err := node.Blockchain.GetLastBlock()
+	if err != nil {
+		return err
+	}
+	if tip.Height <= last.Block {
+		return nil
+	}
+
+	logrus.Infof("[+] Syncing the chain from %s, from block %d to %d", peerID, last.Block, tip.Height)
+	node.ChainSync.start(peerID, last.Block, tip.Height)
+	defer func() { node.ChainSync.finish(err) }()
+
+	from := last.Block + 1
+	for from <= tip.Height {
+		resp, err := node.requestChainSync(ctx, peerID, ChainSyncRequest{From: from, Count: maxSyncBlocks})
+		if err != nil {
+			return err
+		}
+		if len(resp.Blocks) == 0 || resp.Blocks[0].Block != from {
+			return fmt.Errorf("%s didn't return block %d of its chain up to block %d", peerID, from, tip.Height)
+		}
+		blocks, certificates := resp.Blocks, resp.Certificates
+		known, err := node.Blockchain.HasBlock(blocks[0].Link)
+		if err != nil {
+			return err
+		}
+		if !known {
+			fork, forkCertificates, err := b.syncFork(ctx, node, peerID, blocks[0].Link)
+			if err != nil {
+				return err
+			}
+			blocks = append(fork, blocks...)
+			certificates = append(forkCertificates, certificates...)
+		}
+		for _, block := range blocks {
+			if err := b.processBlock(node, block); err != nil {
+				return err
+			}
+		}
+		for _, certificate := range certificates {
+			if err := node.Blockchain.ImportCertificate(certificate); err != nil {
+				logrus.Warnf("[-] Rejected the finality certificate of block %d from %s: %v", certificate.Block, peerID, err)
+			}
+		}
+		from = resp.Blocks[len(resp.Blocks)-1].Block + 1
+		node.ChainSync.progress(from-1, len(blocks))
+	}
+	logrus.Infof("[+] Chain synced from %s up to block %d", peerID, from-1)
+	return nil
+}
+
+// applyValidatorSets applies the validator set records of a peer that this node is missing, e.g.
+// those applied before it joined. The records are signed by the governance peer.
+func applyValidatorSets(node *OracleNode, peerID peer.ID, records []chain.ValidatorSetRecord) {
+	for _, record := range records {
+		if record.Version <= node.Blockchain.Validators().Version() {
+			continue
+		}
+		if err := node.Blockchain.ApplyValidatorSet(record); err != nil {
+			logrus.Warnf("[-] Rejected validator set version %d from %s: %v", record.Version, peerID, err)
+		}
+	}
+}
+
+// syncFork fetches the blocks of a peer ending with the given hash, back to a block whose parent
+// is stored, along with their finality certificates.
+func (b *BlockEventTracker) syncFork(ctx context.Context, node *OracleNode, peerID peer.ID, hash []byte) ([]*chain.Block, []*chain.Certificate, error) {
+	var fork []*chain.Block
+	var certificates []*chain.Certificate
+	for len(fork) < maxSyncForkDepth {
+		resp, err := node.requestChainSync(ctx, peerID, ChainSyncRequest{Hash: hash, Count: maxSyncBlocks})
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(resp.Blocks) == 0 || !bytes.Equal(resp.Blocks[len(resp.Blocks)-1].Hash, hash) {
+			return nil, nil, fmt.Errorf("%s didn't return block %x", peerID, hash)
+		}
+		blocks := resp.Blocks
+		certificates = append(resp.Certificates, certificates...)
+		for i := len(blocks) - 1; i >= 0; i-- {
+			fork = append([]*chain.Block{blocks[i]}, fork...)
+			known, err := node.Blockchain.HasBlock(blocks[i].Link)
+			if err != nil {
+				return nil, nil, err
+			}
+			if known {
+				return fork, certificates, nil
+			}
+		}
+		first := blocks[0]
+		if len(first.Link) == 0 {
+			return nil, nil, fmt.Errorf("the chain of %s has another genesis block", peerID)
+		}
+		hash = first.Link
+	}
+	return nil, nil, fmt.Errorf("the chain of %s forks more than %d blocks back, from %s", peerID, maxSyncForkDepth, hex.EncodeToString(hash))
+}
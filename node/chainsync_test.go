@@ -0,0 +1,113 @@
+package node
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/masa-finance/masa-oracle/pkg/chain"
+	myNetwork "github.com/masa-finance/masa-oracle/pkg/network"
+)
+
+func TestSyncChain(t *testing.T) {
+	ctx := context.Background()
+	newValidator := func() *OracleNode {
+		node, err := NewOracleNode(ctx, EnableRandomIdentity, EnableTCP, WithPort(0), IsValidator, WithChainSyncProtocol("chainSync"))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = node.Host.Close() })
+		node.Host.SetStreamHandler(node.protocolWithVersion(node.Options.ChainSyncProtocol), node.HandleChainSyncStream)
+		return node
+	}
+	a, b := newValidator(), newValidator()
+	governanceKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	governance, err := peer.IDFromPrivateKey(governanceKey)
+	require.NoError(t, err)
+	for _, node := range []*OracleNode{a, b} {
+		validators, err := chain.NewValidatorSet([]string{a.Host.ID().String(), b.Host.ID().String()}, governance.String())
+		require.NoError(t, err)
+		node.Blockchain.SetValidators(validators)
+		require.NoError(t, node.Blockchain.Init(t.TempDir()))
+		t.Cleanup(func() { _ = node.Blockchain.Close() })
+	}
+
+	// a builds a longer chain than b, and finalizes its first block
+	keyA, keyB := a.Host.Peerstore().PrivKey(a.Host.ID()), b.Host.Peerstore().PrivKey(b.Host.ID())
+	require.NoError(t, a.Blockchain.AddBlock([]byte("a1"), keyA))
+	a1, err := a.Blockchain.GetLastBlock()
+	require.NoError(t, err)
+	voteA, err := chain.NewVote(a1, keyA)
+	require.NoError(t, err)
+	voteB, err := chain.NewVote(a1, keyB)
+	require.NoError(t, err)
+	_, err = a.Blockchain.AddVote(*voteA)
+	require.NoError(t, err)
+	certificate, err := a.Blockchain.AddVote(*voteB)
+	require.NoError(t, err)
+	require.NotNil(t, certificate)
+	require.NoError(t, a.Blockchain.AddBlock([]byte("a2"), keyA))
+	// Only a validates from block 3 on, which b learns while syncing
+	record := chain.ValidatorSetRecord{Version: 1, Height: 3, Validators: []string{a.Host.ID().String()}}
+	require.NoError(t, record.Sign(governanceKey))
+	require.NoError(t, a.Blockchain.ApplyValidatorSet(record))
+	require.NoError(t, a.Blockchain.AddBlock([]byte("a3"), keyA))
+	require.NoError(t, b.Blockchain.AddBlock([]byte("b1"), keyB))
+
+	// c claims a longer chain with a block signed by a, but doesn't serve its blocks, and d claims
+	// a much longer chain without showing its last block
+	a4, err := a.Blockchain.BuildBlock([]byte("a4"), keyA)
+	require.NoError(t, err)
+	newLiar := func(tip ChainSyncResponse) *OracleNode {
+		node, err := NewOracleNode(ctx, EnableRandomIdentity, EnableTCP, WithPort(0), WithChainSyncProtocol("chainSync"))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = node.Host.Close() })
+		node.Host.SetStreamHandler(node.protocolWithVersion(node.Options.ChainSyncProtocol), func(stream network.Stream) {
+			defer stream.Close()
+			var req ChainSyncRequest
+			if err := myNetwork.ReadMessage(stream, &req); err != nil {
+				return
+			}
+			resp := tip
+			if req.Count > 0 {
+				resp = ChainSyncResponse{Height: tip.Height, Hash: tip.Hash}
+			}
+			_ = myNetwork.WriteMessage(stream, resp)
+		})
+		return node
+	}
+	c := newLiar(ChainSyncResponse{Height: a4.Block, Hash: a4.Hash, Head: a4})
+	d := newLiar(ChainSyncResponse{Height: 1000, Hash: a4.Hash})
+
+	for _, p := range []*OracleNode{a, c, d} {
+		require.NoError(t, b.Host.Connect(ctx, peer.AddrInfo{ID: p.Host.ID(), Addrs: p.Host.Addrs()}))
+	}
+	require.Eventually(t, func() bool { return len(b.chainSyncPeers()) == 3 }, 5*time.Second, 10*time.Millisecond)
+
+	// b fails to sync from c, then fetches the blocks of a by height, and the fork of a by hash
+	tracker := NewBlockChain(nil, "")
+	require.NoError(t, tracker.syncChain(ctx, b))
+	assert.Equal(t, a.Blockchain.LastHash, b.Blockchain.LastHash)
+	finalized, _ := b.Blockchain.Finalized()
+	assert.EqualValues(t, 1, finalized)
+	assert.EqualValues(t, 1, b.Blockchain.Validators().Version())
+
+	status := b.ChainSync.Status()
+	assert.False(t, status.Syncing)
+	assert.Equal(t, a.Host.ID().String(), status.Peer)
+	assert.EqualValues(t, 3, status.Height)
+	assert.EqualValues(t, 3, status.TargetHeight)
+	assert.EqualValues(t, 3, status.BlocksSynced)
+	assert.Empty(t, status.LastError)
+
+	// Nothing is synced once the chains are the same, c still fails
+	assert.Error(t, tracker.syncChain(ctx, b))
+	assert.Equal(t, a.Blockchain.LastHash, b.Blockchain.LastHash)
+	assert.EqualValues(t, 3, b.ChainSync.Status().BlocksSynced)
+}
@@ -0,0 +1,56 @@
+package node
+
+import (
+	"fmt"
+
+	myNetwork "github.com/masa-finance/masa-oracle/pkg/network"
+)
+
+// CheckListening returns an error if the libp2p host isn't listening on any address.
+func (node *OracleNode) CheckListening() error {
+	if len(node.Host.Network().ListenAddresses()) == 0 {
+		return fmt.Errorf("libp2p host not listening")
+	}
+	return nil
+}
+
+// CheckRoutingTable returns an error if the DHT routing table is empty, i.e. the node can't
+// find peers to send work to.
+func (node *OracleNode) CheckRoutingTable() error {
+	if node.DHT == nil {
+		return fmt.Errorf("DHT not started")
+	}
+	if node.DHT.RoutingTable().Size() == 0 {
+		return fmt.Errorf("DHT routing table empty")
+	}
+	return nil
+}
+
+// CheckBootnodes returns an error if the node isn't connected to any of its bootnodes.
+func (node *OracleNode) CheckBootnodes() error {
+	if !myNetwork.IsConnectedToBootnode(node.Host, node.Options.Bootnodes) {
+		return fmt.Errorf("not connected to any bootnode")
+	}
+	return nil
+}
+
+// CheckSubscriptions returns an error if the node isn't subscribed to one of the topics it
+// needs to follow the network.
+func (node *OracleNode) CheckSubscriptions() error {
+	if node.PubSubManager == nil {
+		return fmt.Errorf("pubsub not started")
+	}
+	topics := []string{node.Options.NodeGossipTopic}
+	if node.Options.NodeLifecycleTopic != "" {
+		topics = append(topics, node.Options.NodeLifecycleTopic)
+	}
+	for _, handler := range node.Options.PubSubHandles {
+		topics = append(topics, handler.ProtocolName)
+	}
+	for _, topic := range topics {
+		if _, err := node.PubSubManager.GetSubscription(node.topicWithVersion(topic)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
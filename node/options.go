@@ -5,6 +5,7 @@ import (
 
 	"github.com/masa-finance/masa-oracle/node/types"
 	"github.com/masa-finance/masa-oracle/pkg/masacrypto"
+	myNetwork "github.com/masa-finance/masa-oracle/pkg/network"
 
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -35,12 +36,15 @@ type NodeOption struct {
 
 	OracleProtocol       string
 	NodeDataSyncProtocol string
+	ChainSyncProtocol    string
 	NodeGossipTopic      string
+	NodeLifecycleTopic   string
 	Rendezvous           string
 	WorkerProtocol       string
 	PageSize             int
 
 	KeyManager *masacrypto.KeyManager
+	BanList    *myNetwork.BanList
 }
 
 type PubSubHandlers struct {
@@ -176,6 +180,13 @@ func WithKeyManager(km *masacrypto.KeyManager) Option {
 	}
 }
 
+// WithBanList blocks connections to and from the peers banned in the list.
+func WithBanList(b *myNetwork.BanList) Option {
+	return func(o *NodeOption) {
+		o.BanList = b
+	}
+}
+
 func WithOracleProtocol(s string) Option {
 	return func(o *NodeOption) {
 		o.OracleProtocol = s
@@ -188,12 +199,24 @@ func WithNodeDataSyncProtocol(s string) Option {
 	}
 }
 
+func WithChainSyncProtocol(s string) Option {
+	return func(o *NodeOption) {
+		o.ChainSyncProtocol = s
+	}
+}
+
 func WithNodeGossipTopic(s string) Option {
 	return func(o *NodeOption) {
 		o.NodeGossipTopic = s
 	}
 }
 
+func WithNodeLifecycleTopic(s string) Option {
+	return func(o *NodeOption) {
+		o.NodeLifecycleTopic = s
+	}
+}
+
 func WithRendezvous(s string) Option {
 	return func(o *NodeOption) {
 		o.Rendezvous = s
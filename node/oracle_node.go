@@ -2,8 +2,10 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	ethereumCrypto "github.com/ethereum/go-ethereum/crypto"
@@ -42,8 +44,14 @@ type OracleNode struct {
 	StartTime     time.Time
 	WorkerTracker *pubsub.WorkerEventTracker
 	Blockchain    *chain.Chain
+	ChainSync     *SyncProgress
 	Options       NodeOption
 	Context       context.Context
+
+	userTopics   map[string]struct{} // topics created through the API, the only ones that can be streamed
+	userTopicsMu sync.Mutex
+
+	capabilitiesMu sync.RWMutex // guards the scraper flags of Options, which can change at runtime
 }
 
 // GetP2PMultiAddrs returns the multiaddresses for the host in P2P format.
@@ -63,6 +71,9 @@ func (node *OracleNode) GetP2PMultiAddrs() ([]multiaddr.Multiaddr, error) {
 func NewOracleNode(ctx context.Context, opts ...Option) (*OracleNode, error) {
 	o := &NodeOption{}
 	o.Apply(opts...)
+	if o.BanList == nil {
+		o.BanList, _ = myNetwork.NewBanList("")
+	}
 
 	// Start with the default scaling limits.
 	scalingLimits := rcmgr.DefaultLimits
@@ -77,10 +88,11 @@ func NewOracleNode(ctx context.Context, opts ...Option) (*OracleNode, error) {
 	var addrStr []string
 	libp2pOptions := []libp2p.Option{
 		libp2p.ResourceManager(resourceManager),
-		libp2p.Ping(false), // disable built-in ping
+		libp2p.Ping(true), // answer pings so requesters can measure the latency to this node
 		libp2p.EnableNATService(),
 		libp2p.NATPortMap(),
 		libp2p.EnableRelay(), // Enable Circuit Relay v2 with hop
+		libp2p.ConnectionGater(o.BanList),
 	}
 
 	if o.RandomIdentity {
@@ -131,7 +143,9 @@ func NewOracleNode(ctx context.Context, opts ...Option) (*OracleNode, error) {
 		Context:       ctx,
 		PubSubManager: subscriptionManager,
 		Blockchain:    &chain.Chain{},
+		ChainSync:     &SyncProgress{},
 		Options:       *o,
+		userTopics:    make(map[string]struct{}),
 	}
 
 	n.Protocol = n.protocolWithVersion(n.Options.OracleProtocol)
@@ -168,8 +182,10 @@ func (node *OracleNode) getNodeData() *pubsub.NodeData {
 
 	nodeData := pubsub.NewNodeData(node.Host.Addrs(), node.Host.ID(), publicEthAddress, pubsub.ActivityJoined)
 	nodeData.IsStaked = node.Options.IsStaked
+	node.capabilitiesMu.RLock()
 	nodeData.IsTwitterScraper = node.Options.IsTwitterScraper
 	nodeData.IsWebScraper = node.Options.IsWebScraper
+	node.capabilitiesMu.RUnlock()
 	nodeData.IsValidator = node.Options.IsValidator
 	nodeData.IsActive = true
 	nodeData.Version = versioning.ProtocolVersion
@@ -204,6 +220,10 @@ func (node *OracleNode) Start() (err error) {
 		node.Host.SetStreamHandler(node.protocolWithVersion(node.Options.NodeGossipTopic), node.GossipNodeData)
 	}
 
+	if node.Options.IsValidator && node.Options.ChainSyncProtocol != "" {
+		node.Host.SetStreamHandler(node.protocolWithVersion(node.Options.ChainSyncProtocol), node.HandleChainSyncStream)
+	}
+
 	node.Host.Network().Notify(node.NodeTracker)
 
 	go node.ListenToNodeTracker()
@@ -318,6 +338,8 @@ func (node *OracleNode) handleStream(stream network.Stream) {
 // It returns true if any of these scrapers are enabled, otherwise false.
 func (node *OracleNode) IsWorker() bool {
 	// need to get this by node data
+	node.capabilitiesMu.RLock()
+	defer node.capabilitiesMu.RUnlock()
 	return node.Options.IsTwitterScraper ||
 		node.Options.IsDiscordScraper ||
 		node.Options.IsTelegramScraper ||
@@ -348,3 +370,45 @@ func (node *OracleNode) LogActiveTopics() {
 		logrus.Info("[-] No active topics.")
 	}
 }
+
+// Leave tells the network that the node is leaving, by broadcasting a leave event signed with the
+// node key on the lifecycle topic, and marks the node as left in its own node tracker.
+func (node *OracleNode) Leave(ctx context.Context) error {
+	if nodeData := node.NodeTracker.GetNodeData(node.Host.ID().String()); nodeData != nil {
+		nodeData.Left()
+	}
+	if node.Options.NodeLifecycleTopic == "" {
+		return nil
+	}
+
+	event := pubsub.NodeLifecycleEvent{
+		EventType: pubsub.EventTypeLeave,
+		NodeID:    node.Host.ID().String(),
+		Nonce:     time.Now().UnixNano(),
+	}
+	key := node.Host.Peerstore().PrivKey(node.Host.ID())
+	if key == nil {
+		return fmt.Errorf("no private key for %s", node.Host.ID())
+	}
+	return pubsub.BroadcastEvent(ctx, node.PubSubManager, node.topicWithVersion(node.Options.NodeLifecycleTopic), event, key)
+}
+
+// Close closes the blockchain store, the DHT and the libp2p host. The node context
+// should be cancelled first, so that the services of the node have stopped.
+func (node *OracleNode) Close() error {
+	var errs []error
+	if node.Blockchain != nil {
+		if err := node.Blockchain.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close the blockchain: %w", err))
+		}
+	}
+	if node.DHT != nil {
+		if err := node.DHT.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close the DHT: %w", err))
+		}
+	}
+	if err := node.Host.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close the host: %w", err))
+	}
+	return errors.Join(errs...)
+}
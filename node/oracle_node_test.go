@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -100,3 +101,54 @@ func TestOracleNode_GetNodeData(t *testing.T) {
 		})
 	}
 }
+
+func TestUserTopics(t *testing.T) {
+	node, err := NewOracleNode(context.Background(),
+		EnableRandomIdentity,
+		WithPort(0),
+		WithNodeGossipTopic("gossip"),
+		WithPubSubHandler("blockTopic", pubsub.NewTopicHandler(), false),
+	)
+	require.NoError(t, err)
+	defer node.Host.Close()
+
+	for _, internal := range []string{"gossip", "blockTopic"} {
+		assert.ErrorIs(t, node.CreateUserTopic(internal, pubsub.NewTopicHandler()), ErrInternalTopic)
+		_, err := node.StreamTopic(internal, 0)
+		assert.ErrorIs(t, err, ErrTopicNotStreamable)
+	}
+
+	_, err = node.StreamTopic("chat", 0)
+	assert.ErrorIs(t, err, ErrTopicNotStreamable)
+
+	require.NoError(t, node.CreateUserTopic("chat", pubsub.NewTopicHandler()))
+	require.NoError(t, node.CreateUserTopic("chat", pubsub.NewTopicHandler()), "creating a topic twice is harmless")
+	client, err := node.StreamTopic("chat", 0)
+	require.NoError(t, err)
+	client.Close()
+}
+
+func TestSetWorkerCapabilityConcurrent(t *testing.T) {
+	node, err := NewOracleNode(context.Background(), EnableRandomIdentity, WithPort(0))
+	require.NoError(t, err)
+	defer node.Host.Close()
+
+	// Run with -race: the flags are read by IsWorker while the admin API changes them
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(enabled bool) {
+			defer wg.Done()
+			_ = node.SetWorkerCapability(pubsub.CategoryWeb, enabled)
+		}(i%2 == 0)
+		go func() {
+			defer wg.Done()
+			_ = node.IsWorker()
+		}()
+	}
+	wg.Wait()
+
+	_ = node.SetWorkerCapability(pubsub.CategoryWeb, true)
+	assert.True(t, node.IsWorker())
+	assert.Error(t, node.SetWorkerCapability(pubsub.CategoryDiscord, true))
+}
@@ -2,9 +2,11 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/masa-finance/masa-oracle/node/types"
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
 
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -53,6 +55,13 @@ func (node *OracleNode) subscribeToTopics() error {
 		return err
 	}
 
+	// Subscribe to NodeLifecycleTopic to learn about nodes leaving the network.
+	if node.Options.NodeLifecycleTopic != "" {
+		if err := node.SubscribeTopic(node.Options.NodeLifecycleTopic, pubsub.NewNodeLifecycleHandler(node.NodeTracker), true); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -64,6 +73,54 @@ func (node *OracleNode) PublishTopicMessage(protocolName string, data string) er
 	return node.PubSubManager.PublishMessage(node.topicWithVersion(protocolName), data)
 }
 
+// ErrInternalTopic is returned when a topic the node uses internally is created through the API.
+var ErrInternalTopic = errors.New("the topic is used internally by the node")
+
+// ErrTopicNotStreamable is returned when streaming a topic that wasn't created through the API.
+var ErrTopicNotStreamable = errors.New("only topics created through the API can be streamed")
+
+// CreateUserTopic subscribes the handler to a topic created through the API. Only these topics
+// can be streamed, the gossip, lifecycle, block, vote and other topics of the node can't be created.
+func (node *OracleNode) CreateUserTopic(protocolName string, handler types.SubscriptionHandler) error {
+	if node.isInternalTopic(protocolName) {
+		return ErrInternalTopic
+	}
+	node.userTopicsMu.Lock()
+	defer node.userTopicsMu.Unlock()
+	if _, ok := node.userTopics[protocolName]; ok {
+		return nil
+	}
+	if err := node.SubscribeTopic(protocolName, handler, false); err != nil {
+		return err
+	}
+	node.userTopics[protocolName] = struct{}{}
+	return nil
+}
+
+// isInternalTopic reports whether the node uses the topic itself.
+func (node *OracleNode) isInternalTopic(protocolName string) bool {
+	if protocolName == node.Options.NodeGossipTopic || protocolName == node.Options.NodeLifecycleTopic {
+		return true
+	}
+	for _, handler := range node.Options.PubSubHandles {
+		if protocolName == handler.ProtocolName {
+			return true
+		}
+	}
+	return false
+}
+
+// StreamTopic subscribes a streaming client to the messages of a topic created through the API.
+func (node *OracleNode) StreamTopic(protocolName string, bufferSize int) (*pubsub.StreamClient, error) {
+	node.userTopicsMu.Lock()
+	_, ok := node.userTopics[protocolName]
+	node.userTopicsMu.Unlock()
+	if !ok || node.isInternalTopic(protocolName) {
+		return nil, ErrTopicNotStreamable
+	}
+	return node.PubSubManager.Stream(node.topicWithVersion(protocolName), bufferSize)
+}
+
 func (node *OracleNode) SubscribeTopic(protocolName string, handler types.SubscriptionHandler, includeSelf bool) error {
 	return node.PubSubManager.AddSubscription(node.topicWithVersion(protocolName), handler, includeSelf)
 }
@@ -3,13 +3,18 @@ package api
 import (
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 
 	node "github.com/masa-finance/masa-oracle/node"
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
 	"github.com/masa-finance/masa-oracle/pkg/event"
+	"github.com/masa-finance/masa-oracle/pkg/health"
+	"github.com/masa-finance/masa-oracle/pkg/idempotency"
 	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+	"github.com/masa-finance/masa-oracle/pkg/ratelimit"
 	"github.com/masa-finance/masa-oracle/pkg/workers"
 )
 
@@ -18,10 +23,51 @@ type API struct {
 	EventTracker              *event.EventTracker
 	WorkManager               *workers.WorkHandlerManager
 	PubKeySubscriptionHandler *pubsub.PublicKeySubscriptionHandler
+	APIKeys                   *apikey.Store
+	Limiter                   *ratelimit.Limiter
+	ClientCertScopes          ClientCertScopes
+	Liveness                  *health.Checker
+	Readiness                 *health.Checker
+	Idempotency               *idempotency.Store
+
+	streams   map[string]int // open topic streams of each caller
+	streamsMu sync.Mutex
+}
+
+// Option configures optional features of the API.
+type Option func(*API)
+
+// WithAPIKeys enables API key authentication with the keys in the given store.
+func WithAPIKeys(store *apikey.Store) Option {
+	return func(api *API) {
+		api.APIKeys = store
+	}
+}
+
+// WithRateLimiter enables per-key rate limiting and usage accounting.
+func WithRateLimiter(limiter *ratelimit.Limiter) Option {
+	return func(api *API) {
+		api.Limiter = limiter
+	}
+}
+
+// WithHealthCheckers sets the component checks run by the liveness and readiness endpoints.
+func WithHealthCheckers(liveness, readiness *health.Checker) Option {
+	return func(api *API) {
+		api.Liveness = liveness
+		api.Readiness = readiness
+	}
+}
+
+// WithIdempotency replays the responses kept in the store to requests retried with the same Idempotency-Key.
+func WithIdempotency(store *idempotency.Store) Option {
+	return func(api *API) {
+		api.Idempotency = store
+	}
 }
 
 // NewAPI creates a new API instance with the given OracleNode.
-func NewAPI(node *node.OracleNode, workManager *workers.WorkHandlerManager, pubkeySubscriptionHandler *pubsub.PublicKeySubscriptionHandler) *API {
+func NewAPI(node *node.OracleNode, workManager *workers.WorkHandlerManager, pubkeySubscriptionHandler *pubsub.PublicKeySubscriptionHandler, opts ...Option) *API {
 	eventTracker := event.NewEventTracker(nil)
 	if eventTracker == nil {
 		logrus.Error("Failed to create EventTracker")
@@ -35,6 +81,9 @@ func NewAPI(node *node.OracleNode, workManager *workers.WorkHandlerManager, pubk
 		WorkManager:               workManager,
 		PubKeySubscriptionHandler: pubkeySubscriptionHandler,
 	}
+	for _, opt := range opts {
+		opt(api)
+	}
 
 	logrus.Debugf("Created API instance with EventTracker: %v", api.EventTracker)
 	return api
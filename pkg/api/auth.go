@@ -0,0 +1,135 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
+)
+
+// apiKeyContextKey is the gin context key under which the authenticated API key is stored.
+const apiKeyContextKey = "apiKey"
+
+// publicRoutes are the path prefixes that don't require an API key.
+var publicRoutes = []string{
+	"/status",
+	"/health",
+	"/readyz",
+	"/swagger",
+	"/chat", // the page itself, which asks for a key to call the API
+}
+
+// queryKeyRoutes are the routes that also accept the API key as an "apiKey" query parameter,
+// since browsers can't set headers on EventSource and WebSocket requests.
+var queryKeyRoutes = map[string]bool{
+	"/api/v1/topic/:name/stream": true,
+}
+
+// authenticate returns a middleware that requires a valid API key on every non-public route.
+// The key is read from the "Authorization: Bearer <key>" or the "X-API-Key" header, or from the
+// "apiKey" query parameter on the queryKeyRoutes. A verified client certificate whose identity is
+// mapped to scopes authenticates the request as well.
+// Without an API key store or client certificate mapping, authentication is disabled and every
+// request is let through.
+func (api *API) authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !api.AuthEnabled() {
+			c.Next()
+			return
+		}
+		if isPublicRoute(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+		if key, ok := api.ClientCertKey(c.Request.TLS); ok {
+			c.Set(apiKeyContextKey, key)
+			c.Next()
+			return
+		}
+
+		token := c.GetHeader("X-API-Key")
+		if token == "" && queryKeyRoutes[c.FullPath()] {
+			token = c.Query("apiKey")
+		}
+		if token == "" {
+			const BearerSchema = "Bearer "
+			authHeader := c.GetHeader("Authorization")
+			if !strings.HasPrefix(authHeader, BearerSchema) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API key required"})
+				return
+			}
+			token = strings.TrimSpace(authHeader[len(BearerSchema):])
+		}
+		if api.APIKeys == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+			return
+		}
+
+		key, err := api.APIKeys.Authenticate(token)
+		if err != nil {
+			message := "Invalid API key"
+			if errors.Is(err, apikey.ErrExpiredKey) || errors.Is(err, apikey.ErrRevokedKey) {
+				message = err.Error()
+			}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": message})
+			return
+		}
+		c.Set(apiKeyContextKey, key)
+		c.Next()
+	}
+}
+
+// requireScope returns a middleware that rejects requests whose API key doesn't grant the scope.
+// It lets every request through when authentication is disabled.
+func (api *API) requireScope(scope apikey.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !api.AuthEnabled() {
+			c.Next()
+			return
+		}
+		key, ok := APIKeyFromContext(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API key required"})
+			return
+		}
+		if !key.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API key lacks the " + string(scope) + " scope"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// requireAuthentication returns a middleware that rejects every request when authentication is
+// disabled, for the routes that must not be open to anyone who can reach the API.
+func (api *API) requireAuthentication() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !api.AuthEnabled() {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "This route requires authentication, set API_AUTH_ENABLED=true"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// APIKeyFromContext returns the API key that authenticated the request, if any.
+func APIKeyFromContext(c *gin.Context) (*apikey.Key, bool) {
+	value, exists := c.Get(apiKeyContextKey)
+	if !exists {
+		return nil, false
+	}
+	key, ok := value.(*apikey.Key)
+	return key, ok
+}
+
+func isPublicRoute(path string) bool {
+	for _, route := range publicRoutes {
+		if strings.HasPrefix(path, route) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,427 @@
+package api
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/sirupsen/logrus"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/chain"
+	"github.com/masa-finance/masa-oracle/pkg/config"
+	"github.com/masa-finance/masa-oracle/pkg/workers"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
+)
+
+// GetLogLevelHandler handles GET requests for the current log level.
+func (api *API) GetLogLevelHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    api_types.LogLevel{Level: logrus.GetLevel().String()},
+		})
+	}
+}
+
+// SetLogLevelHandler handles PUT requests to change the log level at runtime.
+// It expects a JSON body with a "level" such as "debug", "info" or "warn".
+func (api *API) SetLogLevelHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var reqBody api_types.LogLevel
+		if err := c.ShouldBindJSON(&reqBody); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		level, err := logrus.ParseLevel(reqBody.Level)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		logrus.SetLevel(level)
+		logrus.Infof("[+] Log level set to %s", level)
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    api_types.LogLevel{Level: level.String()},
+		})
+	}
+}
+
+// ListBansHandler handles GET requests to list the banned peers.
+func (api *API) ListBansHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil || api.Node.Options.BanList == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Node is not initialized"})
+			return
+		}
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    api.Node.Options.BanList.List(),
+		})
+	}
+}
+
+// BanPeerHandler handles POST requests to ban a peer. It expects a JSON body with the "peerId",
+// an optional "reason" and an optional "duration" such as "24h"; without one the ban is permanent.
+// The connections to the peer are closed, and new ones are refused in both directions.
+func (api *API) BanPeerHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil || api.Node.Options.BanList == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Node is not initialized"})
+			return
+		}
+		var reqBody api_types.BanRequest
+		if err := c.ShouldBindJSON(&reqBody); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		id, err := peer.Decode(reqBody.PeerId)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid peer ID"})
+			return
+		}
+		var ttl time.Duration
+		if reqBody.Duration != "" {
+			ttl, err = time.ParseDuration(reqBody.Duration)
+			if err != nil || ttl <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "duration must be a positive duration, e.g. 24h"})
+				return
+			}
+		}
+
+		ban, err := api.Node.BanPeer(id, reqBody.Reason, ttl)
+		if err != nil {
+			if id == api.Node.Host.ID() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			handleError(c, "Failed to ban peer", err)
+			return
+		}
+		logrus.Infof("[+] Banned peer %s: %s", id, reqBody.Reason)
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    ban,
+		})
+	}
+}
+
+// UnbanPeerHandler handles DELETE requests to lift the ban of a peer.
+func (api *API) UnbanPeerHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil || api.Node.Options.BanList == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Node is not initialized"})
+			return
+		}
+		id, err := peer.Decode(c.Param("peerId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid peer ID"})
+			return
+		}
+		found, err := api.Node.Options.BanList.Unban(id)
+		if err != nil {
+			handleError(c, "Failed to unban peer", err)
+			return
+		}
+		if !found {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Peer is not banned"})
+			return
+		}
+		logrus.Infof("[+] Unbanned peer %s", id)
+		c.JSON(http.StatusOK, gin.H{"success": true})
+	}
+}
+
+// DisconnectPeerHandler handles POST requests to close every connection to a peer.
+// Unlike a ban, the peer can connect again.
+func (api *API) DisconnectPeerHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Node is not initialized"})
+			return
+		}
+		id, err := peer.Decode(c.Param("peerId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid peer ID"})
+			return
+		}
+		if len(api.Node.Host.Network().ConnsToPeer(id)) == 0 {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Peer is not connected"})
+			return
+		}
+		if err := api.Node.Host.Network().ClosePeer(id); err != nil {
+			handleError(c, "Failed to disconnect peer", err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"success": true})
+	}
+}
+
+// GetCapabilitiesHandler handles GET requests for the worker capabilities of the node.
+func (api *API) GetCapabilitiesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		capabilities := make(map[string]bool, len(workers.Capabilities))
+		for _, category := range workers.Capabilities {
+			capabilities[strings.ToLower(category.String())] = api.WorkManager.HasCapability(category)
+		}
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    capabilities,
+		})
+	}
+}
+
+// SetCapabilityHandler handles PUT requests to turn a worker capability ("twitter" or "web") on or off.
+// It expects a JSON body with "enabled". The work handlers are registered or removed, and the node
+// data announced to the network is updated, so that other nodes start or stop sending that work.
+func (api *API) SetCapabilityHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Node is not initialized"})
+			return
+		}
+		var reqBody api_types.CapabilityRequest
+		if err := c.ShouldBindJSON(&reqBody); err != nil || reqBody.Enabled == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "enabled must be provided"})
+			return
+		}
+		category := data_types.WorkerTypeToCategory(data_types.WorkerType(c.Param("capability")))
+		if category < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported capability, expected twitter or web"})
+			return
+		}
+		if err := api.WorkManager.SetCapability(category, *reqBody.Enabled); err != nil {
+			if errors.Is(err, workers.ErrUnsupportedCapability) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported capability, expected twitter or web"})
+				return
+			}
+			handleError(c, "Failed to update the worker capability", err)
+			return
+		}
+		if err := api.Node.SetWorkerCapability(category, *reqBody.Enabled); err != nil {
+			logrus.Warnf("[-] Unable to announce the worker capabilities: %v", err)
+		}
+		logrus.Infof("[+] Worker capability %s enabled: %t", category, *reqBody.Enabled)
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    gin.H{strings.ToLower(category.String()): *reqBody.Enabled},
+		})
+	}
+}
+
+// RefreshDHTHandler handles POST requests to refresh the DHT routing table.
+// It waits for the refresh to complete and returns the size of the routing table.
+func (api *API) RefreshDHTHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil || api.Node.DHT == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "DHT is not initialized"})
+			return
+		}
+		select {
+		case err := <-api.Node.DHT.RefreshRoutingTable():
+			if err != nil {
+				handleError(c, "Failed to refresh the DHT routing table", err)
+				return
+			}
+		case <-c.Request.Context().Done():
+			c.JSON(http.StatusGatewayTimeout, gin.H{"error": "Request cancelled before the refresh completed"})
+			return
+		}
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    api_types.PeerCount{Peers: api.Node.DHT.RoutingTable().Size()},
+		})
+	}
+}
+
+// ResyncNodeDataHandler handles POST requests to resynchronize the node data with the connected peers.
+func (api *API) ResyncNodeDataHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil || api.Node.NodeTracker == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Node is not initialized"})
+			return
+		}
+		peers, err := api.Node.ResyncNodeData()
+		if err != nil {
+			handleError(c, "Failed to resync node data", err)
+			return
+		}
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    api_types.PeerCount{Peers: peers},
+		})
+	}
+}
+
+// GetRoutingTableHandler handles GET requests to dump the DHT routing table.
+func (api *API) GetRoutingTableHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil || api.Node.DHT == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "DHT is not initialized"})
+			return
+		}
+		infos := api.Node.DHT.RoutingTable().GetPeerInfos()
+		entries := make([]api_types.RoutingTableEntry, 0, len(infos))
+		for _, info := range infos {
+			addrs := api.Node.Host.Peerstore().Addrs(info.Id)
+			addresses := make([]string, 0, len(addrs))
+			for _, addr := range addrs {
+				addresses = append(addresses, addr.String())
+			}
+			entries = append(entries, api_types.RoutingTableEntry{
+				PeerId:                        info.Id.String(),
+				Addresses:                     addresses,
+				AddedAt:                       info.AddedAt,
+				LastUsefulAt:                  info.LastUsefulAt,
+				LastSuccessfulOutboundQueryAt: info.LastSuccessfulOutboundQueryAt,
+			})
+		}
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    entries,
+		})
+	}
+}
+
+// GetConnectionsHandler handles GET requests to list the open libp2p connections.
+func (api *API) GetConnectionsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Node is not initialized"})
+			return
+		}
+		conns := api.Node.Host.Network().Conns()
+		connections := make([]api_types.ConnectionInfo, 0, len(conns))
+		for _, conn := range conns {
+			stat := conn.Stat()
+			connections = append(connections, api_types.ConnectionInfo{
+				PeerId:     conn.RemotePeer().String(),
+				Direction:  stat.Direction.String(),
+				LocalAddr:  conn.LocalMultiaddr().String(),
+				RemoteAddr: conn.RemoteMultiaddr().String(),
+				Opened:     stat.Opened,
+				Streams:    len(conn.GetStreams()),
+			})
+		}
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    connections,
+		})
+	}
+}
+
+// PublishValidatorSetHandler handles POST requests to change the validators allowed to produce
+// blocks. It expects a validator set record with a "version" higher than the current one, the
+// "height" of the first block it applies to, above the last block, and the "validators" peer IDs. A record without a signature is signed with the key of this node, which
+// must be the governance peer. The record is applied if this node is a validator, and published
+// to the other validators.
+func (api *API) PublishValidatorSetHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Node is not initialized"})
+			return
+		}
+		var record chain.ValidatorSetRecord
+		if err := c.ShouldBindJSON(&record); err != nil || len(record.Validators) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if len(record.Signature) == 0 {
+			if err := record.Sign(api.Node.Host.Peerstore().PrivKey(api.Node.Host.ID())); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+		if api.Node.Options.IsValidator {
+			if err := api.Node.Blockchain.ApplyValidatorSet(record); err != nil {
+				status := http.StatusInternalServerError
+				if errors.Is(err, chain.ErrInvalidValidatorSet) {
+					status = http.StatusBadRequest
+				}
+				c.JSON(status, gin.H{"error": err.Error()})
+				return
+			}
+		}
+		data, err := json.Marshal(record)
+		if err == nil {
+			err = api.Node.PublishTopic(config.ValidatorSetTopic, data)
+		}
+		if err != nil {
+			logrus.Errorf("[-] Error publishing the validator set: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    record,
+		})
+	}
+}
+
+// PublishRecordsHandler handles POST requests to batch records, such as work events or
+// attestations, into one block. It expects the JSON "records", which are stored as sent. The
+// block is built on the last block of the chain of this node, which must be a validator, and
+// published to the other validators. The response holds the CIDs of the records, by which their
+// inclusion proofs are retrieved.
+func (api *API) PublishRecordsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil || !api.Node.Options.IsValidator {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Node is not a validator and cannot access this endpoint"})
+			return
+		}
+		var req api_types.RecordsRequest
+		if err := c.ShouldBindJSON(&req); err != nil || len(req.Records) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if len(req.Records) > maxBlockRecords {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d records per block", maxBlockRecords)})
+			return
+		}
+
+		records := make([][]byte, len(req.Records))
+		resp := api_types.RecordsBlock{CIDs: make([]string, len(req.Records))}
+		for i, record := range req.Records {
+			records[i] = record
+			id, err := chain.DataCID(record)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			resp.CIDs[i] = id
+		}
+		key := api.Node.Host.Peerstore().PrivKey(api.Node.Host.ID())
+		block, err := api.Node.Blockchain.BuildRecordBlock(records, key)
+		if err == nil {
+			_, err = api.Node.Blockchain.ImportBlock(block)
+		}
+		if err != nil {
+			logrus.Errorf("[-] Error building the block of %d records: %v", len(records), err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		data, err := json.Marshal(block)
+		if err == nil {
+			err = api.Node.PublishTopic(config.BlockTopic, data)
+		}
+		if err != nil {
+			logrus.Errorf("[-] Error publishing block %d: %v", block.Block, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp.Block = block.Block
+		resp.Hash = hex.EncodeToString(block.Hash)
+		resp.Root = hex.EncodeToString(block.Root)
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    resp,
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
+)
+
+// ListAPIKeysHandler handles GET requests to list the API keys issued by this node.
+// Only the key records are returned, never the keys themselves.
+func (api *API) ListAPIKeysHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    api.APIKeys.List(),
+		})
+	}
+}
+
+// CreateAPIKeyHandler handles POST requests to issue a new API key.
+// It expects a JSON body with a "label", the "scopes" to grant and an optional "expiresIn" duration
+// such as "720h". The key is only returned in this response.
+func (api *API) CreateAPIKeyHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var reqBody api_types.APIKeyRequest
+		if err := c.ShouldBindJSON(&reqBody); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+			return
+		}
+		if reqBody.Label == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Label must be provided"})
+			return
+		}
+		var ttl time.Duration
+		if reqBody.ExpiresIn != "" {
+			var err error
+			ttl, err = time.ParseDuration(reqBody.ExpiresIn)
+			if err != nil || ttl <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "expiresIn must be a positive duration, e.g. 720h"})
+				return
+			}
+		}
+
+		key, token, err := api.APIKeys.Issue(reqBody.Label, reqBody.Scopes, ttl)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, api_types.APIKeyResponse{
+			Success: true,
+			Data:    key,
+			Key:     token,
+		})
+	}
+}
+
+// RevokeAPIKeyHandler handles DELETE requests to revoke the API key with the given ID.
+// The key is rejected from the next request on.
+func (api *API) RevokeAPIKeyHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key, err := api.APIKeys.Revoke(c.Param("id"))
+		if err != nil {
+			if errors.Is(err, apikey.ErrNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			handleError(c, "Failed to revoke API key", err)
+			return
+		}
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    key,
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/audit"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// GetAuditLogHandler handles GET requests to query the audit log of the work requests this node
+// originated or executed. Records can be filtered by time range ("from" and "to", RFC 3339),
+// peer ID ("peer", requester or worker), work type ("workType"), outcome ("outcome"),
+// role ("role") and limited in number ("limit").
+func (api *API) GetAuditLogHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		store := api.WorkManager.AuditStore()
+		if store == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Audit log is disabled on this node"})
+			return
+		}
+
+		filter, err := parseAuditFilter(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		events, err := store.Query(filter)
+		if err != nil {
+			handleError(c, "Failed to query the audit log", err)
+			return
+		}
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    events,
+		})
+	}
+}
+
+func parseAuditFilter(c *gin.Context) (audit.Filter, error) {
+	filter := audit.Filter{
+		PeerId:   c.Query("peer"),
+		WorkType: c.Query("workType"),
+		Outcome:  audit.Outcome(c.Query("outcome")),
+		Role:     audit.Role(c.Query("role")),
+		Limit:    defaultAuditLimit,
+	}
+
+	for name, t := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := c.Query(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s time, expected RFC 3339: %v", name, err)
+			}
+			*t = parsed
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return filter, fmt.Errorf("to must not be before from")
+	}
+
+	switch filter.Outcome {
+	case "", audit.OutcomeSuccess, audit.OutcomeFailure:
+	default:
+		return filter, fmt.Errorf("invalid outcome %q", filter.Outcome)
+	}
+	switch filter.Role {
+	case "", audit.RoleOriginated, audit.RoleExecuted:
+	default:
+		return filter, fmt.Errorf("invalid role %q", filter.Role)
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > maxAuditLimit {
+			return filter, fmt.Errorf("limit must be between 1 and %d", maxAuditLimit)
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
@@ -1,11 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,12 +17,22 @@ import (
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
 	"github.com/masa-finance/masa-oracle/pkg/chain"
 	"github.com/masa-finance/masa-oracle/pkg/config"
+	"github.com/masa-finance/masa-oracle/pkg/tee"
+	"github.com/masa-finance/masa-oracle/pkg/tracing"
 	"github.com/masa-finance/masa-oracle/pkg/workers"
 	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
 )
 
+const (
+	defaultBlocksLimit = 100
+	maxBlocksLimit     = 1000
+	// maxBlockRecords keeps the blocks batching records within the size of pubsub messages.
+	maxBlockRecords = 1024
+)
+
 // sendWorkRequest sends a work request to a worker for processing.
 // It marshals the request details into JSON and sends it over a libp2p stream.
 // It is currently re-using the response channel map for this; however, it could be a simple synchronous call
@@ -27,21 +40,24 @@ import (
 //
 // Parameters:
 // - api: The API instance containing the Node and PubSubManager.
+// - ctx: The context of the API request, whose trace the work request joins.
 // - requestID: A unique identifier for the request.
 // - workType: The type of work to be performed by the worker.
 // - bodyBytes: The request body in byte slice format.
+// - sealing: The requester's choice of sealed, unsealed or both results, and the tee endpoint used to decrypt.
 //
 // Returns:
 // - error: An error object if the request could not be sent or processed, otherwise nil.
-func (api *API) sendWorkRequest(requestID string, workType data_types.WorkerType, bodyBytes []byte, wg *sync.WaitGroup) error {
+func (api *API) sendWorkRequest(ctx context.Context, requestID string, workType data_types.WorkerType, bodyBytes []byte, sealing data_types.SealingOptions, wg *sync.WaitGroup) error {
 	request := data_types.WorkRequest{
-		WorkType:  workType,
-		RequestId: requestID,
-		Data:      bodyBytes,
+		WorkType:     workType,
+		RequestId:    requestID,
+		Data:         bodyBytes,
+		TraceContext: tracing.Inject(ctx),
 	}
 	response := api.WorkManager.DistributeWork(api.Node, request)
 
-	err := response.UnsealDataIfNeeded()
+	err := response.ApplySealing(sealing, &request)
 	if err != nil {
 		return fmt.Errorf("failed to get response data: %v", err)
 	}
@@ -108,31 +124,62 @@ func handleResponse(c *gin.Context, response data_types.WorkResponse, wg *sync.W
 func handleErrorResponse(c *gin.Context, response data_types.WorkResponse) {
 	logrus.Errorf("[+] Work error: %s", response.Error)
 
-	errorResponse := func(status int, message string) {
-		c.JSON(status, gin.H{
-			"error":        message,
-			"details":      response.Error,
-			"workerPeerId": response.WorkerPeerId,
-		})
-	}
+	status, code, message := classifyWorkError(response.Error)
+	c.JSON(status, api_types.ErrorResponse{
+		Error:        message,
+		Code:         code,
+		Details:      response.Error,
+		WorkerPeerId: response.WorkerPeerId,
+	})
+}
 
+// WorkErrorStatus classifies a work error into the HTTP status and message returned to API callers.
+func WorkErrorStatus(workError string) (int, string) {
+	status, _, message := classifyWorkError(workError)
+	return status, message
+}
+
+func classifyWorkError(workError string) (int, api_types.ErrorCode, string) {
 	switch {
-	case strings.Contains(response.Error, "Twitter API rate limit exceeded (429 error)"):
-		errorResponse(http.StatusTooManyRequests, "Twitter API rate limit exceeded")
-	case strings.Contains(response.Error, "no workers could process"):
-		errorResponse(http.StatusServiceUnavailable, "No available workers to process the request")
+	case strings.Contains(workError, "Twitter API rate limit exceeded (429 error)"):
+		return http.StatusTooManyRequests, api_types.CodeUpstreamRateLimited, "Twitter API rate limit exceeded"
+	case strings.Contains(workError, "content policy:"):
+		return http.StatusForbidden, api_types.CodeContentPolicy, "Request rejected by the workers' content policy"
+	case strings.Contains(workError, "no workers could process"):
+		return http.StatusServiceUnavailable, api_types.CodeNoWorkers, "No available workers to process the request"
 	default:
-		errorResponse(http.StatusInternalServerError, "An error occurred while processing the request")
+		return http.StatusInternalServerError, api_types.CodeInternal, "An error occurred while processing the request"
+	}
+}
+
+// getSealingOptions reads the per-request sealing options from the query parameters.
+// "sealing" selects sealed, unsealed or both results and defaults to the node's setting.
+// "teeEndpoint" selects one of the tee endpoints configured on this node for decryption.
+func getSealingOptions(c *gin.Context) (data_types.SealingOptions, error) {
+	return ParseSealingOptions(c.Query("sealing"), c.Query("teeEndpoint"))
+}
+
+// ParseSealingOptions validates a requester's choice of sealing mode and tee endpoint.
+func ParseSealingOptions(sealing, endpoint string) (data_types.SealingOptions, error) {
+	mode, err := data_types.ParseSealingMode(sealing)
+	if err != nil {
+		return data_types.SealingOptions{}, err
 	}
+	if endpoint != "" {
+		if _, err := tee.DecryptEndpointURL(endpoint); err != nil {
+			return data_types.SealingOptions{}, err
+		}
+	}
+	return data_types.SealingOptions{Mode: mode, TeeEndpoint: endpoint}, nil
 }
 
 func handleError(c *gin.Context, message string, err error) {
 	logrus.Errorf("%s: %v", message, err)
-	c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	c.JSON(http.StatusInternalServerError, api_types.ErrorResponse{Error: "Internal server error", Code: api_types.CodeInternal})
 }
 
 func handleTimeout(c *gin.Context) {
-	c.JSON(http.StatusGatewayTimeout, gin.H{"error": "Request timed out in API layer"})
+	c.JSON(http.StatusGatewayTimeout, api_types.ErrorResponse{Error: "Request timed out in API layer", Code: api_types.CodeTimeout})
 }
 
 // SearchTweetsProfile returns a gin.HandlerFunc that processes a request to search for tweets from a specific user profile.
@@ -157,6 +204,12 @@ func (api *API) SearchTweetsProfile() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		}
 
+		sealing, err := getSealingOptions(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		api.sendTrackingEvent(data_types.TwitterProfile, bodyBytes)
 		requestID := uuid.New().String()
 		responseCh := workers.GetResponseChannelMap().CreateChannel(requestID)
@@ -164,7 +217,7 @@ func (api *API) SearchTweetsProfile() gin.HandlerFunc {
 		defer workers.GetResponseChannelMap().Delete(requestID)
 		go handleWorkResponse(c, responseCh, wg)
 
-		err = api.sendWorkRequest(requestID, data_types.TwitterProfile, bodyBytes, wg)
+		err = api.sendWorkRequest(c.Request.Context(), requestID, data_types.TwitterProfile, bodyBytes, sealing, wg)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		}
@@ -179,10 +232,7 @@ func (api *API) SearchTweetsProfile() gin.HandlerFunc {
 // On success, it returns the scraped tweets in a JSON response. On failure, it returns an appropriate error message and HTTP status code.
 func (api *API) SearchTweetsRecent() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var reqBody struct {
-			Query string `json:"query"`
-			Count int    `json:"count"`
-		}
+		var reqBody api_types.RecentTweetsRequest
 
 		if err := c.ShouldBindJSON(&reqBody); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
@@ -199,6 +249,12 @@ func (api *API) SearchTweetsRecent() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		}
 
+		sealing, err := getSealingOptions(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		api.sendTrackingEvent(data_types.Twitter, bodyBytes)
 		requestID := uuid.New().String()
 		responseCh := workers.GetResponseChannelMap().CreateChannel(requestID)
@@ -206,7 +262,7 @@ func (api *API) SearchTweetsRecent() gin.HandlerFunc {
 		defer workers.GetResponseChannelMap().Delete(requestID)
 		go handleWorkResponse(c, responseCh, wg)
 
-		err = api.sendWorkRequest(requestID, data_types.Twitter, bodyBytes, wg)
+		err = api.sendWorkRequest(c.Request.Context(), requestID, data_types.Twitter, bodyBytes, sealing, wg)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		}
@@ -242,6 +298,12 @@ func (api *API) SearchTwitterFollowers() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		}
 
+		sealing, err := getSealingOptions(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		api.sendTrackingEvent(data_types.TwitterFollowers, bodyBytes)
 		requestID := uuid.New().String()
 		responseCh := workers.GetResponseChannelMap().CreateChannel(requestID)
@@ -249,7 +311,7 @@ func (api *API) SearchTwitterFollowers() gin.HandlerFunc {
 		defer workers.GetResponseChannelMap().Delete(requestID)
 		go handleWorkResponse(c, responseCh, wg)
 
-		err = api.sendWorkRequest(requestID, data_types.TwitterFollowers, bodyBytes, wg)
+		err = api.sendWorkRequest(c.Request.Context(), requestID, data_types.TwitterFollowers, bodyBytes, sealing, wg)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		}
@@ -259,7 +321,7 @@ func (api *API) SearchTwitterFollowers() gin.HandlerFunc {
 
 // WebData returns a gin.HandlerFunc that processes web scraping requests.
 // It expects a JSON body with fields "url" (string) and "depth" (int), representing the URL to scrape and the depth of the scrape, respectively.
-// The handler validates the request body, ensuring the URL is not empty and the depth is positive.
+// The handler validates the request body, ensuring the URL is a valid http(s) URL and the depth is positive.
 // If the node has not staked, it returns an error indicating the node cannot participate.
 // On a valid request, it attempts to scrape web data using the specified URL and depth.
 // On success, it returns the scraped data in a sanitized JSON response. On failure, it returns an appropriate error message and HTTP status code.
@@ -269,22 +331,16 @@ func (api *API) WebData() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Node has not staked and cannot participate"})
 			return
 		}
-		var reqBody struct {
-			Url   string `json:"url"`
-			Depth int    `json:"depth"`
-		}
+		var reqBody data_types.WebRequest
 		if err := c.ShouldBindJSON(&reqBody); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
 			return
 		}
 
-		if reqBody.Url == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "URL parameter is missing"})
+		if err := reqBody.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		if reqBody.Depth <= 0 {
-			reqBody.Depth = 1 // Default count
-		}
 
 		// worker handler implementation
 		bodyBytes, err := json.Marshal(reqBody)
@@ -292,6 +348,12 @@ func (api *API) WebData() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		}
 
+		sealing, err := getSealingOptions(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		api.sendTrackingEvent(data_types.Web, bodyBytes)
 		requestID := uuid.New().String()
 		responseCh := workers.GetResponseChannelMap().CreateChannel(requestID)
@@ -299,7 +361,7 @@ func (api *API) WebData() gin.HandlerFunc {
 		defer workers.GetResponseChannelMap().Delete(requestID)
 		go handleWorkResponse(c, responseCh, wg)
 
-		err = api.sendWorkRequest(requestID, data_types.Web, bodyBytes, wg)
+		err = api.sendWorkRequest(c.Request.Context(), requestID, data_types.Web, bodyBytes, sealing, wg)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		}
@@ -307,14 +369,14 @@ func (api *API) WebData() gin.HandlerFunc {
 	}
 }
 
-// GetBlocks returns a gin.HandlerFunc that handles requests to retrieve all blocks from the blockchain.
+// GetBlocks returns a gin.HandlerFunc that handles requests to retrieve a page of the blocks of the blockchain.
 //
 // This function:
 // 1. Checks if the node is a validator.
-// 2. Retrieves all blocks from the blockchain.
-// 3. Formats each block's data into a more readable structure.
-// 4. Encodes the input data of each block in base64.
-// 5. Returns the formatted blocks as a JSON response.
+// 2. Parses the range of block numbers to return ("from", "to" and "limit").
+// 3. Retrieves the blocks of the range through the height index of the chain.
+// 4. Formats each block's data, with its input data encoded in base64.
+// 5. Returns the formatted blocks as a JSON response, the last one first.
 //
 // The function is only accessible to validator nodes and will return an error for non-validator nodes.
 func (api *API) GetBlocks() gin.HandlerFunc {
@@ -325,50 +387,90 @@ func (api *API) GetBlocks() gin.HandlerFunc {
 			return
 		}
 
-		type BlockData struct {
-			Block            uint64      `json:"block"`
-			InputData        interface{} `json:"input_data"`
-			TransactionHash  string      `json:"transaction_hash"`
-			PreviousHash     string      `json:"previous_hash"`
-			TransactionNonce int         `json:"nonce"`
+		if err := api.Node.Blockchain.Check(); err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
 		}
-
-		type Blocks struct {
-			BlockData []BlockData `json:"blocks"`
+		height, _ := api.Node.Blockchain.Head()
+		from, to, err := parseBlockRange(c, height)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		blocks, err := api.Node.Blockchain.GetBlockRange(from, to)
+		if err != nil {
+			handleError(c, "Failed to retrieve the blocks", err)
+			return
 		}
-		var existingBlocks Blocks
-		blocks := chain.GetBlockchain(api.Node.Blockchain)
+		finalizedHeight, _ := api.Node.Blockchain.Finalized()
 
-		for _, block := range blocks {
+		blocksResponse := api_types.BlocksResponse{Height: height, BlockData: []api_types.BlockData{}}
+		for i := len(blocks) - 1; i >= 0; i-- {
+			block := blocks[i]
 			var inputData interface{}
 			err := json.Unmarshal(block.Data, &inputData)
 			if err != nil {
 				inputData = string(block.Data) // Fallback to string if unmarshal fails
 			}
 
-			blockData := BlockData{
+			blockData := api_types.BlockData{
 				Block:            block.Block,
 				InputData:        base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%v", inputData))),
 				TransactionHash:  fmt.Sprintf("%x", block.Hash),
 				PreviousHash:     fmt.Sprintf("%x", block.Link),
 				TransactionNonce: int(block.Nonce),
+				Producer:         block.Producer,
+				Finalized:        block.Block <= finalizedHeight,
+				Records:          len(block.Records),
+				Root:             hex.EncodeToString(block.Root),
 			}
-			existingBlocks.BlockData = append(existingBlocks.BlockData, blockData)
+			blocksResponse.BlockData = append(blocksResponse.BlockData, blockData)
 		}
+		c.JSON(http.StatusOK, blocksResponse)
+	}
+}
 
-		jsonData, err := json.Marshal(existingBlocks)
-		if err != nil {
-			logrus.Error("[-] Error marshalling blocks: ", err)
-			return
+// parseBlockRange returns the range of block numbers requested with "from", "to" and "limit".
+// Without "from", the range ends with "to", the last block by default; without "to", it starts
+// with "from". It holds at most limit blocks.
+func parseBlockRange(c *gin.Context, height uint64) (uint64, uint64, error) {
+	limit := uint64(defaultBlocksLimit)
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || parsed == 0 || parsed > maxBlocksLimit {
+			return 0, 0, fmt.Errorf("limit must be between 1 and %d", maxBlocksLimit)
+		}
+		limit = parsed
+	}
+	bounds := map[string]*uint64{}
+	for _, name := range []string{"from", "to"} {
+		if value := c.Query(name); value != "" {
+			parsed, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return 0, 0, fmt.Errorf("invalid %s block number %q", name, value)
+			}
+			bounds[name] = &parsed
 		}
-		var blocksResponse Blocks
-		err = json.Unmarshal(jsonData, &blocksResponse)
-		if err != nil {
-			logrus.Error("[-] Error unmarshalling blocks: ", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
+	}
+
+	from, to := bounds["from"], bounds["to"]
+	switch {
+	case from != nil && to != nil:
+		if *to < *from {
+			return 0, 0, fmt.Errorf("to must not be below from")
 		}
-		c.JSON(http.StatusOK, blocksResponse)
+		return *from, min(*to, *from+limit-1), nil
+	case from != nil:
+		return *from, min(height, *from+limit-1), nil
+	default:
+		last := height
+		if to != nil {
+			last = min(*to, height)
+		}
+		if last < limit {
+			return 0, last, nil
+		}
+		return last - limit + 1, last, nil
 	}
 }
 
@@ -404,6 +506,12 @@ func (api *API) GetBlockByHash() gin.HandlerFunc {
 			return
 		}
 
+		finalized, err := api.Node.Blockchain.IsFinalized(block)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
 		var blockData map[string]interface{}
 		err = json.Unmarshal(block.Data, &blockData)
 		var inputData any
@@ -412,20 +520,183 @@ func (api *API) GetBlockByHash() gin.HandlerFunc {
 		} else {
 			inputData = blockData
 		}
-		responseData := gin.H{
-			"block":            block.Block,
-			"input_data":       inputData,
-			"transaction_hash": blockHash,
-			"nonce":            block.Nonce,
+		responseData := api_types.BlockData{
+			Block:            block.Block,
+			InputData:        inputData,
+			TransactionHash:  blockHash,
+			PreviousHash:     fmt.Sprintf("%x", block.Link),
+			TransactionNonce: int(block.Nonce),
+			Producer:         block.Producer,
+			Finalized:        finalized,
+			Records:          len(block.Records),
+			Root:             hex.EncodeToString(block.Root),
 		}
 		c.JSON(http.StatusOK, responseData)
 	}
 }
 
-// Test is a temporary function that handles test requests.
+// GetCertificateHandler returns the finality certificate of a block, with the votes of the
+// validators that finalized it. Blocks finalized along with a descendant have no certificate.
+func (api *API) GetCertificateHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !api.Node.Options.IsValidator {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Node is not a validator and cannot access this endpoint"})
+			return
+		}
+		hash, err := hex.DecodeString(c.Param("blockHash"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid block hash"})
+			return
+		}
+		certificate, err := api.Node.Blockchain.GetCertificate(hash)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if certificate == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No finality certificate for this block"})
+			return
+		}
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    certificate,
+		})
+	}
+}
+
+// GetInclusionProofHandler returns the inclusion proof of the record of a block at the given
+// index, which proves that the record is part of the block without the other records.
+func (api *API) GetInclusionProofHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !api.Node.Options.IsValidator {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Node is not a validator and cannot access this endpoint"})
+			return
+		}
+		hash, err := hex.DecodeString(c.Param("blockHash"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid block hash"})
+			return
+		}
+		index, err := strconv.Atoi(c.Param("index"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid record index"})
+			return
+		}
+		known, err := api.Node.Blockchain.HasBlock(hash)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !known {
+			c.JSON(http.StatusNotFound, gin.H{"error": "hash not found"})
+			return
+		}
+		proof, err := api.Node.Blockchain.GetInclusionProof(hash, index)
+		respondProof(c, proof, err)
+	}
+}
+
+// GetRecordProofHandler returns the inclusion proof of the record of the chain with the given CID.
+func (api *API) GetRecordProofHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !api.Node.Options.IsValidator {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Node is not a validator and cannot access this endpoint"})
+			return
+		}
+		proof, err := api.Node.Blockchain.GetRecordProof(c.Param("cid"))
+		respondProof(c, proof, err)
+	}
+}
+
+func respondProof(c *gin.Context, proof *chain.InclusionProof, err error) {
+	if errors.Is(err, chain.ErrRecordNotFound) || errors.Is(err, chain.ErrBlockNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, api_types.Response{
+		Success: true,
+		Data:    proof,
+	})
+}
+
+// GetChainStatusHandler returns the height and hash of the last block and of the last finalized block.
+func (api *API) GetChainStatusHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !api.Node.Options.IsValidator {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Node is not a validator and cannot access this endpoint"})
+			return
+		}
+		if err := api.Node.Blockchain.Check(); err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
+		}
+		last, err := api.Node.Blockchain.GetLastBlock()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		finalizedHeight, finalizedHash := api.Node.Blockchain.Finalized()
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data: api_types.ChainStatus{
+				Height:          last.Block,
+				Hash:            hex.EncodeToString(last.Hash),
+				FinalizedHeight: finalizedHeight,
+				FinalizedHash:   hex.EncodeToString(finalizedHash),
+			},
+		})
+	}
+}
+
+// GetChainSyncHandler returns the progress of the chain sync.
+func (api *API) GetChainSyncHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !api.Node.Options.IsValidator {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Node is not a validator and cannot access this endpoint"})
+			return
+		}
+		status := api.Node.ChainSync.Status()
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    api_types.SyncStatus(status),
+		})
+	}
+}
+
+// GetValidatorsHandler returns the validators allowed to produce blocks, and the version of the
+// governance record that set them.
+func (api *API) GetValidatorsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !api.Node.Options.IsValidator {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Node is not a validator and cannot access this endpoint"})
+			return
+		}
+		validators := api.Node.Blockchain.Validators()
+		if validators == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Blockchain is not initialized"})
+			return
+		}
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    api_types.ValidatorSet{Version: validators.Version(), Height: validators.Height(), Validators: validators.Validators()},
+		})
+	}
+}
+
+// Test is a temporary function that handles test requests. It builds a block with the request
+// body on the last block of the validator's chain and publishes it to the other validators.
 // TODO: Remove this function once testing is complete.
 func (api *API) Test() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if !api.Node.Options.IsValidator {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Node is not a validator and cannot access this endpoint"})
+			return
+		}
+
 		var reqBody map[string]interface{}
 
 		if err := c.ShouldBindJSON(&reqBody); err != nil {
@@ -444,14 +715,27 @@ func (api *API) Test() gin.HandlerFunc {
 			return
 		}
 
-		err = api.Node.PublishTopic(config.BlockTopic, bodyBytes)
+		key := api.Node.Host.Peerstore().PrivKey(api.Node.Host.ID())
+		block, err := api.Node.Blockchain.BuildBlock(bodyBytes, key)
+		if err == nil {
+			_, err = api.Node.Blockchain.ImportBlock(block)
+		}
+		if err != nil {
+			logrus.Errorf("[-] Error building block: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		blockBytes, err := json.Marshal(block)
+		if err == nil {
+			err = api.Node.PublishTopic(config.BlockTopic, blockBytes)
+		}
 		if err != nil {
 			logrus.Errorf("[-] Error publishing block: %v", err)
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"message": "message sent", "data": reqBody})
+		c.JSON(http.StatusOK, gin.H{"message": "block published", "data": reqBody, "hash": hex.EncodeToString(block.Hash)})
 	}
 }
 
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBlockRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		query    string
+		from, to uint64
+		err      bool
+	}{
+		{query: "", from: 151, to: 250},
+		{query: "limit=10", from: 241, to: 250},
+		{query: "to=5", from: 0, to: 5},
+		{query: "to=500&limit=10", from: 241, to: 250},
+		{query: "from=200", from: 200, to: 250},
+		{query: "from=10&limit=5", from: 10, to: 14},
+		{query: "from=10&to=20", from: 10, to: 20},
+		{query: "from=10&to=2000&limit=1000", from: 10, to: 1009},
+		{query: "from=20&to=10", err: true},
+		{query: "from=-1", err: true},
+		{query: "limit=0", err: true},
+		{query: "limit=1001", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/blocks?"+tt.query, nil)
+			from, to, err := parseBlockRange(c, 250)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+		})
+	}
+}
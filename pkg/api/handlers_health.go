@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/masa-finance/masa-oracle/pkg/health"
+)
+
+// LivenessHandler runs the liveness checks, which fail when the node has to be restarted.
+func (api *API) LivenessHandler() gin.HandlerFunc {
+	return healthHandler(func() *health.Checker { return api.Liveness })
+}
+
+// ReadinessHandler runs the readiness checks, which fail when the node can't serve data requests.
+func (api *API) ReadinessHandler() gin.HandlerFunc {
+	return healthHandler(func() *health.Checker { return api.Readiness })
+}
+
+// healthHandler responds with the report of a checker, with a 503 status if a critical component is down.
+// Without a checker, the node is reported up.
+func healthHandler(checker func() *health.Checker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := health.Report{Status: health.StatusUp, Components: []health.ComponentStatus{}}
+		if ch := checker(); ch != nil {
+			report = ch.Run(c.Request.Context())
+		}
+		status := http.StatusOK
+		if report.Status != health.StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, gin.H{"success": status == http.StatusOK, "data": report})
+	}
+}
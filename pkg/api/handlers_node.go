@@ -16,14 +16,15 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/masa-finance/masa-oracle/node"
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
 	"github.com/masa-finance/masa-oracle/pkg/config"
 	"github.com/masa-finance/masa-oracle/pkg/pubsub"
 )
 
 // GetNodeDataHandler handles GET requests to retrieve paginated node data from the node tracker.
-// It parses the page number and page size from the request path, retrieves all node data from the
+// It parses the page number and page size from the query string, retrieves all node data from the
 // node tracker, calculates pagination details like total pages based on page size, and returns a
-// page of node data in the response.
+// page of node data in the response. A page past the last one is empty.
 func (api *API) GetNodeDataHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		pageNbr, err := GetPathInt(c, "pageNbr")
@@ -31,11 +32,15 @@ func (api *API) GetNodeDataHandler() gin.HandlerFunc {
 			pageNbr = 0
 		}
 		pageSize, err := GetPathInt(c, "pageSize")
-		if err != nil {
+		if err != nil || pageSize <= 0 {
 			pageSize = config.PageSize
 		}
+		if pageNbr < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "pageNbr must not be negative"})
+			return
+		}
 
-		if api.Node == nil || api.Node.DHT == nil {
+		if api.Node == nil || api.Node.DHT == nil || api.Node.NodeTracker == nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"success": false,
 				"message": "An unexpected error occurred.",
@@ -46,23 +51,23 @@ func (api *API) GetNodeDataHandler() gin.HandlerFunc {
 		totalRecords := len(allNodeData)
 		totalPages := int(math.Ceil(float64(totalRecords) / float64(pageSize)))
 
-		startIndex := pageNbr * pageSize
-		endIndex := startIndex + pageSize
-		if endIndex > totalRecords {
-			endIndex = totalRecords
+		startIndex := totalRecords
+		if pageNbr < totalPages {
+			startIndex = pageNbr * pageSize
 		}
+		endIndex := min(startIndex+pageSize, totalRecords)
 		nodeDataPage := node.NodeDataPage{
 			Data:         allNodeData[startIndex:endIndex],
 			PageNumber:   pageNbr,
 			TotalPages:   totalPages,
 			TotalRecords: totalRecords,
 		}
-		c.JSON(http.StatusOK, gin.H{
-			"success":      true,
-			"data":         nodeDataPage.Data,
-			"pageNbr":      nodeDataPage.PageNumber,
-			"total":        nodeDataPage.TotalPages,
-			"totalRecords": nodeDataPage.TotalRecords,
+		c.JSON(http.StatusOK, api_types.NodeDataPageResponse{
+			Success:      true,
+			Data:         nodeDataPage.Data,
+			PageNbr:      nodeDataPage.PageNumber,
+			Total:        nodeDataPage.TotalPages,
+			TotalRecords: nodeDataPage.TotalRecords,
 		})
 	}
 }
@@ -95,9 +100,9 @@ func (api *API) GetNodeHandler() gin.HandlerFunc {
 		nd.CurrentUptimeStr = pubsub.PrettyDuration(nd.CurrentUptime)
 		nd.AccumulatedUptimeStr = pubsub.PrettyDuration(nd.AccumulatedUptime)
 
-		c.JSON(http.StatusOK, gin.H{
-			"success": true,
-			"data":    nd,
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    nd,
 		})
 	}
 }
@@ -118,19 +123,17 @@ func (api *API) GetPeersHandler() gin.HandlerFunc {
 		peers := api.Node.NodeTracker.GetAllNodeData()
 
 		// Create a slice to hold the data
-		data := make([]map[string]interface{}, len(peers))
+		data := make([]api_types.Peer, len(peers))
 
 		// Populate the data slice
 		for i, peer := range peers {
-			data[i] = map[string]interface{}{
-				"peerId": peer.PeerId.String(),
-			}
+			data[i] = api_types.Peer{PeerId: peer.PeerId.String()}
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"success":    true,
-			"data":       data,
-			"totalCount": len(peers),
+		c.JSON(http.StatusOK, api_types.PeersResponse{
+			Success:    true,
+			Data:       data,
+			TotalCount: len(peers),
 		})
 	}
 }
@@ -152,19 +155,29 @@ func (api *API) GetPeerAddresses() gin.HandlerFunc {
 		peers := api.Node.NodeTracker.GetAllNodeData()
 
 		// Create a slice to hold the data
-		data := make([]map[string]interface{}, len(peers))
+		data := make([]api_types.PeerAddresses, len(peers))
 
 		for i, peer := range peers {
-			data[i] = map[string]interface{}{
-				"peerId":      peer.PeerId.String(),
-				"peerAddress": peer.Multiaddrs[0].String(),
+			addresses := make([]string, 0, len(peer.Multiaddrs))
+			for _, ma := range peer.Multiaddrs {
+				addresses = append(addresses, ma.String())
+			}
+			// peerAddress is the first address, kept for existing clients
+			peerAddress := ""
+			if len(addresses) > 0 {
+				peerAddress = addresses[0]
+			}
+			data[i] = api_types.PeerAddresses{
+				PeerId:        peer.PeerId.String(),
+				PeerAddress:   peerAddress,
+				PeerAddresses: addresses,
 			}
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"success":    true,
-			"data":       data,
-			"totalCount": len(peers),
+		c.JSON(http.StatusOK, api_types.PeersResponse{
+			Success:    true,
+			Data:       data,
+			TotalCount: len(peers),
 		})
 	}
 }
@@ -210,7 +223,7 @@ func (api *API) PublishPublicKeyHandler() gin.HandlerFunc {
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"status": "Public key published successfully"})
+		c.JSON(http.StatusOK, api_types.StatusResponse{Status: "Public key published successfully"})
 	}
 }
 
@@ -228,9 +241,9 @@ func (api *API) GetPublicKeysHandler() gin.HandlerFunc {
 		}
 
 		publicKeys := api.PubKeySubscriptionHandler.GetPublicKeys()
-		c.JSON(http.StatusOK, gin.H{
-			"success":    true,
-			"publicKeys": publicKeys,
+		c.JSON(http.StatusOK, api_types.PublicKeysResponse{
+			Success:    true,
+			PublicKeys: publicKeys,
 		})
 	}
 }
@@ -266,23 +279,23 @@ func (api *API) GetFromDHT() gin.HandlerFunc {
 		if err := json.Unmarshal(nv, &sharedData); err != nil {
 			if decodedString, decodeErr := base64.StdEncoding.DecodeString(string(nv)); decodeErr == nil {
 				if json.Unmarshal(decodedString, &sharedData) == nil {
-					c.JSON(http.StatusOK, gin.H{
-						"success": true,
-						"message": sharedData,
+					c.JSON(http.StatusOK, api_types.DHTResponse{
+						Success: true,
+						Message: sharedData,
 					})
 					return
 				}
 			}
-			c.JSON(http.StatusOK, gin.H{
-				"success": true,
-				"message": string(nv),
+			c.JSON(http.StatusOK, api_types.DHTResponse{
+				Success: true,
+				Message: string(nv),
 			})
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"success": true,
-			"message": sharedData,
+		c.JSON(http.StatusOK, api_types.DHTResponse{
+			Success: true,
+			Message: sharedData,
 		})
 	}
 }
@@ -322,9 +335,9 @@ func (api *API) PostToDHT() gin.HandlerFunc {
 			})
 			return
 		}
-		c.JSON(http.StatusOK, gin.H{
-			"success": true,
-			"message": keyStr,
+		c.JSON(http.StatusOK, api_types.DHTResponse{
+			Success: true,
+			Message: keyStr,
 		})
 	}
 }
@@ -353,7 +366,7 @@ func (api *API) PostNodeStatusHandler() gin.HandlerFunc {
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"status": "Message posted to topic successfully"})
+		c.JSON(http.StatusOK, api_types.StatusResponse{Status: "Message posted to topic successfully"})
 	}
 }
 
@@ -411,27 +424,6 @@ func (api *API) NodeStatusPageHandler() gin.HandlerFunc {
 	}
 }
 
-// GetNodeApiKey returns a gin.HandlerFunc that generates and returns a JWT token for the node.
-// The JWT token is signed using the node's host ID as the secret key.
-// On success, it returns the generated JWT token in a JSON response.
-// On failure, it returns an appropriate error message and HTTP status code.
-func (api *API) GetNodeApiKey() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		jwtToken, err := consensus.GenerateJWTToken(api.Node.Host.ID().String())
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"success": false,
-				"message": err,
-			})
-			return
-		}
-		c.JSON(http.StatusOK, gin.H{
-			"success": true,
-			"message": jwtToken,
-		})
-	}
-}
-
 // fromUnixTime converts a Unix timestamp into a formatted string.
 // The Unix timestamp is expected to be in seconds.
 // The returned string is in the format "2006-01-02T15:04:05.000Z".
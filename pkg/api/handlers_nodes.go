@@ -0,0 +1,191 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
+)
+
+const (
+	defaultNodesLimit = 100
+	maxNodesLimit     = 1000
+)
+
+// nodeFields are the fields a node directory entry can be projected to: the JSON fields of
+// pubsub.NodeData and the computed reliability score.
+var nodeFields = func() map[string]bool {
+	fields := map[string]bool{"reliability": true}
+	t := reflect.TypeOf(pubsub.NodeData{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// QueryNodesHandler handles GET requests to query the node directory. Nodes can be filtered by
+// stake ("staked"), activity ("active"), validator role ("validator"), the work type they can
+// do ("capability"), version ("version"), minimum accumulated uptime ("minUptime", a duration)
+// and minimum reliability score ("minReliability", 0 to 1). They are sorted by "sort" in "order"
+// and paged with "limit" and the "cursor" returned with the previous page. "fields" projects
+// each entry to a comma-separated list of fields.
+func (api *API) QueryNodesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil || api.Node.NodeTracker == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Node is not initialized"})
+			return
+		}
+
+		query, err := parseNodeQuery(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		fields, err := parseNodeFields(c.Query("fields"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		result, err := api.Node.NodeTracker.QueryNodes(query)
+		if err != nil {
+			if errors.Is(err, pubsub.ErrInvalidCursor) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			handleError(c, "Failed to query the node directory", err)
+			return
+		}
+
+		data := make([]map[string]interface{}, 0, len(result.Nodes))
+		for i := range result.Nodes {
+			entry, err := nodeEntry(&result.Nodes[i], fields)
+			if err != nil {
+				handleError(c, "Failed to encode node data", err)
+				return
+			}
+			data = append(data, entry)
+		}
+		c.JSON(http.StatusOK, api_types.NodesResponse{
+			Success:    true,
+			Data:       data,
+			Total:      result.Total,
+			NextCursor: result.NextCursor,
+		})
+	}
+}
+
+func parseNodeQuery(c *gin.Context) (pubsub.NodeQuery, error) {
+	query := pubsub.NodeQuery{
+		Version: c.Query("version"),
+		Cursor:  c.Query("cursor"),
+		Limit:   defaultNodesLimit,
+	}
+
+	for name, filter := range map[string]**bool{"staked": &query.Staked, "active": &query.Active, "validator": &query.Validator} {
+		if value := c.Query(name); value != "" {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return query, fmt.Errorf("invalid %s value %q, expected true or false", name, value)
+			}
+			*filter = &b
+		}
+	}
+
+	if value := c.Query("capability"); value != "" {
+		category := data_types.WorkerTypeToCategory(data_types.WorkerType(value))
+		if category < 0 {
+			return query, fmt.Errorf("unknown capability %q", value)
+		}
+		query.Capability = &category
+	}
+	if value := c.Query("minUptime"); value != "" {
+		uptime, err := time.ParseDuration(value)
+		if err != nil || uptime < 0 {
+			return query, fmt.Errorf("invalid minUptime %q, expected a duration such as 24h", value)
+		}
+		query.MinUptime = uptime
+	}
+	if value := c.Query("minReliability"); value != "" {
+		score, err := strconv.ParseFloat(value, 64)
+		if err != nil || score < 0 || score > 1 {
+			return query, fmt.Errorf("minReliability must be between 0 and 1")
+		}
+		query.MinReliability = score
+	}
+
+	sortKey, err := pubsub.ParseNodeSortKey(c.Query("sort"))
+	if err != nil {
+		return query, err
+	}
+	query.SortBy = sortKey
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return query, fmt.Errorf("order must be asc or desc")
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > maxNodesLimit {
+			return query, fmt.Errorf("limit must be between 1 and %d", maxNodesLimit)
+		}
+		query.Limit = limit
+	}
+	return query, nil
+}
+
+// parseNodeFields parses a comma-separated list of node fields. An empty list selects every field.
+func parseNodeFields(s string) ([]string, error) {
+	fields := make([]string, 0)
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !nodeFields[field] {
+			return nil, fmt.Errorf("unknown field %q", field)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// nodeEntry returns the directory entry of a node, projected to the given fields.
+func nodeEntry(nd *pubsub.NodeData, fields []string) (map[string]interface{}, error) {
+	b, err := json.Marshal(nd)
+	if err != nil {
+		return nil, err
+	}
+	entry := make(map[string]interface{})
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return nil, err
+	}
+	entry["reliability"] = nd.ReliabilityScore()
+	if len(fields) == 0 {
+		return entry, nil
+	}
+
+	projected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if value, ok := entry[field]; ok {
+			projected[field] = value
+		}
+	}
+	return projected, nil
+}
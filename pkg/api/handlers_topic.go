@@ -1,19 +1,26 @@
 package api
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
 
+	"github.com/masa-finance/masa-oracle/node"
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
 	"github.com/masa-finance/masa-oracle/pkg/pubsub"
 )
 
 // CreateNewTopicHandler creates a new topic with a given name and subscribes a handler to it.
 func (api *API) CreateNewTopicHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var request struct {
-			TopicName string `json:"topicName"`
-		}
+		var request api_types.TopicRequest
 
 		if err := c.BindJSON(&request); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
@@ -28,23 +35,24 @@ func (api *API) CreateNewTopicHandler() gin.HandlerFunc {
 		// Initialize a TopicHandler for managing messages from the new topic.
 		topicHandler := pubsub.NewTopicHandler()
 
-		// Use the AddSubscription method to create the new topic and subscribe the TopicHandler to it.
-		if err := api.Node.SubscribeTopic(request.TopicName, topicHandler, false); err != nil {
+		// Create the new topic and subscribe the TopicHandler to it.
+		if err := api.Node.CreateUserTopic(request.TopicName, topicHandler); err != nil {
+			if errors.Is(err, node.ErrInternalTopic) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"status": "New topic created and subscribed successfully"})
+		c.JSON(http.StatusOK, api_types.StatusResponse{Status: "New topic created and subscribed successfully"})
 	}
 }
 
 // PostToTopicHandler allows posting a message to a specified topic.
 func (api *API) PostToTopicHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var request struct {
-			TopicName string `json:"topicName"`
-			Message   string `json:"message"`
-		}
+		var request api_types.TopicRequest
 
 		if err := c.BindJSON(&request); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
@@ -62,6 +70,159 @@ func (api *API) PostToTopicHandler() gin.HandlerFunc {
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"status": "Message posted to topic successfully"})
+		c.JSON(http.StatusOK, api_types.StatusResponse{Status: "Message posted to topic successfully"})
+	}
+}
+
+const (
+	maxStreamBuffer    = 4096
+	maxStreamsPerKey   = 16
+	streamHeartbeat    = 30 * time.Second
+	streamWriteTimeout = 10 * time.Second
+)
+
+var streamUpgrader = websocket.Upgrader{
+	// Cross-origin requests are allowed for the whole API, see the CORS configuration
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// StreamTopicHandler streams the messages of a topic created through the API in real time, with the
+// sender's peer ID and the time they were received. WebSocket upgrade requests get one JSON message
+// per text frame; other requests get server-sent events. A "buffer" query parameter sets how many
+// messages are buffered for the client; when it falls behind, messages are dropped and the next
+// message delivered reports how many. Each API key has at most maxStreamsPerKey open streams.
+func (api *API) StreamTopicHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil || api.Node.PubSubManager == nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Node or PubSubManager is not initialized"})
+			return
+		}
+
+		bufferSize := pubsub.DefaultStreamBuffer
+		if value := c.Query("buffer"); value != "" {
+			size, err := strconv.Atoi(value)
+			if err != nil || size <= 0 || size > maxStreamBuffer {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("buffer must be between 1 and %d", maxStreamBuffer)})
+				return
+			}
+			bufferSize = size
+		}
+
+		// Without an API key, callers are told apart by their address
+		caller := callerID(c)
+		if caller == AnonymousKeyID {
+			caller += "/" + c.ClientIP()
+		}
+		if !api.acquireStream(caller) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": fmt.Sprintf("at most %d topic streams can be open per API key", maxStreamsPerKey)})
+			return
+		}
+		defer api.releaseStream(caller)
+
+		client, err := api.Node.StreamTopic(c.Param("name"), bufferSize)
+		if err != nil {
+			switch {
+			case errors.Is(err, node.ErrTopicNotStreamable):
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			case errors.Is(err, pubsub.ErrTooManyStreamClients):
+				c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			return
+		}
+		defer client.Close()
+
+		if websocket.IsWebSocketUpgrade(c.Request) {
+			streamWebSocket(c, client)
+		} else {
+			streamServerSentEvents(c, client)
+		}
+	}
+}
+
+// acquireStream counts a new topic stream of the caller, unless it has too many open already.
+func (api *API) acquireStream(caller string) bool {
+	api.streamsMu.Lock()
+	defer api.streamsMu.Unlock()
+	if api.streams == nil {
+		api.streams = make(map[string]int)
+	}
+	if api.streams[caller] >= maxStreamsPerKey {
+		return false
+	}
+	api.streams[caller]++
+	return true
+}
+
+func (api *API) releaseStream(caller string) {
+	api.streamsMu.Lock()
+	defer api.streamsMu.Unlock()
+	if api.streams[caller]--; api.streams[caller] <= 0 {
+		delete(api.streams, caller)
+	}
+}
+
+func streamServerSentEvents(c *gin.Context, client *pubsub.StreamClient) {
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case msg, ok := <-client.Messages():
+			if !ok {
+				return false
+			}
+			c.SSEvent("message", msg)
+		case <-heartbeat.C:
+			c.SSEvent("ping", time.Now().UTC())
+		}
+		return true
+	})
+}
+
+func streamWebSocket(c *gin.Context, client *pubsub.StreamClient) {
+	conn, err := streamUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logrus.Errorf("[-] Error upgrading topic stream to WebSocket: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	// Read until the client goes away; the stream doesn't accept messages from it
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case msg, ok := <-client.Messages():
+			if !ok {
+				_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "subscription ended"), time.Now().Add(streamWriteTimeout))
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			if err := conn.WriteJSON(msg); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout)); err != nil {
+				return
+			}
+		}
 	}
 }
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
+	"github.com/masa-finance/masa-oracle/pkg/ratelimit"
+)
+
+// GetUsageHandler handles GET requests for the usage accounted per API key, work type and day.
+// Callers see their own usage; admin keys can see every key's usage or select one with "key".
+// Records can be filtered by work type ("workType") and day range ("from" and "to", YYYY-MM-DD).
+func (api *API) GetUsageHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Limiter == nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Usage accounting is disabled on this node"})
+			return
+		}
+
+		filter := ratelimit.UsageFilter{
+			KeyID:    callerID(c),
+			WorkType: c.Query("workType"),
+			From:     c.Query("from"),
+			To:       c.Query("to"),
+		}
+		if key, ok := APIKeyFromContext(c); !ok || key.HasScope(apikey.ScopeAdmin) {
+			filter.KeyID = c.Query("key")
+		} else if requested := c.Query("key"); requested != "" && requested != key.ID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only admin keys can see the usage of other keys"})
+			return
+		}
+		for name, day := range map[string]string{"from": filter.From, "to": filter.To} {
+			if day == "" {
+				continue
+			}
+			if _, err := time.Parse(ratelimit.DayFormat, day); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid %s parameter, expected YYYY-MM-DD", name)})
+				return
+			}
+		}
+
+		records, err := api.Limiter.Usage().Query(filter)
+		if err != nil {
+			handleError(c, "Failed to query usage", err)
+			return
+		}
+		c.JSON(http.StatusOK, api_types.UsageResponse{
+			Success: true,
+			Data:    records,
+			Limits:  api.Limiter.Limits(),
+		})
+	}
+}
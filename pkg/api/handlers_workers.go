@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+)
+
+// GetWorkerDiagnosticsHandler handles GET requests to retrieve the worker selection diagnostics.
+// It returns, for every worker category, the eligible remote workers along with their measured
+// round-trip latency and the configured maximum, plus the metrics of the local work handlers.
+func (api *API) GetWorkerDiagnosticsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Node == nil || api.Node.NodeTracker == nil || api.WorkManager == nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"message": "An unexpected error occurred.",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, api_types.Response{
+			Success: true,
+			Data:    api.WorkManager.GetWorkerDiagnostics(api.Node),
+		})
+	}
+}
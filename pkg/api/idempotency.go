@@ -0,0 +1,137 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/idempotency"
+)
+
+const (
+	// IdempotencyKeyHeader is the header carrying the client's idempotency key.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from an earlier request with the same key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// maxIdempotentBodySize is the size of the requests read to fingerprint them.
+	maxIdempotentBodySize = 1 << 20
+)
+
+// responseRecorder copies the body of a response as it is written.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotent returns a middleware that makes requests with an Idempotency-Key header safe to retry.
+// The response is kept for the window of the store, keyed by the API key (the client address
+// without one) and the idempotency key, and replayed to retries. Request bodies are limited to
+// maxIdempotentBodySize. A retry of a request still in flight waits for its response. Server
+// errors and rate limit rejections aren't kept, so that they can be retried.
+func (api *API) idempotent() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if api.Idempotency == nil || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, api_types.ErrorResponse{
+				Error: "Idempotency-Key must be at most 255 characters",
+				Code:  api_types.CodeInvalidRequest,
+			})
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxIdempotentBodySize+1))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, api_types.ErrorResponse{Error: "Unable to read the request body", Code: api_types.CodeInvalidRequest})
+			return
+		}
+		if len(body) > maxIdempotentBodySize {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, api_types.ErrorResponse{
+				Error: "Requests sent with an Idempotency-Key must be at most 1 MiB",
+				Code:  api_types.CodeInvalidRequest,
+			})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Without an API key, callers are told apart by their address
+		caller := callerID(c)
+		if caller == AnonymousKeyID {
+			caller += "/" + c.ClientIP()
+		}
+		call, owner, err := api.Idempotency.Begin(caller+"\x00"+key, requestFingerprint(c.Request, body))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, api_types.ErrorResponse{Error: err.Error(), Code: api_types.CodeIdempotencyMismatch})
+			return
+		}
+		if !owner {
+			replayResponse(c, call)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		var response *idempotency.Response
+		defer func() {
+			// A nil response, after a panic, releases the retries waiting for it
+			status := recorder.Status()
+			call.Complete(response, status < http.StatusInternalServerError && status != http.StatusTooManyRequests)
+		}()
+		c.Next()
+		c.Writer = recorder.ResponseWriter
+
+		response = &idempotency.Response{
+			Status:      recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}
+	}
+}
+
+// replayResponse sends the response of the original request once it has completed.
+func replayResponse(c *gin.Context, call *idempotency.Call) {
+	response, err := call.Wait(c.Request.Context())
+	switch {
+	case errors.Is(err, idempotency.ErrAbandoned):
+		c.AbortWithStatusJSON(http.StatusInternalServerError, api_types.ErrorResponse{Error: err.Error(), Code: api_types.CodeInternal})
+		return
+	case err != nil:
+		// The client is gone
+		logrus.Debugf("[-] Stopped waiting for the original request with idempotency key %q: %v", c.GetHeader(IdempotencyKeyHeader), err)
+		c.Abort()
+		return
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(response.Status, response.ContentType, response.Body)
+	c.Abort()
+}
+
+// requestFingerprint identifies a request by its method, path, query and body.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
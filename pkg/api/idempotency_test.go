@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/masa-finance/masa-oracle/pkg/idempotency"
+)
+
+func TestIdempotent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	api := &API{Idempotency: idempotency.New(time.Hour, idempotency.DefaultMaxBytes)}
+	var calls atomic.Int32
+	release := make(chan struct{})
+	router := gin.New()
+	router.POST("/data/web", api.idempotent(), func(c *gin.Context) {
+		n := calls.Add(1)
+		<-release
+		c.JSON(http.StatusOK, gin.H{"call": n})
+	})
+
+	send := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/data/web", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// A retry sent while the original request is in flight attaches to it
+	var wg sync.WaitGroup
+	responses := make([]*httptest.ResponseRecorder, 2)
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i] = send("key1", `{"url":"https://example.com"}`)
+		}(i)
+	}
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.EqualValues(t, 1, calls.Load())
+	for _, w := range responses {
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"call":1}`, w.Body.String())
+	}
+	assert.NotEqual(t, responses[0].Header().Get(IdempotentReplayedHeader), responses[1].Header().Get(IdempotentReplayedHeader))
+
+	// A later retry gets the stored response
+	w := send("key1", `{"url":"https://example.com"}`)
+	assert.JSONEq(t, `{"call":1}`, w.Body.String())
+	assert.Equal(t, "true", w.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	w = send("key1", `{"url":"https://example.org"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	send("key2", `{"url":"https://example.com"}`)
+	send("", `{"url":"https://example.com"}`)
+	assert.EqualValues(t, 3, calls.Load())
+
+	w = send("key3", strings.Repeat(" ", maxIdempotentBodySize+1))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.EqualValues(t, 3, calls.Load())
+}
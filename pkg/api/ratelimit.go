@@ -0,0 +1,109 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/ratelimit"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
+)
+
+// AnonymousKeyID identifies the caller when API authentication is disabled.
+const AnonymousKeyID = "anonymous"
+
+// callerID returns the ID of the API key that authenticated the request, or AnonymousKeyID.
+func callerID(c *gin.Context) string {
+	if key, ok := APIKeyFromContext(c); ok {
+		return key.ID
+	}
+	return AnonymousKeyID
+}
+
+// rateLimit returns a middleware that limits the requests per second and the concurrent
+// requests of each API key. Public routes are not limited.
+func (api *API) rateLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Limiter == nil || isPublicRoute(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+		id := callerID(c)
+
+		decision := api.Limiter.Allow(id)
+		setRateLimitHeaders(c, decision)
+		if !decision.Allowed {
+			abortRateLimited(c, decision.Reset, api_types.CodeRateLimited, "Rate limit exceeded")
+			return
+		}
+
+		release, ok := api.Limiter.Acquire(id)
+		if !ok {
+			abortRateLimited(c, time.Second, api_types.CodeRateLimited, "Too many concurrent requests")
+			return
+		}
+		defer release()
+		c.Next()
+	}
+}
+
+// workUnits returns a middleware that charges a work unit of the given type to the caller's
+// daily quota. The unit is reserved before the request is handled and only charged if it succeeds.
+// The RateLimit-* headers of the daily quota replace the per-second ones.
+func (api *API) workUnits(workType data_types.WorkerType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if api.Limiter == nil {
+			c.Next()
+			return
+		}
+		id := callerID(c)
+
+		decision, err := api.Limiter.Reserve(id, string(workType))
+		if err != nil {
+			handleError(c, "Failed to check the daily quota", err)
+			c.Abort()
+			return
+		}
+		setRateLimitHeaders(c, decision)
+		if !decision.Allowed {
+			if err := api.Limiter.Reject(id, string(workType)); err != nil {
+				logrus.Errorf("[-] Error recording usage: %v", err)
+			}
+			abortRateLimited(c, decision.Reset, api_types.CodeQuotaExceeded, fmt.Sprintf("Daily quota of %d %s work units exceeded", decision.Limit, workType))
+			return
+		}
+
+		c.Next()
+
+		if err := api.Limiter.Complete(id, string(workType), c.Writer.Status() < http.StatusBadRequest); err != nil {
+			logrus.Errorf("[-] Error recording usage: %v", err)
+		}
+	}
+}
+
+// setRateLimitHeaders sets the RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and
+// RateLimit-Policy headers (draft-ietf-httpapi-ratelimit-headers) for a limited request.
+func setRateLimitHeaders(c *gin.Context, d ratelimit.Decision) {
+	if d.Limit <= 0 {
+		return
+	}
+	c.Header("RateLimit-Limit", strconv.Itoa(d.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(d.Reset)))
+	c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", d.Limit, ceilSeconds(d.Window)))
+}
+
+func abortRateLimited(c *gin.Context, retryAfter time.Duration, code api_types.ErrorCode, message string) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Max(1, float64(ceilSeconds(retryAfter))))))
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, api_types.ErrorResponse{Error: message, Code: code})
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
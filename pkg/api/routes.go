@@ -4,18 +4,18 @@ import (
 	"embed"
 	"html/template"
 	"net/http"
-	"os"
 	"strings"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 
 	"github.com/masa-finance/masa-oracle/docs"
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
 	"github.com/masa-finance/masa-oracle/pkg/pubsub"
 	"github.com/masa-finance/masa-oracle/pkg/workers"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
 
 	"github.com/gin-contrib/cors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"path/filepath"
 	"runtime"
@@ -33,99 +33,32 @@ var htmlTemplates embed.FS
 // Routes are added for peers, ads, subscriptions, node data, public keys,
 // topics, the DHT, node status, and serving HTML pages. Middleware is added
 // for CORS and templates.
-func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManager, pubkeySubscriptionHandler *pubsub.PublicKeySubscriptionHandler) *gin.Engine {
+func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManager, pubkeySubscriptionHandler *pubsub.PublicKeySubscriptionHandler, opts ...Option) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
 
-	API := NewAPI(node, workerManager, pubkeySubscriptionHandler)
+	API := NewAPI(node, workerManager, pubkeySubscriptionHandler, opts...)
 
 	// Initialize CORS middleware with a configuration that allows all origins and specifies
 	// the HTTP methods and headers that can be used in requests.
 	router.Use(cors.New(cors.Config{
-		AllowAllOrigins:     true,                                      // Allow requests from any origin
-		AllowMethods:        []string{"GET", "POST", "PUT", "OPTIONS"}, // Specify allowed methods
-		AllowHeaders:        []string{"Origin", "Authorization"},       // Specify allowed headers
+		AllowAllOrigins:     true,                                                                // Allow requests from any origin
+		AllowMethods:        []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},                 // Specify allowed methods
+		AllowHeaders:        []string{"Origin", "Authorization", "X-API-Key", "Idempotency-Key"}, // Specify allowed headers
+		ExposeHeaders:       []string{"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After", "Idempotent-Replayed"},
 		AllowPrivateNetwork: true,
 	}))
 
-	// Define a list of routes that should not require authentication.
-	ignoredRoutes := []string{
-		"/status",
-	}
-
-	// Middleware to enforce API token authentication, excluding ignored routes.
-	router.Use(func(c *gin.Context) {
-
-		if API.Node.Options.IsStaked {
-			c.Next() // Proceed to the next middleware or handler as a staked node.
-			return
-		}
-
-		// Iterate over the ignored routes to determine if the current request should bypass authentication.
-		for _, route := range ignoredRoutes {
-			if c.Request.URL.Path == route {
-				c.Next() // Proceed to the next middleware or handler without authentication.
-				return
-			}
-			if strings.HasPrefix(c.Request.URL.Path, "/auth") {
-				c.Next() // Proceed to the next middleware or handler without authentication.
-				return
-			}
-			if strings.HasPrefix(c.Request.URL.Path, "/health") {
-				c.Next() // Proceed to the next middleware or handler without authentication.
-				return
-			}
-			if strings.HasPrefix(c.Request.URL.Path, "/swagger") {
-				c.Next() // Proceed to the next middleware or handler without authentication.
-				return
-			}
-		}
-
-		// Define the prefix expected in the Authorization header.
-		const BearerSchema = "Bearer "
-		// Retrieve the Authorization header from the request.
-		authHeader := c.GetHeader("Authorization")
-		// If the Authorization header is missing, abort the request with an unauthorized status code and message.
-		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API token required"})
-			return
-		}
-		// Extract the token from the Authorization header by removing the Bearer schema prefix.
-		token := authHeader[len(BearerSchema):]
+	// Middleware to record a span for each request, joining the trace of the caller if any.
+	router.Use(traceRequests())
 
-		// Validate the token against the expected API key stored in environment variables.
-		// logrus.Info(os.Getenv("API_KEY"))
-		if os.Getenv("API_KEY") == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "JWT Token required"})
-			return
-		}
+	// Middleware to enforce API key authentication, excluding public routes.
+	// Each route then requires the scope it needs.
+	router.Use(API.authenticate())
 
-		// Validate the JWT token
-		claims := jwt.MapClaims{}
-		_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
-			//@todo decode the token get the apiKey, hash and compare it
-			//if token != ???? {
-			//	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid API token"})
-			//	return
-			//} else {
-			//	c.Next()
-			//	return
-			//}
-			return []byte(API.Node.Host.ID().String()), nil
-		})
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid JWT token"})
-			return
-		}
-		// Check if the token has expired
-		if exp, ok := claims["exp"].(float64); ok {
-			if int64(exp) < time.Now().Unix() {
-				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "JWT token has expired"})
-				return
-			}
-		}
-		c.Next()
-	})
+	// Middleware to enforce the per-key request rate and concurrency limits.
+	// Data routes also charge work units to the key's daily quota.
+	router.Use(API.rateLimit())
 
 	// Serving html
 	templ := template.Must(template.ParseFS(htmlTemplates, "templates/*.html"))
@@ -138,6 +71,13 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 
 	setupSwaggerHandler(router)
 
+	dataRead := API.requireScope(apikey.ScopeDataRead)
+	dhtWrite := API.requireScope(apikey.ScopeDHTWrite)
+	topicsPublish := API.requireScope(apikey.ScopeTopicsPublish)
+	admin := API.requireScope(apikey.ScopeAdmin)
+	// Retries of data requests and DHT writes with the same Idempotency-Key get the original response.
+	idempotent := API.idempotent()
+
 	v1 := router.Group("/api/v1")
 	{
 
@@ -148,7 +88,7 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Produce  json
 		// @Success 200 {array} string "List of peer IDs"
 		// @Router /peers [get]
-		v1.GET("/peers", API.GetPeersHandler())
+		v1.GET("/peers", dataRead, API.GetPeersHandler())
 
 		// @Summary Get peer addresses
 		// @Description Retrieves a list of peer addresses connected to the node
@@ -157,7 +97,7 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Produce  json
 		// @Success 200 {array} string "List of peer addresses"
 		// @Router /peer/addresses [get]
-		v1.GET("/peer/addresses", API.GetPeerAddresses())
+		v1.GET("/peer/addresses", dataRead, API.GetPeerAddresses())
 
 		// @Summary Search Followers by Twitter Username
 		// @Description Retrieves followers from a specific Twitter profile.
@@ -166,10 +106,13 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Produce  json
 		// @Param   username   path    string  true  "Twitter Username"
 		// @Param   count   query   int     false  "Maximum number of users to return"  default(20)
+		// @Param   sealing   query   string  false  "Return sealed, unsealed or both results"  Enums(sealed, unsealed, both)
+		// @Param   teeEndpoint   query   string  false  "Name of the configured tee endpoint used to unseal the result"
 		// @Success 200 {array} Profile "Array of profiles a user has as followers"
 		// @Failure 400 {object} ErrorResponse "Invalid username or error fetching followers"
+		// @Failure 429 {object} ErrorResponse "Rate limit or daily quota exceeded"
 		// @Router /data/twitter/followers/{username} [get]
-		v1.GET("/data/twitter/followers/:username", API.SearchTwitterFollowers())
+		v1.GET("/data/twitter/followers/:username", dataRead, API.workUnits(data_types.TwitterFollowers), API.SearchTwitterFollowers())
 
 		// @Summary Search Twitter Profile
 		// @Description Retrieves tweets from a specific Twitter profile
@@ -177,10 +120,13 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Accept  json
 		// @Produce  json
 		// @Param   username   path    string  true  "Twitter Username"
+		// @Param   sealing   query   string  false  "Return sealed, unsealed or both results"  Enums(sealed, unsealed, both)
+		// @Param   teeEndpoint   query   string  false  "Name of the configured tee endpoint used to unseal the result"
 		// @Success 200 {array} Tweet "List of tweets from the profile"
 		// @Failure 400 {object} ErrorResponse "Invalid username or error fetching tweets"
+		// @Failure 429 {object} ErrorResponse "Rate limit or daily quota exceeded"
 		// @Router /data/twitter/profile/{username} [get]
-		v1.GET("/data/twitter/profile/:username", API.SearchTweetsProfile())
+		v1.GET("/data/twitter/profile/:username", dataRead, API.workUnits(data_types.TwitterProfile), API.SearchTweetsProfile())
 
 		// @Summary Search recent tweets
 		// @Description Retrieves recent tweets based on query parameters, supporting advanced search options
@@ -188,8 +134,13 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Accept json
 		// @Produce json
 		// @Param body body object true "Search Query"
+		// @Param   sealing   query   string  false  "Return sealed, unsealed or both results"  Enums(sealed, unsealed, both)
+		// @Param   teeEndpoint   query   string  false  "Name of the configured tee endpoint used to unseal the result"
+		// @Param   Idempotency-Key   header    string  false  "Key making retries of the request return the original response"
 		// @Success 200 {array} Tweet "List of recent tweets"
 		// @Failure 400 {object} ErrorResponse "Invalid query or error fetching tweets"
+		// @Failure 422 {object} ErrorResponse "Idempotency-Key reused for a different request"
+		// @Failure 429 {object} ErrorResponse "Rate limit or daily quota exceeded"
 		// @Router /data/twitter/tweets/recent [post]
 		// @Param body body object true "Search Query" SchemaExample({"query": "#MasaNode", "count": 10})
 		// @Example hashtag {"query": "#MasaNode", "count": 10}
@@ -207,18 +158,23 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Example urlInclusion {"query": "url:\"http://example.com\"", "count": 10}
 		// @Example questionFilter {"query": "Masa ?", "count": 10}
 		// @Example safeSearch {"query": "Masa filter:safe", "count": 10}
-		v1.POST("/data/twitter/tweets/recent", API.SearchTweetsRecent())
+		v1.POST("/data/twitter/tweets/recent", dataRead, idempotent, API.workUnits(data_types.Twitter), API.SearchTweetsRecent())
 
 		// @Summary Web Data
 		// @Description Retrieves data from the web
 		// @Tags Web
 		// @Accept  json
 		// @Produce  json
-		// @Param   url   body    object  true  "Web Data Request"  example({"url": "https://hedgey.finance/"})
+		// @Param   url   body    object  true  "Web Data Request"  example({"url": "https://hedgey.finance/", "depth": 1})
+		// @Param   sealing   query   string  false  "Return sealed, unsealed or both results"  Enums(sealed, unsealed, both)
+		// @Param   teeEndpoint   query   string  false  "Name of the configured tee endpoint used to unseal the result"
+		// @Param   Idempotency-Key   header    string  false  "Key making retries of the request return the original response"
 		// @Success 200 {object} WebDataResponse "Successfully retrieved web data"
 		// @Failure 400 {object} ErrorResponse "Invalid URL or error fetching web data"
+		// @Failure 422 {object} ErrorResponse "Idempotency-Key reused for a different request"
+		// @Failure 429 {object} ErrorResponse "Rate limit or daily quota exceeded"
 		// @Router /data/web [post]
-		v1.POST("/data/web", API.WebData())
+		v1.POST("/data/web", dataRead, idempotent, API.workUnits(data_types.Web), API.WebData())
 
 		// @Summary Get DHT Data
 		// @Description Retrieves data from the DHT (Distributed Hash Table)
@@ -228,7 +184,7 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Success 200 {object} DHTResponse "Successfully retrieved data from DHT"
 		// @Failure 400 {object} ErrorResponse "Error retrieving data from DHT"
 		// @Router /dht [get]
-		v1.GET("/dht", API.GetFromDHT())
+		v1.GET("/dht", dataRead, API.GetFromDHT())
 
 		// @Summary Post to DHT
 		// @Description Adds data to the DHT (Distributed Hash Table)
@@ -236,20 +192,45 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Accept  json
 		// @Produce  json
 		// @Param   data   body    string  true  "Data to store in DHT"
+		// @Param   Idempotency-Key   header    string  false  "Key making retries of the request return the original response"
 		// @Success 200 {object} SuccessResponse "Successfully added data to DHT"
 		// @Failure 400 {object} ErrorResponse "Error adding data to DHT"
+		// @Failure 422 {object} ErrorResponse "Idempotency-Key reused for a different request"
 		// @Router /dht [post]
-		v1.POST("/dht", API.PostToDHT())
+		v1.POST("/dht", dhtWrite, idempotent, API.PostToDHT())
 
 		// @Summary Node Data
 		// @Description Retrieves data from the node
 		// @Tags Node
 		// @Accept  json
 		// @Produce  json
+		// @Param   pageNbr    query   int     false  "Page number, starting at 0"  default(0)
+		// @Param   pageSize   query   int     false  "Number of nodes per page"  default(25)
 		// @Success 200 {object} NodeDataResponse "Successfully retrieved node data"
 		// @Failure 400 {object} ErrorResponse "Error retrieving node data"
 		// @Router /node/data [get]
-		v1.GET("/node/data", API.GetNodeDataHandler())
+		v1.GET("/node/data", dataRead, API.GetNodeDataHandler())
+
+		// @Summary Node Directory
+		// @Description Queries the node directory with filters, sorting, cursor pagination and field projection
+		// @Tags Node
+		// @Produce  json
+		// @Param   staked          query   bool    false  "Only staked (true) or unstaked (false) nodes"
+		// @Param   active          query   bool    false  "Only active (true) or inactive (false) nodes"
+		// @Param   validator       query   bool    false  "Only validators (true) or non-validators (false)"
+		// @Param   capability      query   string  false  "Work type the node can do, e.g. twitter or web"
+		// @Param   version         query   string  false  "Node version"
+		// @Param   minUptime       query   string  false  "Minimum accumulated uptime, e.g. 24h"
+		// @Param   minReliability  query   number  false  "Minimum reliability score, between 0 and 1"
+		// @Param   sort            query   string  false  "Sort key"  Enums(peerId, version, firstJoined, lastJoined, lastUpdated, uptime, reliability)  default(peerId)
+		// @Param   order           query   string  false  "Sort order"  Enums(asc, desc)  default(asc)
+		// @Param   limit           query   int     false  "Maximum number of nodes to return"  default(100)
+		// @Param   cursor          query   string  false  "nextCursor of the previous page"
+		// @Param   fields          query   string  false  "Comma-separated fields to return, e.g. peerId,version,reliability"
+		// @Success 200 {object} map[string]interface{} "Successfully queried the node directory"
+		// @Failure 400 {object} ErrorResponse "Invalid query"
+		// @Router /nodes [get]
+		v1.GET("/nodes", dataRead, API.QueryNodesHandler())
 
 		// @Summary Get Node Data by Peer ID
 		// @Description Retrieves data for a specific node identified by peer ID
@@ -260,7 +241,7 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Success 200 {object} NodeDataResponse "Successfully retrieved node data by peer ID"
 		// @Failure 400 {object} ErrorResponse "Error retrieving node data by peer ID"
 		// @Router /node/data/{peerid} [get]
-		v1.GET("/node/data/:peerid", API.GetNodeHandler())
+		v1.GET("/node/data/:peerid", dataRead, API.GetNodeHandler())
 
 		// @Summary Update Node Status
 		// @Description Updates the status of the node
@@ -271,7 +252,215 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Success 200 {object} SuccessResponse "Successfully updated node status"
 		// @Failure 400 {object} ErrorResponse "Error updating node status"
 		// @Router /node/status [post]
-		v1.POST("/node/status", API.PostNodeStatusHandler())
+		v1.POST("/node/status", admin, API.PostNodeStatusHandler())
+
+		// @Summary Worker Diagnostics
+		// @Description Retrieves the eligible workers per category with their measured latency, and local handler metrics
+		// @Tags Node
+		// @Accept  json
+		// @Produce  json
+		// @Success 200 {object} workers.WorkerDiagnostics "Successfully retrieved worker diagnostics"
+		// @Failure 500 {object} ErrorResponse "Error retrieving worker diagnostics"
+		// @Router /workers/diagnostics [get]
+		v1.GET("/workers/diagnostics", dataRead, API.GetWorkerDiagnosticsHandler())
+
+		// @Summary Audit Log
+		// @Description Queries the audit log of the work requests this node originated or executed
+		// @Tags Node
+		// @Accept  json
+		// @Produce  json
+		// @Param   from       query   string  false  "Start of the time range (RFC 3339)"
+		// @Param   to         query   string  false  "End of the time range (RFC 3339)"
+		// @Param   peer       query   string  false  "Requester or worker peer ID"
+		// @Param   workType   query   string  false  "Work type, e.g. twitter or web"
+		// @Param   outcome    query   string  false  "Outcome of the request"  Enums(success, failure)
+		// @Param   role       query   string  false  "Role of this node"  Enums(originated, executed)
+		// @Param   limit      query   int     false  "Maximum number of records to return"  default(100)
+		// @Success 200 {array} audit.WorkEvent "Successfully retrieved audit records"
+		// @Failure 400 {object} ErrorResponse "Invalid filter"
+		// @Router /audit [get]
+		v1.GET("/audit", admin, API.GetAuditLogHandler())
+
+		// @Summary Usage
+		// @Description Retrieves the work units used per API key, work type and day, and the limits applied to each key. Only admin keys can see the usage of other keys
+		// @Tags Authentication
+		// @Produce  json
+		// @Param   key        query   string  false  "API key ID (admin only)"
+		// @Param   workType   query   string  false  "Work type, e.g. twitter or web"
+		// @Param   from       query   string  false  "First day (YYYY-MM-DD)"
+		// @Param   to         query   string  false  "Last day (YYYY-MM-DD)"
+		// @Success 200 {array} ratelimit.Usage "Successfully retrieved usage"
+		// @Failure 400 {object} ErrorResponse "Invalid filter"
+		// @Router /usage [get]
+		v1.GET("/usage", dataRead, API.GetUsageHandler())
+
+		if API.APIKeys != nil {
+			// @Summary List API Keys
+			// @Description Lists the API keys issued by this node, without the keys themselves
+			// @Tags Authentication
+			// @Produce  json
+			// @Success 200 {array} apikey.Key "Successfully retrieved API keys"
+			// @Router /apikeys [get]
+			v1.GET("/apikeys", admin, API.ListAPIKeysHandler())
+
+			// @Summary Create API Key
+			// @Description Issues a new API key with the given label, scopes and expiry. The key is only shown in this response
+			// @Tags Authentication
+			// @Accept  json
+			// @Produce  json
+			// @Param   body   body    object  true  "API key request"  example({"label": "partner-team", "scopes": ["data:read"], "expiresIn": "720h"})
+			// @Success 200 {object} apikey.Key "Successfully created API key"
+			// @Failure 400 {object} ErrorResponse "Invalid label, scopes or expiry"
+			// @Router /apikeys [post]
+			v1.POST("/apikeys", admin, API.CreateAPIKeyHandler())
+
+			// @Summary Revoke API Key
+			// @Description Revokes the API key with the given ID, effective immediately
+			// @Tags Authentication
+			// @Produce  json
+			// @Param   id   path    string  true  "API key ID"
+			// @Success 200 {object} apikey.Key "Successfully revoked API key"
+			// @Failure 404 {object} ErrorResponse "API key not found"
+			// @Router /apikeys/{id} [delete]
+			v1.DELETE("/apikeys/:id", admin, API.RevokeAPIKeyHandler())
+		}
+
+		// The admin routes change the node at runtime, so they are never open, even without API keys
+		adminRoutes := v1.Group("/admin", API.requireAuthentication(), admin)
+		{
+			// @Summary Get Log Level
+			// @Description Retrieves the current log level
+			// @Tags Admin
+			// @Produce  json
+			// @Success 200 {object} SuccessResponse "Successfully retrieved the log level"
+			// @Router /admin/loglevel [get]
+			adminRoutes.GET("/loglevel", API.GetLogLevelHandler())
+
+			// @Summary Set Log Level
+			// @Description Changes the log level at runtime
+			// @Tags Admin
+			// @Accept  json
+			// @Produce  json
+			// @Param   body   body    object  true  "Log level"  example({"level": "debug"})
+			// @Success 200 {object} SuccessResponse "Successfully changed the log level"
+			// @Failure 400 {object} ErrorResponse "Invalid log level"
+			// @Router /admin/loglevel [put]
+			adminRoutes.PUT("/loglevel", API.SetLogLevelHandler())
+
+			// @Summary List Bans
+			// @Description Lists the peers banned from connecting to the node
+			// @Tags Admin
+			// @Produce  json
+			// @Success 200 {array} network.Ban "Successfully retrieved the bans"
+			// @Router /admin/bans [get]
+			adminRoutes.GET("/bans", API.ListBansHandler())
+
+			// @Summary Ban Peer
+			// @Description Bans a peer, closing its connections and refusing new ones in both directions. Without a duration the ban is permanent
+			// @Tags Admin
+			// @Accept  json
+			// @Produce  json
+			// @Param   body   body    object  true  "Ban request"  example({"peerId": "16Uiu2HAm...", "reason": "spam", "duration": "24h"})
+			// @Success 200 {object} network.Ban "Successfully banned the peer"
+			// @Failure 400 {object} ErrorResponse "Invalid peer ID or duration"
+			// @Router /admin/bans [post]
+			adminRoutes.POST("/bans", API.BanPeerHandler())
+
+			// @Summary Unban Peer
+			// @Description Lifts the ban of a peer
+			// @Tags Admin
+			// @Produce  json
+			// @Param   peerId   path    string  true  "Peer ID"
+			// @Success 200 {object} SuccessResponse "Successfully unbanned the peer"
+			// @Failure 404 {object} ErrorResponse "Peer is not banned"
+			// @Router /admin/bans/{peerId} [delete]
+			adminRoutes.DELETE("/bans/:peerId", API.UnbanPeerHandler())
+
+			// @Summary Disconnect Peer
+			// @Description Closes every connection to a peer, which can connect again
+			// @Tags Admin
+			// @Produce  json
+			// @Param   peerId   path    string  true  "Peer ID"
+			// @Success 200 {object} SuccessResponse "Successfully disconnected the peer"
+			// @Failure 404 {object} ErrorResponse "Peer is not connected"
+			// @Router /admin/peers/{peerId}/disconnect [post]
+			adminRoutes.POST("/peers/:peerId/disconnect", API.DisconnectPeerHandler())
+
+			// @Summary Get Worker Capabilities
+			// @Description Retrieves which kinds of work the node does for the network
+			// @Tags Admin
+			// @Produce  json
+			// @Success 200 {object} SuccessResponse "Successfully retrieved the worker capabilities"
+			// @Router /admin/capabilities [get]
+			adminRoutes.GET("/capabilities", API.GetCapabilitiesHandler())
+
+			// @Summary Set Worker Capability
+			// @Description Turns a worker capability on or off without a restart, and announces it to the network
+			// @Tags Admin
+			// @Accept  json
+			// @Produce  json
+			// @Param   capability   path    string  true  "Worker capability"  Enums(twitter, web)
+			// @Param   body   body    object  true  "Capability state"  example({"enabled": false})
+			// @Success 200 {object} SuccessResponse "Successfully updated the worker capability"
+			// @Failure 400 {object} ErrorResponse "Unsupported capability"
+			// @Router /admin/capabilities/{capability} [put]
+			adminRoutes.PUT("/capabilities/:capability", API.SetCapabilityHandler())
+
+			// @Summary Refresh DHT
+			// @Description Refreshes the DHT routing table and returns its size
+			// @Tags Admin
+			// @Produce  json
+			// @Success 200 {object} SuccessResponse "Successfully refreshed the routing table"
+			// @Router /admin/dht/refresh [post]
+			adminRoutes.POST("/dht/refresh", API.RefreshDHTHandler())
+
+			// @Summary Resync Node Data
+			// @Description Announces the node data of this node and sends the node directory to the connected peers
+			// @Tags Admin
+			// @Produce  json
+			// @Success 200 {object} SuccessResponse "Successfully started the resync"
+			// @Router /admin/nodedata/resync [post]
+			adminRoutes.POST("/nodedata/resync", API.ResyncNodeDataHandler())
+
+			// @Summary Get Routing Table
+			// @Description Dumps the peers of the DHT routing table
+			// @Tags Admin
+			// @Produce  json
+			// @Success 200 {array} api_types.RoutingTableEntry "Successfully retrieved the routing table"
+			// @Router /admin/routing [get]
+			adminRoutes.GET("/routing", API.GetRoutingTableHandler())
+
+			// @Summary Get Connections
+			// @Description Lists the open libp2p connections
+			// @Tags Admin
+			// @Produce  json
+			// @Success 200 {array} api_types.ConnectionInfo "Successfully retrieved the connections"
+			// @Router /admin/connections [get]
+			adminRoutes.GET("/connections", API.GetConnectionsHandler())
+
+			// @Summary Publish Validator Set
+			// @Description Replaces the validators allowed to produce blocks with a governance record, which is signed with the node key if it has no signature. The node must be the governance peer
+			// @Tags Admin
+			// @Accept  json
+			// @Produce  json
+			// @Param   record   body    chain.ValidatorSetRecord  true  "Validator set record"
+			// @Success 200 {object} chain.ValidatorSetRecord "Validator set applied and published"
+			// @Failure 400 {object} ErrorResponse "Invalid or outdated record"
+			// @Router /admin/validators [post]
+			adminRoutes.POST("/validators", API.PublishValidatorSetHandler())
+
+			// @Summary Publish Records
+			// @Description Batches records, such as work events or attestations, into one block whose header holds their Merkle root, and publishes it to the validators. The records are stored as sent
+			// @Tags Admin
+			// @Accept  json
+			// @Produce  json
+			// @Param   records   body    api_types.RecordsRequest  true  "Records of the block"
+			// @Success 200 {object} api_types.RecordsBlock "Block built and published"
+			// @Failure 400 {object} ErrorResponse "Invalid records or node is not a validator"
+			// @Failure 500 {object} ErrorResponse "Error building or publishing the block"
+			// @Router /admin/blocks [post]
+			adminRoutes.POST("/blocks", API.PublishRecordsHandler())
+		}
 
 		// @Summary Get Public Keys
 		// @Description Retrieves a list of public keys from the node
@@ -281,7 +470,7 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Success 200 {array} string "Successfully retrieved public keys"
 		// @Failure 400 {object} ErrorResponse "Error retrieving public keys"
 		// @Router /publickeys [get]
-		v1.GET("/publickeys", API.GetPublicKeysHandler())
+		v1.GET("/publickeys", dataRead, API.GetPublicKeysHandler())
 
 		// @Summary Publish Public Key
 		// @Description Publishes a new public key to the node
@@ -292,7 +481,7 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Success 200 {object} SuccessResponse "Successfully published public key"
 		// @Failure 400 {object} ErrorResponse "Error publishing public key"
 		// @Router /publickey/publish [post]
-		v1.POST("/publickey/publish", API.PublishPublicKeyHandler())
+		v1.POST("/publickey/publish", topicsPublish, API.PublishPublicKeyHandler())
 
 		// @Summary Create New Topic
 		// @Description Creates a new discussion topic
@@ -303,7 +492,7 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Success 201 {object} TopicResponse "Successfully created new topic"
 		// @Failure 400 {object} ErrorResponse "Error creating new topic"
 		// @Router /topic/create [post]
-		v1.POST("/topic/create", API.CreateNewTopicHandler())
+		v1.POST("/topic/create", topicsPublish, API.CreateNewTopicHandler())
 
 		// @Summary Post to a Topic
 		// @Description Adds a post to an existing discussion topic
@@ -314,17 +503,37 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Success 200 {object} PostResponse "Successfully added post to topic"
 		// @Failure 400 {object} ErrorResponse "Error adding post to topic"
 		// @Router /topic/post [post]
-		v1.POST("/topic/post", API.PostToTopicHandler())
+		v1.POST("/topic/post", topicsPublish, API.PostToTopicHandler())
+
+		// @Summary Stream a Topic
+		// @Description Streams the messages of a topic created through the API in real time, with the sender's peer ID and the time they were received. WebSocket upgrade requests get one JSON message per frame, other requests get server-sent events
+		// @Tags Topics
+		// @Produce  text/event-stream
+		// @Param   name     path    string  true   "Topic name"
+		// @Param   buffer   query   int     false  "Messages buffered for the client before messages are dropped"  default(64)
+		// @Param   apiKey   query   string  false  "API key, for clients that can't set headers"
+		// @Success 200 {object} pubsub.StreamMessage "Stream of topic messages"
+		// @Failure 400 {object} ErrorResponse "Invalid buffer size"
+		// @Failure 404 {object} ErrorResponse "The topic wasn't created through the API"
+		// @Failure 429 {object} ErrorResponse "Too many streams for the API key or the topic"
+		// @Failure 500 {object} ErrorResponse "Error subscribing to the topic"
+		// @Router /topic/{name}/stream [get]
+		v1.GET("/topic/:name/stream", dataRead, API.StreamTopicHandler())
 
 		// @Summary Get Blocks
-		// @Description Retrieves the list of blocks from the blockchain
+		// @Description Retrieves a page of the blocks of the blockchain, the last one first. Without "from", the page ends with "to", the last block by default; without "to", it starts with "from"
 		// @Tags Blocks
 		// @Accept  json
 		// @Produce  json
+		// @Param   from    query   int  false  "Number of the first block"
+		// @Param   to      query   int  false  "Number of the last block"
+		// @Param   limit   query   int  false  "Maximum number of blocks, up to 1000"  default(100)
 		// @Success 200 {object} Blocks "Successfully retrieved blocks"
-		// @Failure 400 {object} ErrorResponse "Error retrieving blocks"
+		// @Failure 400 {object} ErrorResponse "Invalid block range"
+		// @Failure 500 {object} ErrorResponse "Error retrieving blocks"
+		// @Failure 503 {object} ErrorResponse "Blockchain not initialized"
 		// @Router /blocks [get]
-		v1.GET("/blocks", API.GetBlocks())
+		v1.GET("/blocks", dataRead, API.GetBlocks())
 
 		// @Summary Get Block by Hash
 		// @Description Retrieves a specific block from the blockchain using its hash
@@ -336,10 +545,70 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		// @Failure 400 {object} ErrorResponse "Invalid block hash"
 		// @Failure 500 {object} ErrorResponse "Error retrieving block"
 		// @Router /blocks/{blockHash} [get]
-		v1.GET("/blocks/:blockHash", API.GetBlockByHash())
+		v1.GET("/blocks/:blockHash", dataRead, API.GetBlockByHash())
+
+		// @Summary Get Finality Certificate
+		// @Description Retrieves the finality certificate of a block: the votes of at least 2/3 of the validators for it. Ancestors of a finalized block are final without a certificate
+		// @Tags Blocks
+		// @Produce  json
+		// @Param   blockHash   path    string  true  "Hash of the block"
+		// @Success 200 {object} chain.Certificate "Successfully retrieved the certificate"
+		// @Failure 400 {object} ErrorResponse "Invalid block hash"
+		// @Failure 404 {object} ErrorResponse "No certificate for the block"
+		// @Router /blocks/{blockHash}/certificate [get]
+		v1.GET("/blocks/:blockHash/certificate", dataRead, API.GetCertificateHandler())
+
+		// @Summary Get Inclusion Proof
+		// @Description Retrieves the proof that the record of a block at the given index is part of the block: the Merkle path of the record and the block header. It is checked against the block hash with chain.VerifyInclusionProof
+		// @Tags Blocks
+		// @Produce  json
+		// @Param   blockHash   path    string  true  "Hash of the block"
+		// @Param   index       path    int     true  "Index of the record in the block"
+		// @Success 200 {object} chain.InclusionProof "Successfully retrieved the proof"
+		// @Failure 400 {object} ErrorResponse "Invalid block hash or record index"
+		// @Failure 404 {object} ErrorResponse "No such block or record"
+		// @Router /blocks/{blockHash}/records/{index}/proof [get]
+		v1.GET("/blocks/:blockHash/records/:index/proof", dataRead, API.GetInclusionProofHandler())
+
+		// @Summary Get Record Proof
+		// @Description Retrieves the inclusion proof of the record of the chain with the given CID (raw SHA-256 CIDv1 of the record)
+		// @Tags Blocks
+		// @Produce  json
+		// @Param   cid   path    string  true  "CID of the record"
+		// @Success 200 {object} chain.InclusionProof "Successfully retrieved the proof"
+		// @Failure 404 {object} ErrorResponse "No such record in the chain"
+		// @Router /records/{cid}/proof [get]
+		v1.GET("/records/:cid/proof", dataRead, API.GetRecordProofHandler())
+
+		// @Summary Get Chain Status
+		// @Description Retrieves the height and hash of the last block and of the last finalized block. Finalized blocks are never reorganized
+		// @Tags Blocks
+		// @Produce  json
+		// @Success 200 {object} api_types.ChainStatus "Successfully retrieved the chain status"
+		// @Failure 400 {object} ErrorResponse "Node is not a validator"
+		// @Router /chain [get]
+		v1.GET("/chain", dataRead, API.GetChainStatusHandler())
+
+		// @Summary Get Chain Sync Status
+		// @Description Retrieves the progress of the sync of the chain from the peer with the longest chain
+		// @Tags Blocks
+		// @Produce  json
+		// @Success 200 {object} api_types.SyncStatus "Successfully retrieved the sync status"
+		// @Failure 400 {object} ErrorResponse "Node is not a validator"
+		// @Router /chain/sync [get]
+		v1.GET("/chain/sync", dataRead, API.GetChainSyncHandler())
+
+		// @Summary Get Validators
+		// @Description Retrieves the validators allowed to produce blocks, and the version of the governance record that set them
+		// @Tags Blocks
+		// @Produce  json
+		// @Success 200 {object} api_types.ValidatorSet "Successfully retrieved the validators"
+		// @Failure 400 {object} ErrorResponse "Node is not a validator"
+		// @Router /validators [get]
+		v1.GET("/validators", dataRead, API.GetValidatorsHandler())
 
-		// @note a test route
-		v1.POST("/test", API.Test())
+		// @note a test route, which makes a validator sign and publish a block, so it is never open
+		v1.POST("/test", API.requireAuthentication(), admin, API.Test())
 
 	}
 
@@ -354,7 +623,7 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 	router.GET("/status", API.NodeStatusPageHandler())
 
 	// @Summary Chat Page
-	// @Description Renders the chat page for user interaction with the AI
+	// @Description Renders a chat page that posts to a topic and streams its messages
 	// @Tags Chat
 	// @Accept  html
 	// @Produce  html
@@ -364,16 +633,6 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 	// @Router /chat [get]
 	router.GET("/chat", API.ChatPageHandler())
 
-	// @Summary Get Node API Key
-	// @Description Retrieves the API key for the node
-	// @Tags Authentication
-	// @Accept  json
-	// @Produce  json
-	// @Success 200 {object} map[string]interface{} "Successfully retrieved API key"
-	// @Failure 500 {object} ErrorResponse "Error generating API key"
-	// @Router /auth [get]
-	router.GET("/auth", API.GetNodeApiKey())
-
 	// @Summary Health Check
 	// @Description Checks the health status of the API
 	// @Tags Health
@@ -387,6 +646,32 @@ func SetupRoutes(node *node.OracleNode, workerManager *workers.WorkHandlerManage
 		})
 	})
 
+	// @Summary Liveness Check
+	// @Description Checks that the node is alive, i.e. that its libp2p host is listening. Returns 503 if it has to be restarted.
+	// @Tags Health
+	// @Produce  json
+	// @Success 200 {object} health.Report "All the critical components are up"
+	// @Failure 503 {object} health.Report "A critical component is down"
+	// @Router /healthz [get]
+	router.GET("/healthz", API.LivenessHandler())
+
+	// @Summary Readiness Check
+	// @Description Checks that the node can serve data requests: DHT routing table, bootnode connectivity, pubsub subscriptions, tee-worker, datastores and staking. Each component reports its status and last error. Returns 503 if a critical component is down.
+	// @Tags Health
+	// @Produce  json
+	// @Success 200 {object} health.Report "All the critical components are up"
+	// @Failure 503 {object} health.Report "A critical component is down"
+	// @Router /readyz [get]
+	router.GET("/readyz", API.ReadinessHandler())
+
+	// @Summary Metrics
+	// @Description Exposes the metrics of the node in the Prometheus text format, e.g. the chain sync progress and the libp2p metrics
+	// @Tags Health
+	// @Produce  plain
+	// @Success 200 {string} string "Prometheus metrics"
+	// @Router /metrics [get]
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
 	return router
 }
 
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
+	"github.com/masa-finance/masa-oracle/pkg/masacrypto"
+)
+
+// clientCertKeyPrefix prefixes the key ID of requests authenticated by a client certificate,
+// so that rate limits and usage are accounted per certificate identity.
+const clientCertKeyPrefix = "cert:"
+
+// ClientCertScopes maps client certificate identities to the scopes they are granted. An identity
+// is the subject common name or one of the DNS, email or URI subject alternative names of a certificate.
+type ClientCertScopes map[string][]apikey.Scope
+
+// WithClientCertScopes authenticates requests carrying a verified client certificate whose
+// identity is mapped to scopes, without an API key.
+func WithClientCertScopes(scopes ClientCertScopes) Option {
+	return func(api *API) {
+		api.ClientCertScopes = scopes
+	}
+}
+
+// AuthEnabled reports whether requests must be authenticated, by an API key or a client certificate.
+func (api *API) AuthEnabled() bool {
+	return api.APIKeys != nil || len(api.ClientCertScopes) > 0
+}
+
+// ClientCertKey returns the key granted to the verified client certificate of a TLS connection,
+// if its identity is mapped to scopes. The key has no secret and only lives for the request.
+func (api *API) ClientCertKey(state *tls.ConnectionState) (*apikey.Key, bool) {
+	if state == nil || len(state.VerifiedChains) == 0 || len(api.ClientCertScopes) == 0 {
+		return nil, false
+	}
+	for _, identity := range CertificateIdentities(state.VerifiedChains[0][0]) {
+		if scopes, ok := api.ClientCertScopes[identity]; ok {
+			return &apikey.Key{
+				ID:     clientCertKeyPrefix + identity,
+				Label:  identity,
+				Scopes: append([]apikey.Scope{}, scopes...),
+			}, true
+		}
+	}
+	return nil, false
+}
+
+// CertificateIdentities returns the identities of a certificate: its subject common name,
+// followed by its DNS, email and URI subject alternative names.
+func CertificateIdentities(cert *x509.Certificate) []string {
+	identities := make([]string, 0, 1+len(cert.DNSNames)+len(cert.EmailAddresses)+len(cert.URIs))
+	if cert.Subject.CommonName != "" {
+		identities = append(identities, cert.Subject.CommonName)
+	}
+	identities = append(identities, cert.DNSNames...)
+	identities = append(identities, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	return identities
+}
+
+// ParseClientCertScopes parses a semicolon-separated list of identity=scopes entries, where
+// scopes is a comma-separated list, e.g. "dashboard=data:read;ops.example.com=admin".
+func ParseClientCertScopes(s string) (ClientCertScopes, error) {
+	mapping := make(ClientCertScopes)
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		identity, scopeList, found := strings.Cut(entry, "=")
+		identity = strings.TrimSpace(identity)
+		if !found || identity == "" {
+			return nil, fmt.Errorf("invalid client certificate mapping %q, expected identity=scopes", entry)
+		}
+		scopes, err := apikey.ParseScopes(scopeList)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scopes for %s: %w", identity, err)
+		}
+		if len(scopes) == 0 {
+			return nil, fmt.Errorf("no scopes for %s", identity)
+		}
+		mapping[identity] = scopes
+	}
+	return mapping, nil
+}
+
+// TLSConfig returns the TLS configuration of the API server for the given certificate and key
+// files. With a client CA bundle, the certificates clients present must be signed by one of its
+// CAs. Clients without a certificate can still connect and authenticate with an API key.
+func TLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading the API certificate: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the client CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in the client CA bundle %s", clientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return config, nil
+}
+
+// selfSignedRenewBefore is how long before its expiry a self-signed certificate is regenerated.
+const selfSignedRenewBefore = 30 * 24 * time.Hour
+
+// EnsureSelfSignedCert generates a self-signed certificate and key at the given paths
+// unless both already exist and the certificate is valid for more than selfSignedRenewBefore.
+func EnsureSelfSignedCert(certFile, keyFile string) error {
+	_, certErr := os.Stat(certFile)
+	_, keyErr := os.Stat(keyFile)
+	if (certErr != nil && !errors.Is(certErr, os.ErrNotExist)) || (keyErr != nil && !errors.Is(keyErr, os.ErrNotExist)) {
+		return fmt.Errorf("error checking the API certificate: %w", errors.Join(certErr, keyErr))
+	}
+	if certErr == nil && keyErr == nil {
+		notAfter, err := certificateExpiry(certFile)
+		if err != nil {
+			return fmt.Errorf("error checking the API certificate: %w", err)
+		}
+		if time.Until(notAfter) > selfSignedRenewBefore {
+			return nil
+		}
+		logrus.Infof("[+] The self-signed API certificate in %s expires on %s, renewing it", certFile, notAfter.Format(time.RFC3339))
+	}
+	if err := masacrypto.GenerateSelfSignedCert(certFile, keyFile); err != nil {
+		return fmt.Errorf("error generating a self-signed API certificate: %w", err)
+	}
+	logrus.Infof("[+] Generated a self-signed API certificate in %s", certFile)
+	return nil
+}
+
+// certificateExpiry returns the expiry time of the first certificate in a PEM file.
+func certificateExpiry(certFile string) (time.Time, error) {
+	data, err := os.ReadFile(certFile)
+	if err != nil {
+		return time.Time{}, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return time.Time{}, fmt.Errorf("no certificate found in %s", certFile)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
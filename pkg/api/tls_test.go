@@ -0,0 +1,170 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
+)
+
+// newTestCert creates a certificate for the common name, signed by the parent or self-signed.
+func newTestCert(t *testing.T, commonName string, isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func tlsCertificate(cert *x509.Certificate, key *ecdsa.PrivateKey) tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{cert.Raw}, PrivateKey: key, Leaf: cert}
+}
+
+func TestParseClientCertScopes(t *testing.T) {
+	scopes, err := ParseClientCertScopes(" dashboard=data:read ; ops.example.com=admin,dht:write;")
+	require.NoError(t, err)
+	assert.Equal(t, ClientCertScopes{
+		"dashboard":       {apikey.ScopeDataRead},
+		"ops.example.com": {apikey.ScopeAdmin, apikey.ScopeDHTWrite},
+	}, scopes)
+
+	for _, invalid := range []string{"dashboard", "=admin", "dashboard=", "dashboard=root"} {
+		_, err := ParseClientCertScopes(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	require.NoError(t, EnsureSelfSignedCert(certFile, keyFile))
+	serverPEM, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	// An existing pair is kept
+	require.NoError(t, EnsureSelfSignedCert(certFile, keyFile))
+	kept, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	assert.Equal(t, serverPEM, kept)
+
+	ca, caKey := newTestCert(t, "test-ca", true, nil, nil)
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}), 0600))
+
+	tlsConfig, err := TLSConfig(certFile, keyFile, caFile)
+	require.NoError(t, err)
+
+	api := NewAPI(nil, nil, nil, WithClientCertScopes(ClientCertScopes{"dashboard": {apikey.ScopeDataRead}}))
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(api.authenticate())
+	router.GET("/read", api.requireScope(apikey.ScopeDataRead), func(c *gin.Context) {
+		key, _ := APIKeyFromContext(c)
+		c.String(http.StatusOK, key.ID)
+	})
+	router.GET("/admin", api.requireScope(apikey.ScopeAdmin), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	server := httptest.NewUnstartedServer(router)
+	server.TLS = tlsConfig
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(serverPEM))
+	client := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: certs,
+			ServerName:   "localhost",
+		}}}
+	}
+	get := func(c *http.Client, path string) (int, error) {
+		resp, err := c.Get(server.URL + path)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	dashboard := tlsCertificate(newTestCert(t, "dashboard", false, ca, caKey))
+	status, err := get(client(dashboard), "/read")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	status, err = get(client(dashboard), "/admin")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, status)
+
+	// A certificate from the CA without mapped scopes still needs an API key
+	other := tlsCertificate(newTestCert(t, "other", false, ca, caKey))
+	status, err = get(client(other), "/read")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// Clients without a certificate connect, and still need an API key. So do clients with a
+	// certificate from another CA, which isn't sent since the server only accepts its client CAs
+	status, err = get(client(), "/read")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	untrusted := tlsCertificate(newTestCert(t, "dashboard", false, nil, nil))
+	status, err = get(client(untrusted), "/read")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
+
+func TestEnsureSelfSignedCertRenewal(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	// A certificate close to its expiry is replaced
+	cert, key := newTestCert(t, "localhost", false, nil, nil)
+	expiring := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	require.NoError(t, os.WriteFile(certFile, expiring, 0600))
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	require.NoError(t, EnsureSelfSignedCert(certFile, keyFile))
+	renewed, err := os.ReadFile(certFile)
+	require.NoError(t, err)
+	assert.NotEqual(t, expiring, renewed)
+	notAfter, err := certificateExpiry(certFile)
+	require.NoError(t, err)
+	assert.Greater(t, time.Until(notAfter), selfSignedRenewBefore)
+
+	// An unreadable certificate is reported, not overwritten
+	require.NoError(t, os.WriteFile(certFile, []byte("garbage"), 0600))
+	assert.Error(t, EnsureSelfSignedCert(certFile, keyFile))
+}
@@ -0,0 +1,269 @@
+// Package api_types holds the request and response bodies of the REST API, shared by the
+// server in pkg/api and the Go client in pkg/client.
+package api_types
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+	"github.com/masa-finance/masa-oracle/pkg/ratelimit"
+)
+
+// ErrorCode classifies an API error, so that clients can tell the errors worth retrying from the others.
+type ErrorCode string
+
+const (
+	CodeInvalidRequest ErrorCode = "invalid_request"
+	CodeUnauthorized   ErrorCode = "unauthorized"
+	CodeForbidden      ErrorCode = "forbidden"
+	CodeNotFound       ErrorCode = "not_found"
+	// CodeIdempotencyMismatch is returned when an idempotency key is reused for a different request.
+	CodeIdempotencyMismatch ErrorCode = "idempotency_mismatch"
+	// CodeRateLimited is returned when the caller exceeds its request rate or concurrency limit.
+	CodeRateLimited ErrorCode = "rate_limited"
+	// CodeQuotaExceeded is returned when the caller has used up its daily work units.
+	CodeQuotaExceeded ErrorCode = "quota_exceeded"
+	// CodeUpstreamRateLimited is returned when the data source rate limited the worker.
+	CodeUpstreamRateLimited ErrorCode = "upstream_rate_limited"
+	CodeContentPolicy       ErrorCode = "content_policy"
+	CodeNoWorkers           ErrorCode = "no_workers"
+	CodeTimeout             ErrorCode = "timeout"
+	CodeUnavailable         ErrorCode = "unavailable"
+	CodeInternal            ErrorCode = "internal"
+)
+
+// CodeForStatus returns the error code of a response that doesn't carry one.
+func CodeForStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusUnprocessableEntity:
+		return CodeIdempotencyMismatch
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	default:
+		return CodeInternal
+	}
+}
+
+// ErrorResponse is the body of an error response. Older handlers report the error in Message.
+type ErrorResponse struct {
+	Error        string    `json:"error,omitempty"`
+	Code         ErrorCode `json:"code,omitempty"`
+	Details      string    `json:"details,omitempty"`
+	WorkerPeerId string    `json:"workerPeerId,omitempty"`
+	Message      string    `json:"message,omitempty"`
+}
+
+// Response is the body of a successful response. Data is decoded into the value it points to.
+type Response struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data"`
+}
+
+// StatusResponse is the body of the responses that only report that the action was done.
+type StatusResponse struct {
+	Status string `json:"status"`
+}
+
+// Peer is an entry of the peer list.
+type Peer struct {
+	PeerId string `json:"peerId"`
+}
+
+// PeerAddresses are the addresses a peer advertises. PeerAddress is the first one.
+type PeerAddresses struct {
+	PeerId        string   `json:"peerId"`
+	PeerAddress   string   `json:"peerAddress"`
+	PeerAddresses []string `json:"peerAddresses"`
+}
+
+// PeersResponse is the body of the peer list and peer addresses responses.
+type PeersResponse struct {
+	Success    bool        `json:"success"`
+	Data       interface{} `json:"data"`
+	TotalCount int         `json:"totalCount"`
+}
+
+// NodeDataPageResponse is a page of the node data known to the node.
+type NodeDataPageResponse struct {
+	Success      bool              `json:"success"`
+	Data         []pubsub.NodeData `json:"data"`
+	PageNbr      int               `json:"pageNbr"`
+	Total        int               `json:"total"`
+	TotalRecords int               `json:"totalRecords"`
+}
+
+// NodesResponse is a page of the node directory. Each entry holds the requested fields only.
+type NodesResponse struct {
+	Success    bool                     `json:"success"`
+	Data       []map[string]interface{} `json:"data"`
+	Total      int                      `json:"total"`
+	NextCursor string                   `json:"nextCursor"`
+}
+
+// PublicKeysResponse is the body of the public key list response.
+type PublicKeysResponse struct {
+	Success    bool                      `json:"success"`
+	PublicKeys []pubsub.PublicKeyMessage `json:"publicKeys"`
+}
+
+// DHTRequest is the body of a DHT write. The value is stored JSON encoded.
+type DHTRequest struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// DHTResponse is the body of the DHT responses. Message is the value read, or the key written.
+type DHTResponse struct {
+	Success bool        `json:"success"`
+	Message interface{} `json:"message"`
+}
+
+// TopicRequest is the body of the topic creation and publication requests.
+type TopicRequest struct {
+	TopicName string `json:"topicName"`
+	Message   string `json:"message,omitempty"`
+}
+
+// RecentTweetsRequest is the body of a recent tweet search.
+type RecentTweetsRequest struct {
+	Query string `json:"query"`
+	Count int    `json:"count"`
+}
+
+// BlockData is a block of the blockchain. InputData is base64 encoded in the block list.
+type BlockData struct {
+	Block            uint64      `json:"block"`
+	InputData        interface{} `json:"input_data"`
+	TransactionHash  string      `json:"transaction_hash"`
+	PreviousHash     string      `json:"previous_hash"`
+	TransactionNonce int         `json:"nonce"`
+	Producer         string      `json:"producer,omitempty"`
+	Finalized        bool        `json:"finalized"`
+	Records          int         `json:"records,omitempty"`
+	Root             string      `json:"root,omitempty"`
+}
+
+// RecordsRequest is the body of a request to batch records into a block.
+type RecordsRequest struct {
+	Records []json.RawMessage `json:"records"`
+}
+
+// RecordsBlock is the block batching records, with the CIDs of the records by which their
+// inclusion proofs are retrieved.
+type RecordsBlock struct {
+	Block uint64   `json:"block"`
+	Hash  string   `json:"hash"`
+	Root  string   `json:"root"`
+	CIDs  []string `json:"cids"`
+}
+
+// ChainStatus is the last block and the last finalized block of the chain. Finalized blocks are
+// never reorganized.
+type ChainStatus struct {
+	Height          uint64 `json:"height"`
+	Hash            string `json:"hash"`
+	FinalizedHeight uint64 `json:"finalizedHeight"`
+	FinalizedHash   string `json:"finalizedHash"`
+}
+
+// SyncStatus is the progress of the sync of the chain from the peer with the longest chain.
+type SyncStatus struct {
+	Syncing      bool      `json:"syncing"`
+	Peer         string    `json:"peer,omitempty"`
+	Height       uint64    `json:"height"`
+	TargetHeight uint64    `json:"targetHeight"`
+	BlocksSynced uint64    `json:"blocksSynced"`
+	LastSync     time.Time `json:"lastSync,omitempty"`
+	LastError    string    `json:"lastError,omitempty"`
+}
+
+// ValidatorSet is the set of validators allowed to produce blocks.
+type ValidatorSet struct {
+	Version    uint64   `json:"version"`
+	Height     uint64   `json:"height"`
+	Validators []string `json:"validators"`
+}
+
+// BlocksResponse is the body of the block list response.
+type BlocksResponse struct {
+	// Height is the number of the last block of the chain.
+	Height    uint64      `json:"height"`
+	BlockData []BlockData `json:"blocks"`
+}
+
+// RoutingTableEntry is a peer of the DHT routing table.
+type RoutingTableEntry struct {
+	PeerId                        string    `json:"peerId"`
+	Addresses                     []string  `json:"addresses"`
+	AddedAt                       time.Time `json:"addedAt"`
+	LastUsefulAt                  time.Time `json:"lastUsefulAt"`
+	LastSuccessfulOutboundQueryAt time.Time `json:"lastSuccessfulOutboundQueryAt"`
+}
+
+// ConnectionInfo is an open libp2p connection.
+type ConnectionInfo struct {
+	PeerId     string    `json:"peerId"`
+	Direction  string    `json:"direction"`
+	LocalAddr  string    `json:"localAddr"`
+	RemoteAddr string    `json:"remoteAddr"`
+	Opened     time.Time `json:"opened"`
+	Streams    int       `json:"streams"`
+}
+
+// LogLevel is the body of the log level requests and responses.
+type LogLevel struct {
+	Level string `json:"level"`
+}
+
+// BanRequest is the body of a peer ban. An empty duration bans the peer until it is unbanned.
+type BanRequest struct {
+	PeerId   string `json:"peerId"`
+	Reason   string `json:"reason,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// CapabilityRequest is the body of a worker capability update.
+type CapabilityRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// PeerCount is the number of peers affected by an admin action.
+type PeerCount struct {
+	Peers int `json:"peers"`
+}
+
+// APIKeyRequest is the body of an API key creation. ExpiresIn is a duration such as "720h".
+type APIKeyRequest struct {
+	Label     string         `json:"label"`
+	Scopes    []apikey.Scope `json:"scopes"`
+	ExpiresIn string         `json:"expiresIn,omitempty"`
+}
+
+// APIKeyResponse is the body of an API key creation, which is the only response carrying the key.
+type APIKeyResponse struct {
+	Success bool        `json:"success"`
+	Data    *apikey.Key `json:"data"`
+	Key     string      `json:"key"`
+}
+
+// UsageResponse is the body of the usage response, with the limits applied to each key.
+type UsageResponse struct {
+	Success bool              `json:"success"`
+	Data    []ratelimit.Usage `json:"data"`
+	Limits  ratelimit.Limits  `json:"limits"`
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/masa-finance/masa-oracle/pkg/tracing"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
+)
+
+// ExecuteWork distributes a work request and waits for its response, for the APIs that don't go
+// through the REST handlers. The sealing options are applied to the response, whose Error field
+// reports a failed request. An error is returned if the request couldn't be completed before the
+// context was done or the worker response timeout elapsed.
+func (api *API) ExecuteWork(ctx context.Context, workType data_types.WorkerType, payload []byte, sealing data_types.SealingOptions) (data_types.WorkResponse, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return data_types.WorkResponse{}, fmt.Errorf("failed to load API config: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, cfg.WorkerResponseTimeout)
+	defer cancel()
+
+	api.sendTrackingEvent(workType, payload)
+	request := data_types.WorkRequest{
+		WorkType:     workType,
+		RequestId:    uuid.New().String(),
+		Data:         payload,
+		TraceContext: tracing.Inject(ctx),
+	}
+
+	responseCh := make(chan data_types.WorkResponse, 1)
+	go func() {
+		responseCh <- api.WorkManager.DistributeWork(api.Node, request)
+	}()
+
+	select {
+	case response := <-responseCh:
+		if err := response.ApplySealing(sealing, &request); err != nil {
+			return response, fmt.Errorf("failed to get response data: %w", err)
+		}
+		return response, nil
+	case <-ctx.Done():
+		return data_types.WorkResponse{}, ctx.Err()
+	}
+}
@@ -0,0 +1,305 @@
+// Package apikey manages the API keys used to authenticate requests to the node's HTTP API.
+// Only a hash of each key is stored; the key itself is shown once, when it is issued.
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	leveldb "github.com/ipfs/go-ds-leveldb"
+)
+
+// Scope is a permission granted to an API key.
+type Scope string
+
+const (
+	ScopeDataRead      Scope = "data:read"      // request data and read node, DHT and chain information
+	ScopeDHTWrite      Scope = "dht:write"      // write to the DHT
+	ScopeTopicsPublish Scope = "topics:publish" // create topics and publish to them
+	ScopeAdmin         Scope = "admin"          // everything, including key management
+)
+
+// AllScopes lists every scope an API key can carry.
+var AllScopes = []Scope{ScopeDataRead, ScopeDHTWrite, ScopeTopicsPublish, ScopeAdmin}
+
+// DatastorePrefix is the namespace of the API keys in the datastore.
+const DatastorePrefix = "/apikeys"
+
+const keyPrefix = "masa"
+
+var (
+	ErrInvalidKey = errors.New("invalid API key")
+	ErrExpiredKey = errors.New("API key has expired")
+	ErrRevokedKey = errors.New("API key has been revoked")
+	ErrNotFound   = errors.New("API key not found")
+)
+
+// Key is the stored record of an API key. It never contains the key itself.
+type Key struct {
+	ID        string     `json:"id"`
+	Label     string     `json:"label"`
+	Scopes    []Scope    `json:"scopes"`
+	Hash      string     `json:"-"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// storedKey is the datastore representation of a Key, which includes the hash.
+type storedKey struct {
+	Key
+	Hash string `json:"hash"`
+}
+
+// HasScope reports whether the key grants the scope. The admin scope grants every scope.
+func (k *Key) HasScope(scope Scope) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Expired reports whether the key has expired at the given time.
+func (k *Key) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// Store keeps the API keys in a datastore, under DatastorePrefix. Keys are cached in memory, so that
+// authentication doesn't hit the disk and revocations take effect immediately.
+type Store struct {
+	datastore ds.Datastore
+	owned     bool // whether the datastore was opened by the store, and is closed with it
+	keys      map[string]*Key
+	mu        sync.RWMutex
+}
+
+// Open opens (or creates) the LevelDB datastore at the given path, usually the node datastore, and
+// the API key store in it.
+func Open(path string) (*Store, error) {
+	datastore, err := leveldb.NewDatastore(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error opening API key store: %w", err)
+	}
+	s, err := NewStore(datastore)
+	if err != nil {
+		_ = datastore.Close()
+		return nil, err
+	}
+	s.owned = true
+	return s, nil
+}
+
+// NewStore creates a Store on top of the given datastore and loads the existing keys. The
+// datastore is shared with its other users, and isn't closed with the store.
+func NewStore(datastore ds.Datastore) (*Store, error) {
+	s := &Store{datastore: datastore, keys: make(map[string]*Key)}
+	results, err := datastore.Query(context.Background(), query.Query{Prefix: DatastorePrefix})
+	if err != nil {
+		return nil, fmt.Errorf("error loading API keys: %w", err)
+	}
+	defer results.Close()
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, fmt.Errorf("error loading API keys: %w", result.Error)
+		}
+		var stored storedKey
+		if err := json.Unmarshal(result.Value, &stored); err != nil {
+			return nil, fmt.Errorf("error decoding API key %s: %w", result.Key, err)
+		}
+		key := stored.Key
+		key.Hash = stored.Hash
+		s.keys[key.ID] = &key
+	}
+	return s, nil
+}
+
+// Close closes the underlying datastore, if the store opened it.
+func (s *Store) Close() error {
+	if !s.owned {
+		return nil
+	}
+	return s.datastore.Close()
+}
+
+// Issue creates a new API key and returns its record together with the key itself,
+// which is not stored and cannot be retrieved later. A zero ttl creates a key that doesn't expire.
+func (s *Store) Issue(label string, scopes []Scope, ttl time.Duration) (*Key, string, error) {
+	if len(scopes) == 0 {
+		return nil, "", fmt.Errorf("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !validScope(scope) {
+			return nil, "", fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+
+	idBytes := make([]byte, 8)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, "", fmt.Errorf("error generating API key: %w", err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("error generating API key: %w", err)
+	}
+	id := hex.EncodeToString(idBytes)
+	token := fmt.Sprintf("%s_%s_%s", keyPrefix, id, base64.RawURLEncoding.EncodeToString(secret))
+
+	key := &Key{
+		ID:        id,
+		Label:     label,
+		Scopes:    scopes,
+		Hash:      hashToken(token),
+		CreatedAt: time.Now().UTC(),
+	}
+	if ttl > 0 {
+		expiresAt := key.CreatedAt.Add(ttl)
+		key.ExpiresAt = &expiresAt
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.put(key); err != nil {
+		return nil, "", err
+	}
+	s.keys[id] = key
+	return key.copy(), token, nil
+}
+
+// Authenticate returns the key record for a presented API key if it is valid, not expired and not revoked.
+func (s *Store) Authenticate(token string) (*Key, error) {
+	id, ok := parseID(token)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, exists := s.keys[id]
+	if !exists || subtle.ConstantTimeCompare([]byte(key.Hash), []byte(hashToken(token))) != 1 {
+		return nil, ErrInvalidKey
+	}
+	if key.RevokedAt != nil {
+		return nil, ErrRevokedKey
+	}
+	if key.Expired(time.Now()) {
+		return nil, ErrExpiredKey
+	}
+	return key.copy(), nil
+}
+
+// Revoke revokes the key with the given ID. It takes effect immediately.
+func (s *Store) Revoke(id string) (*Key, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, exists := s.keys[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	if key.RevokedAt == nil {
+		revoked := key.copy()
+		now := time.Now().UTC()
+		revoked.RevokedAt = &now
+		if err := s.put(revoked); err != nil {
+			return nil, err
+		}
+		s.keys[id] = revoked
+		key = revoked
+	}
+	return key.copy(), nil
+}
+
+// Get returns the key record with the given ID.
+func (s *Store) Get(id string) (*Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, exists := s.keys[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return key.copy(), nil
+}
+
+// List returns all key records, including revoked and expired ones, oldest first.
+func (s *Store) List() []*Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]*Key, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key.copy())
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys
+}
+
+func (s *Store) put(key *Key) error {
+	value, err := json.Marshal(storedKey{Key: *key, Hash: key.Hash})
+	if err != nil {
+		return fmt.Errorf("error encoding API key: %w", err)
+	}
+	if err := s.datastore.Put(context.Background(), ds.NewKey(DatastorePrefix+"/"+key.ID), value); err != nil {
+		return fmt.Errorf("error storing API key: %w", err)
+	}
+	return nil
+}
+
+func (k *Key) copy() *Key {
+	c := *k
+	c.Scopes = append([]Scope{}, k.Scopes...)
+	return &c
+}
+
+// ParseScopes parses a comma-separated list of scopes.
+func ParseScopes(s string) ([]Scope, error) {
+	scopes := make([]Scope, 0)
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !validScope(Scope(name)) {
+			return nil, fmt.Errorf("unknown scope %q", name)
+		}
+		scopes = append(scopes, Scope(name))
+	}
+	return scopes, nil
+}
+
+func validScope(scope Scope) bool {
+	for _, s := range AllScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// parseID extracts the key ID from a key of the form masa_<id>_<secret>.
+func parseID(token string) (string, bool) {
+	parts := strings.SplitN(token, "_", 3)
+	if len(parts) != 3 || parts[0] != keyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,82 @@
+package apikey
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	datastore := dssync.MutexWrap(ds.NewMapDatastore())
+	store, err := NewStore(datastore)
+	require.NoError(t, err)
+
+	key, token, err := store.Issue("indexer", []Scope{ScopeDataRead}, 0)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "masa_"+key.ID+"_"))
+	assert.NotContains(t, key.Hash, token)
+
+	authenticated, err := store.Authenticate(token)
+	require.NoError(t, err)
+	assert.Equal(t, "indexer", authenticated.Label)
+	assert.True(t, authenticated.HasScope(ScopeDataRead))
+	assert.False(t, authenticated.HasScope(ScopeDHTWrite))
+
+	_, err = store.Authenticate(token + "x")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	_, err = store.Authenticate("not-a-key")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	// Keys survive a restart
+	reloaded, err := NewStore(datastore)
+	require.NoError(t, err)
+	_, err = reloaded.Authenticate(token)
+	require.NoError(t, err)
+
+	_, err = store.Revoke(key.ID)
+	require.NoError(t, err)
+	_, err = store.Authenticate(token)
+	assert.ErrorIs(t, err, ErrRevokedKey)
+	_, err = store.Revoke("unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	reloaded, err = NewStore(datastore)
+	require.NoError(t, err)
+	_, err = reloaded.Authenticate(token)
+	assert.ErrorIs(t, err, ErrRevokedKey)
+}
+
+func TestExpiry(t *testing.T) {
+	store, err := NewStore(dssync.MutexWrap(ds.NewMapDatastore()))
+	require.NoError(t, err)
+
+	_, token, err := store.Issue("short-lived", []Scope{ScopeAdmin}, time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = store.Authenticate(token)
+	assert.ErrorIs(t, err, ErrExpiredKey)
+}
+
+func TestScopes(t *testing.T) {
+	admin := &Key{Scopes: []Scope{ScopeAdmin}}
+	for _, scope := range AllScopes {
+		assert.True(t, admin.HasScope(scope))
+	}
+
+	scopes, err := ParseScopes("data:read, topics:publish")
+	require.NoError(t, err)
+	assert.Equal(t, []Scope{ScopeDataRead, ScopeTopicsPublish}, scopes)
+
+	_, err = ParseScopes("data:write")
+	assert.Error(t, err)
+
+	store, err := NewStore(dssync.MutexWrap(ds.NewMapDatastore()))
+	require.NoError(t, err)
+	_, _, err = store.Issue("none", nil, 0)
+	assert.Error(t, err)
+}
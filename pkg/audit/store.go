@@ -0,0 +1,273 @@
+// Package audit keeps an append-only local record of the work requests this node
+// originated or executed.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	leveldb "github.com/ipfs/go-ds-leveldb"
+	"github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Role describes this node's part in a work request.
+type Role string
+
+const (
+	RoleOriginated Role = "originated" // this node distributed the request
+	RoleExecuted   Role = "executed"   // this node executed the request for a peer
+)
+
+// Outcome is the result of a work request.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Error codes recorded for failed work requests.
+const (
+	ErrorCodeTimeout     = "timeout"
+	ErrorCodeNoWorkers   = "no_workers"
+	ErrorCodePolicy      = "content_policy"
+	ErrorCodeRateLimited = "rate_limited"
+	ErrorCodeAttestation = "attestation"
+	ErrorCodeNotFound    = "handler_not_found"
+	ErrorCodeWorker      = "worker_error"
+)
+
+const keyPrefix = "/audit"
+
+// WorkEvent is an audit record of a single work request.
+type WorkEvent struct {
+	CID             string  `json:"cid"` // CID of the request payload
+	RequestId       string  `json:"requestId"`
+	WorkType        string  `json:"workType"`
+	Role            Role    `json:"role"`
+	RequesterPeerId string  `json:"requesterPeerId"`
+	WorkerPeerId    string  `json:"workerPeerId,omitempty"`
+	Duration        float64 `json:"duration"` // in seconds
+	Outcome         Outcome `json:"outcome"`
+	ErrorCode       string  `json:"errorCode,omitempty"`
+	Error           string  `json:"error,omitempty"`
+	Timestamp       int64   `json:"timestamp"` // unix nanoseconds
+}
+
+// Filter selects audit records. Zero values match everything.
+type Filter struct {
+	From     time.Time
+	To       time.Time
+	PeerId   string // matches the requester or the worker
+	WorkType string
+	Outcome  Outcome
+	Role     Role
+	Limit    int
+}
+
+// Store is an append-only audit store backed by LevelDB. Records are keyed by their timestamp,
+// so they are kept in chronological order and time ranges can be scanned efficiently.
+// A nil *Store is valid and discards every record.
+type Store struct {
+	datastore *leveldb.Datastore
+	retention time.Duration
+	mu        sync.Mutex
+}
+
+// Open opens (or creates) the audit store at the given path. Records older than the
+// retention period are pruned by StartRetention; a zero retention keeps them forever.
+func Open(path string, retention time.Duration) (*Store, error) {
+	datastore, err := leveldb.NewDatastore(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error opening audit store: %w", err)
+	}
+	return &Store{datastore: datastore, retention: retention}, nil
+}
+
+// Close closes the underlying datastore.
+func (s *Store) Close() error {
+	if s == nil {
+		return nil
+	}
+	return s.datastore.Close()
+}
+
+// Record appends an event to the store. Existing records are never overwritten.
+func (s *Store) Record(ev WorkEvent) error {
+	if s == nil {
+		return nil
+	}
+	if ev.Timestamp == 0 {
+		ev.Timestamp = time.Now().UnixNano()
+	}
+	value, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("error encoding audit event: %w", err)
+	}
+
+	ctx := context.Background()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := eventKey(ev)
+	for i := 1; ; i++ {
+		exists, err := s.datastore.Has(ctx, key)
+		if err != nil {
+			return fmt.Errorf("error checking audit event: %w", err)
+		}
+		if !exists {
+			break
+		}
+		key = ds.NewKey(fmt.Sprintf("%s-%d", eventKey(ev).String(), i))
+	}
+	if err := s.datastore.Put(ctx, key, value); err != nil {
+		return fmt.Errorf("error storing audit event: %w", err)
+	}
+	return nil
+}
+
+// Query returns the events matching the filter, oldest first.
+func (s *Store) Query(filter Filter) ([]WorkEvent, error) {
+	events := make([]WorkEvent, 0)
+	if s == nil {
+		return events, nil
+	}
+
+	// Keys are ordered by timestamp, so only the requested time range is read
+	rng := util.BytesPrefix([]byte(keyPrefix + "/"))
+	if !filter.From.IsZero() {
+		rng.Start = []byte(timeKey(filter.From.UnixNano()))
+	}
+	if !filter.To.IsZero() {
+		rng.Limit = []byte(timeKey(filter.To.UnixNano() + 1))
+	}
+	iter := s.datastore.DB.NewIterator(rng, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		var ev WorkEvent
+		if err := json.Unmarshal(iter.Value(), &ev); err != nil {
+			logrus.Warnf("[-] Skipping unreadable audit record %s: %v", iter.Key(), err)
+			continue
+		}
+		if !filter.matches(ev) {
+			continue
+		}
+		events = append(events, ev)
+		if filter.Limit > 0 && len(events) >= filter.Limit {
+			break
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("error reading audit store: %w", err)
+	}
+	return events, nil
+}
+
+// Prune deletes the records older than the given time and returns how many were deleted.
+func (s *Store) Prune(before time.Time) (int, error) {
+	if s == nil {
+		return 0, nil
+	}
+	ctx := context.Background()
+	results, err := s.datastore.Query(ctx, query.Query{Prefix: keyPrefix, KeysOnly: true})
+	if err != nil {
+		return 0, fmt.Errorf("error querying audit store: %w", err)
+	}
+	defer results.Close()
+
+	beforeKey := timeKey(before.UnixNano())
+	pruned := 0
+	for result := range results.Next() {
+		if result.Error != nil {
+			return pruned, fmt.Errorf("error reading audit store: %w", result.Error)
+		}
+		if result.Key >= beforeKey {
+			break
+		}
+		if err := s.datastore.Delete(ctx, ds.NewKey(result.Key)); err != nil {
+			return pruned, fmt.Errorf("error deleting audit record: %w", err)
+		}
+		pruned++
+	}
+	return pruned, nil
+}
+
+// StartRetention prunes the records older than the retention period every interval until
+// the context is cancelled. It does nothing if the store keeps records forever.
+func (s *Store) StartRetention(ctx context.Context, interval time.Duration) {
+	if s == nil || s.retention <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			pruned, err := s.Prune(time.Now().Add(-s.retention))
+			if err != nil {
+				logrus.Errorf("[-] Error pruning audit store: %v", err)
+			} else if pruned > 0 {
+				logrus.Infof("[+] Pruned %d audit records older than %s", pruned, s.retention)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (f Filter) matches(ev WorkEvent) bool {
+	if f.PeerId != "" && ev.RequesterPeerId != f.PeerId && ev.WorkerPeerId != f.PeerId {
+		return false
+	}
+	if f.WorkType != "" && ev.WorkType != f.WorkType {
+		return false
+	}
+	if f.Outcome != "" && ev.Outcome != f.Outcome {
+		return false
+	}
+	if f.Role != "" && ev.Role != f.Role {
+		return false
+	}
+	return true
+}
+
+// ErrorCode classifies a work error message into one of the audit error codes.
+func ErrorCode(errMsg string) string {
+	msg := strings.ToLower(errMsg)
+	switch {
+	case errMsg == "":
+		return ""
+	case strings.Contains(msg, "content policy:"):
+		return ErrorCodePolicy
+	case strings.Contains(msg, "attestation"):
+		return ErrorCodeAttestation
+	case strings.Contains(msg, "rate limit") || strings.Contains(msg, "429"):
+		return ErrorCodeRateLimited
+	case strings.Contains(msg, "timed out") || strings.Contains(msg, "timeout") || strings.Contains(msg, "deadline exceeded"):
+		return ErrorCodeTimeout
+	case strings.Contains(msg, "no eligible workers"):
+		return ErrorCodeNoWorkers
+	case strings.Contains(msg, "work handler not found"):
+		return ErrorCodeNotFound
+	default:
+		return ErrorCodeWorker
+	}
+}
+
+// timeKey returns the key prefix for a timestamp; zero-padding keeps keys in chronological order.
+func timeKey(unixNano int64) string {
+	return fmt.Sprintf("%s/%020d", keyPrefix, unixNano)
+}
+
+func eventKey(ev WorkEvent) ds.Key {
+	return ds.NewKey(fmt.Sprintf("%s/%s/%s", timeKey(ev.Timestamp), ev.Role, ev.RequestId))
+}
@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	store, err := Open(t.TempDir(), time.Hour)
+	require.NoError(t, err)
+	defer store.Close()
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []WorkEvent{
+		{RequestId: "1", WorkType: "twitter", Role: RoleOriginated, RequesterPeerId: "self", WorkerPeerId: "peerA", Outcome: OutcomeSuccess, Timestamp: base.UnixNano()},
+		{RequestId: "2", WorkType: "web", Role: RoleExecuted, RequesterPeerId: "peerB", WorkerPeerId: "self", Outcome: OutcomeFailure, ErrorCode: ErrorCodeTimeout, Timestamp: base.Add(time.Minute).UnixNano()},
+		{RequestId: "3", WorkType: "web", Role: RoleOriginated, RequesterPeerId: "self", WorkerPeerId: "peerA", Outcome: OutcomeSuccess, Timestamp: base.Add(2 * time.Minute).UnixNano()},
+	}
+	// Record out of order, queries must still return them chronologically
+	for _, i := range []int{2, 0, 1} {
+		require.NoError(t, store.Record(events[i]))
+	}
+	// Records are appended, never overwritten
+	require.NoError(t, store.Record(events[0]))
+
+	all, err := store.Query(Filter{})
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+	assert.Equal(t, "1", all[0].RequestId)
+	assert.Equal(t, "3", all[3].RequestId)
+
+	byPeer, err := store.Query(Filter{PeerId: "peerA", WorkType: "web"})
+	require.NoError(t, err)
+	require.Len(t, byPeer, 1)
+	assert.Equal(t, "3", byPeer[0].RequestId)
+
+	byRange, err := store.Query(Filter{From: base.Add(30 * time.Second), To: base.Add(time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, byRange, 1)
+	assert.Equal(t, "2", byRange[0].RequestId)
+
+	failures, err := store.Query(Filter{Outcome: OutcomeFailure, Role: RoleExecuted})
+	require.NoError(t, err)
+	assert.Len(t, failures, 1)
+
+	limited, err := store.Query(Filter{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, limited, 2)
+
+	pruned, err := store.Prune(base.Add(90 * time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 3, pruned)
+	remaining, err := store.Query(Filter{})
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "3", remaining[0].RequestId)
+}
+
+func TestErrorCode(t *testing.T) {
+	assert.Equal(t, "", ErrorCode(""))
+	assert.Equal(t, ErrorCodeTimeout, ErrorCode("work execution timed out"))
+	assert.Equal(t, ErrorCodeNoWorkers, ErrorCode("no eligible workers found"))
+	assert.Equal(t, ErrorCodePolicy, ErrorCode("content policy: private or local address: 10.0.0.1"))
+	assert.Equal(t, ErrorCodeWorker, ErrorCode("unable to parse twitter query data"))
+}
@@ -1,24 +1,43 @@
 package chain
 
 import (
-	"bytes"
 	"encoding/base64"
-	"encoding/gob"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/sirupsen/logrus"
+
+	"github.com/masa-finance/masa-oracle/pkg/consensus"
+)
+
+var (
+	// ErrInvalidBlockSignature is returned for a block whose signature doesn't match its producer.
+	ErrInvalidBlockSignature = errors.New("invalid block signature")
+	// ErrRecordNotFound is returned for a record that isn't part of a block.
+	ErrRecordNotFound = errors.New("record not found")
 )
 
 type Block struct {
-	Block uint64
-	Data  []byte //	this block's data
-	Hash  []byte //	this block's hash
-	Link  []byte //	the hash of the last block in the chain
-	Nonce int64  //	the nonce used to sign the block for verification
+	Version uint64 `json:"version,omitempty"` //	the hash version of the block, see HashVersion
+	Block   uint64 `json:"block"`
+	Data    []byte `json:"data"`  //	this block's data
+	Hash    []byte `json:"hash"`  //	this block's hash
+	Link    []byte `json:"link"`  //	the hash of the last block in the chain
+	Nonce   int64  `json:"nonce"` //	the nonce used to sign the block for verification
+
+	Producer  string `json:"producer,omitempty"`  //	the peer ID of the validator that produced the block
+	Signature []byte `json:"signature,omitempty"` //	the producer's signature of the block hash and number
+
+	Records [][]byte `json:"records,omitempty"` //	the records batched in the block
+	Root    []byte   `json:"root,omitempty"`    //	the Merkle root of the records, part of the block hash
 }
 
 func (b *Block) Build(data []byte, link []byte, stake *big.Int, block uint64) {
+	b.Version = HashVersion
 	b.Block = block
 	b.Data = data
 	b.Link = link
@@ -27,21 +46,108 @@ func (b *Block) Build(data []byte, link []byte, stake *big.Int, block uint64) {
 	b.Nonce, b.Hash = pos.Run()
 }
 
+// BuildRecords builds a block batching the records, whose Merkle root is part of the block hash.
+func (b *Block) BuildRecords(records [][]byte, link []byte, stake *big.Int, block uint64) {
+	b.Records = records
+	b.Root = MerkleRoot(records)
+	b.Build(nil, link, stake, block)
+}
+
+// Proof returns the inclusion proof of the record of the block at the given index.
+func (b *Block) Proof(index int) (*InclusionProof, error) {
+	if index < 0 || index >= len(b.Records) {
+		return nil, fmt.Errorf("%w: block %d has %d records", ErrRecordNotFound, b.Block, len(b.Records))
+	}
+	return &InclusionProof{
+		Record: b.Records[index],
+		Index:  index,
+		Count:  len(b.Records),
+		Path:   merklePath(b.Records, index),
+		Header: BlockHeader{
+			Version:   b.Version,
+			Block:     b.Block,
+			Link:      b.Link,
+			Data:      b.Data,
+			Root:      b.Root,
+			Nonce:     b.Nonce,
+			Producer:  b.Producer,
+			Signature: b.Signature,
+		},
+	}, nil
+}
+
+// payloads returns the data and the records of the block.
+func (b *Block) payloads() [][]byte {
+	if len(b.Records) == 0 {
+		return [][]byte{b.Data}
+	}
+	if len(b.Data) == 0 {
+		return b.Records
+	}
+	return append([][]byte{b.Data}, b.Records...)
+}
+
+// PayloadSize returns the size of the data and the records of the block.
+func (b *Block) PayloadSize() int {
+	size := 0
+	for _, payload := range b.payloads() {
+		size += len(payload)
+	}
+	return size
+}
+
+// signedBytes returns the data signed by the producer of the block.
+func (b *Block) signedBytes() []byte {
+	data := make([]byte, 0, len(b.Hash)+8+len(b.Producer))
+	data = append(data, b.Hash...)
+	data = binary.BigEndian.AppendUint64(data, b.Block)
+	return append(data, b.Producer...)
+}
+
+// Sign sets the producer of the block to the peer of the key, and signs the block with it.
+// The block must be built first, since its hash is signed.
+func (b *Block) Sign(key crypto.PrivKey) error {
+	if key == nil {
+		return fmt.Errorf("no private key to sign block %d", b.Block)
+	}
+	id, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	b.Producer = id.String()
+	b.Signature, err = consensus.SignData(key, b.signedBytes())
+	return err
+}
+
+// Verify checks that the block is signed by its producer.
+func (b *Block) Verify() error {
+	id, err := peer.Decode(b.Producer)
+	if err != nil {
+		return fmt.Errorf("%w: invalid producer %q", ErrInvalidBlockSignature, b.Producer)
+	}
+	pubKey, err := id.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf("%w: failed to extract the public key of %s: %v", ErrInvalidBlockSignature, id, err)
+	}
+	if ok, err := pubKey.Verify(b.signedBytes(), b.Signature); err != nil || !ok {
+		return fmt.Errorf("%w: block %d %x", ErrInvalidBlockSignature, b.Block, b.Hash)
+	}
+	return nil
+}
+
+// Serialize returns the canonical encoding of the block, in which it is stored.
 func (b *Block) Serialize() ([]byte, error) {
-	buffer := bytes.Buffer{}
-	encoder := gob.NewEncoder(&buffer)
-	err := encoder.Encode(b)
+	data, err := encodeBlock(b)
 	if err != nil {
 		logrus.Error("[-] Failed to serialize block: ", b, err)
 	}
-	return buffer.Bytes(), err
+	return data, err
 }
 
+// Deserialize decodes a block stored in the canonical encoding. Blocks stored in the legacy gob
+// encoding return ErrLegacyEncoding until they are converted with Migrate.
 func (b *Block) Deserialize(data []byte) error {
-	buffer := bytes.Buffer{}
-	buffer.Write(data)
-	decoder := gob.NewDecoder(&buffer)
-	err := decoder.Decode(&b)
+	err := decodeBlock(data, b)
 	if err != nil {
 		logrus.Error("[-] Failed to deserialize data into block: ", data, err)
 	}
@@ -59,4 +165,9 @@ func (b *Block) Print() {
 	fmt.Printf("\t Transaction Hash:\t%x\n", b.Hash)
 	fmt.Printf("\t Previous Hash:  \t%x\n", b.Link)
 	fmt.Printf("\t Transaction Nonce:\t%d\n", b.Nonce)
+	fmt.Printf("\t Producer:  \t\t%s\n", b.Producer)
+	if len(b.Records) > 0 {
+		fmt.Printf("\t Records:  \t\t%d\n", len(b.Records))
+		fmt.Printf("\t Merkle Root:  \t\t%x\n", b.Root)
+	}
 }
@@ -1,6 +1,8 @@
 package chain_test
 
 import (
+	"bytes"
+	"encoding/gob"
 	"math/big"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -82,5 +84,38 @@ var _ = Describe("Block", func() {
 			err := block.Deserialize(invalidData)
 			Expect(err).NotTo(BeNil())
 		})
+
+		It("should report blocks stored in the legacy encoding", func() {
+			block.Build(data, link, stake, blockNumber)
+			var legacy bytes.Buffer
+			Expect(gob.NewEncoder(&legacy).Encode(block)).To(Succeed())
+
+			err := (&Block{}).Deserialize(legacy.Bytes())
+			Expect(err).To(MatchError(ErrLegacyEncoding))
+		})
+	})
+
+	Describe("Encoding", func() {
+		It("should encode and hash a block deterministically", func() {
+			block.BuildRecords([][]byte{[]byte("a"), []byte("b")}, link, stake, blockNumber)
+			Expect(block.Version).To(BeEquivalentTo(HashVersion))
+			Expect(BlockHash(block, stake)).To(Equal(block.Hash))
+			Expect(IsValidPoS(block, stake)).To(BeTrue())
+
+			first, err := block.Serialize()
+			Expect(err).NotTo(HaveOccurred())
+			decoded := &Block{}
+			Expect(decoded.Deserialize(first)).To(Succeed())
+			Expect(decoded.Hash).To(Equal(block.Hash))
+			Expect(decoded.Records).To(Equal(block.Records))
+			Expect(decoded.Root).To(Equal(block.Root))
+			second, err := decoded.Serialize()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second).To(Equal(first))
+
+			legacy := *block
+			legacy.Version = LegacyHashVersion
+			Expect(BlockHash(&legacy, stake)).NotTo(Equal(block.Hash))
+		})
 	})
 })
@@ -1,18 +1,64 @@
 package chain
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
 	"path/filepath"
+	"sync"
 
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/sirupsen/logrus"
 )
 
+// genesisTimestamp is where the search for the nonce of the genesis block starts, so that every
+// validator has the same genesis block.
+const genesisTimestamp = int64(1704067200)
+
+var (
+	// ErrInvalidBlock is returned for a block whose number, hash or proof of stake is wrong.
+	ErrInvalidBlock = errors.New("invalid block")
+	// ErrUnknownParent is returned for a block whose parent block isn't stored yet.
+	ErrUnknownParent = errors.New("unknown parent block")
+)
+
+// ImportResult describes how an imported block changed the chain.
+type ImportResult struct {
+	// Known is set if the block was already stored, in which case nothing changed.
+	Known bool
+	// Head is set if the block became the last block of the chain.
+	Head bool
+	// Reorged is the number of blocks of the former chain that are no longer part of it,
+	// when the block is the head of a fork that became the longest chain.
+	Reorged int
+	// Finalized is set if the votes received for the block before it made it final.
+	Finalized bool
+}
+
 type Chain struct {
-	LastHash     []byte
-	storage      *Persistance
-	CurrentBlock uint64
+	LastHash       []byte
+	storage        *Persistance
+	CurrentBlock   uint64
+	validators     *ValidatorSet
+	finalizedHash  []byte
+	finalizedBlock uint64
+	votes          map[string]map[string]Vote // by hex encoded block hash and voter
+	mu             sync.Mutex
+}
+
+// SetValidators sets the validators allowed to produce blocks. It must be called before Init,
+// which applies the last governance record stored on top of it. Without validators, every
+// block is rejected.
+func (c *Chain) SetValidators(validators *ValidatorSet) {
+	c.validators = validators
+}
+
+// Validators returns the validators allowed to produce blocks.
+func (c *Chain) Validators() *ValidatorSet {
+	return c.validators
 }
 
 // Init initializes the blockchain.
@@ -21,6 +67,9 @@ type Chain struct {
 // 1. Creates a data directory for storing blocks if it doesn't exist.
 // 2. Initializes the storage for the blockchain.
 // 3. Creates and stores the genesis block if the blockchain is empty.
+// 4. Indexes the blocks of the chain stored without an index.
+// 5. Applies the last validator set record stored, if any.
+// 6. Restores the last finalized block, the genesis block if none was finalized.
 //
 // Returns:
 //   - error: An error if any step in the initialization process fails, nil otherwise.
@@ -34,32 +83,176 @@ func (c *Chain) Init(path string) error {
 	}
 	logrus.WithFields(logrus.Fields{"block": Difficulty}).Info("[+] Initializing blockchain...")
 	c.storage = &Persistance{}
-	_, err := c.storage.Init(dataDir, func() (Serializable, []byte) {
-		genesisBlock := makeGenesisBlock()
+	lastHash, err := c.storage.Init(dataDir, func() (Serializable, []byte) {
+		genesisBlock := genesis()
 		return genesisBlock, genesisBlock.Hash
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	lastBlock, err := c.getBlock(lastHash)
+	if errors.Is(err, ErrLegacyEncoding) {
+		// Release the datastore for the migration
+		_ = c.storage.Close()
+		c.storage = nil
+		return fmt.Errorf("%w: convert the chain in %s with --migrateChain first", err, dataDir)
+	}
+	if err != nil {
+		return err
+	}
+	if err := c.reindex(lastBlock); err != nil {
+		return err
+	}
+	if err := c.loadValidatorSet(); err != nil {
+		return err
+	}
+	finalizedBlock, err := c.loadFinalized()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.LastHash = lastHash
+	c.CurrentBlock = lastBlock.Block
+	c.finalizedHash = finalizedBlock.Hash
+	c.finalizedBlock = finalizedBlock.Block
+	c.votes = make(map[string]map[string]Vote)
+	return nil
+}
+
+// loadFinalized returns the last finalized block, the stored genesis block if none was finalized:
+// chains created before the genesis block was fixed start with a genesis block of their own.
+func (c *Chain) loadFinalized() (*Block, error) {
+	known, err := c.storage.Has([]byte(KeyFinalizedHash))
+	if err != nil {
+		return nil, err
+	}
+	if !known {
+		return c.GetBlockByNumber(0)
+	}
+	hash, err := c.storage.Get([]byte(KeyFinalizedHash))
+	if err != nil {
+		return nil, err
+	}
+	return c.getBlock(hash)
+}
+
+// Close closes the storage of the blockchain, if it was initialized.
+func (c *Chain) Close() error {
+	if c.storage == nil {
+		return nil
+	}
+	return c.storage.Close()
+}
+
+// Check returns an error if the storage of the blockchain isn't initialized or has been closed.
+func (c *Chain) Check() error {
+	if c.storage == nil || c.storage.db == nil {
+		return fmt.Errorf("blockchain storage not initialized")
+	}
+	if c.storage.closed {
+		return fmt.Errorf("blockchain storage closed")
+	}
+	return nil
 }
 
+// genesis returns the genesis block, which is only computed once.
+var genesis = sync.OnceValue(makeGenesisBlock)
+
 // makeGenesisBlock creates and returns the genesis block for the blockchain.
 //
 // This function:
 //  1. Logs the creation of the genesis block.
-//  2. Initializes a new Block.
-//  3. Builds the block with "Genesis" as data, an empty link (as it's the first block),
-//     and a nonce of 1.
+//  2. Initializes a new Block with "Genesis" as data and an empty link (as it's the first block).
+//  3. Searches its nonce from a fixed timestamp, so that the genesis block is the same on every node.
 //
 // Returns:
 //   - *Block: A pointer to the newly created genesis block.
 func makeGenesisBlock() *Block {
 	logrus.Info("[+] Generating genesis block...")
-	newBlock := &Block{}
-	emptyLink := []byte{}
-	newBlock.Build([]byte("Genesis"), emptyLink, big.NewInt(1), 0)
+	stake := big.NewInt(1)
+	newBlock := &Block{Block: 0, Data: []byte("Genesis"), Link: []byte{}}
+	pos := &ProofOfStake{Block: newBlock, Target: GetProofOfStakeTarget(stake), Stake: stake}
+	newBlock.Nonce, newBlock.Hash = pos.runFrom(genesisTimestamp)
 	return newBlock
 }
 
+// loadValidatorSet applies the validator set records stored by ApplyValidatorSet, in order.
+func (c *Chain) loadValidatorSet() error {
+	if c.validators == nil {
+		return nil
+	}
+	records, err := c.ValidatorSetRecords()
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := c.validators.Apply(record); err != nil {
+			// e.g. the governance peer was changed in the configuration
+			logrus.Warnf("[-] Ignoring the stored validator set version %d: %v", record.Version, err)
+		}
+	}
+	return nil
+}
+
+// ValidatorSetRecords returns the validator set records applied to the chain, by version, so
+// that peers syncing the chain can check its blocks against the validators of their time.
+func (c *Chain) ValidatorSetRecords() ([]ValidatorSetRecord, error) {
+	if err := c.Check(); err != nil {
+		return nil, err
+	}
+	var records []ValidatorSetRecord
+	var decodeErr error
+	err := c.storage.IterateFrom([]byte(validatorSetPrefix), []byte(validatorSetPrefix), func(_, value []byte) bool {
+		var record ValidatorSetRecord
+		if decodeErr = json.Unmarshal(value, &record); decodeErr != nil {
+			return false
+		}
+		records = append(records, record)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode a stored validator set: %w", decodeErr)
+	}
+	return records, nil
+}
+
+// ApplyValidatorSet replaces the validators with those of a governance record signed by the
+// governance peer from the height of the record on, and stores the record so that it still
+// applies after a restart. Records that would change the validators of a finalized block are
+// rejected with ErrInvalidValidatorSet.
+func (c *Chain) ApplyValidatorSet(record ValidatorSetRecord) error {
+	if err := c.Check(); err != nil {
+		return err
+	}
+	if c.validators == nil {
+		return fmt.Errorf("%w: no validator set", ErrInvalidValidatorSet)
+	}
+	// A finalized block can't change validators, since its certificate was checked against the old set
+	if finalized, _ := c.Finalized(); record.Height <= finalized {
+		return fmt.Errorf("%w: height %d is at or below the last finalized block %d", ErrInvalidValidatorSet, record.Height, finalized)
+	}
+	if err := c.validators.Apply(record); err != nil {
+		return err
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	current := c.CurrentBlock
+	c.mu.Unlock()
+	if record.Height <= current {
+		logrus.Warnf("[-] Validator set version %d applies from block %d, at or below the last block %d", record.Version, record.Height, current)
+	}
+	logrus.Infof("[+] Validator set version %d applied from block %d: %v", record.Version, record.Height, record.Validators)
+	return c.storage.Set(validatorSetKey(record.Version), data)
+}
+
 // UpdateLastHash updates the LastHash field of the Chain struct with the most recent hash from storage.
 //
 // This function:
@@ -74,50 +267,554 @@ func (c *Chain) UpdateLastHash() error {
 		logrus.Error("[-] Failed to get last hash from the storage: ", err)
 		return err
 	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.LastHash = lastHash
 	return nil
 }
 
-// AddBlock adds a new block to the blockchain with the given data.
-//
-// This function:
-// 1. Updates the last hash of the chain.
-// 2. Creates a new block with the provided data, the last hash, and a nonce of 1.
-// 3. Validates the new block using Proof of Stake (PoS).
-// 4. Saves the new block to storage if valid.
-// 5. Updates the LastHash of the chain to the hash of the new block.
+// AddBlock builds a block with the given data on the last block and adds it to the blockchain.
 //
 // Parameters:
 //   - data: The data to be included in the new block.
+//   - key: The private key of the validator producing the block.
 //
 // Returns:
 //   - error: An error if any step fails, nil otherwise.
-func (c *Chain) AddBlock(data []byte) error {
+func (c *Chain) AddBlock(data []byte, key crypto.PrivKey) error {
 	logrus.Info("[+] Adding block...")
-	if err := c.UpdateLastHash(); err != nil {
+	newBlock, err := c.BuildBlock(data, key)
+	if err != nil {
 		return err
 	}
+	_, err = c.ImportBlock(newBlock)
+	return err
+}
+
+// BuildBlock creates a block with the given data on the last block of the chain, running the
+// proof of stake, and signs it with the key of the producing validator. The block isn't added
+// to the chain, see ImportBlock.
+func (c *Chain) BuildBlock(data []byte, key crypto.PrivKey) (*Block, error) {
+	return c.buildBlock(key, func(block *Block, link []byte, number uint64) {
+		block.Build(data, link, big.NewInt(1), number)
+	})
+}
+
+// BuildRecordBlock creates a block batching the records on the last block of the chain, like
+// BuildBlock. The Merkle root of the records is part of the block hash, so that each record can
+// be proven part of the block, see GetInclusionProof.
+func (c *Chain) BuildRecordBlock(records [][]byte, key crypto.PrivKey) (*Block, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no records to build a block with")
+	}
+	return c.buildBlock(key, func(block *Block, link []byte, number uint64) {
+		block.BuildRecords(records, link, big.NewInt(1), number)
+	})
+}
+
+// buildBlock builds and signs a block on the last block of the chain.
+func (c *Chain) buildBlock(key crypto.PrivKey, build func(block *Block, link []byte, number uint64)) (*Block, error) {
+	if err := c.Check(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	link, number := c.LastHash, c.getNextBlockNumber()
+	c.mu.Unlock()
+
 	newBlock := &Block{}
-	nextBlockNumber := c.getNextBlockNumber()
-	newBlock.Build(data, c.LastHash, big.NewInt(1), nextBlockNumber)
+	build(newBlock, link, number)
+	if err := newBlock.Sign(key); err != nil {
+		return nil, err
+	}
+	return newBlock, nil
+}
+
+func (c *Chain) getNextBlockNumber() uint64 {
+	return c.CurrentBlock + 1
+}
 
-	if !IsValidPoS(newBlock, big.NewInt(1)) {
-		logrus.Error("[-] Invalid PoS block")
-		return fmt.Errorf("invalid PoS block")
+// ImportBlock validates a block and stores it. The block must be signed by a validator of the set
+// in effect at its number, built on a stored block that descends from the last finalized block,
+// with the next number, and have a valid proof of stake. The longest chain is followed: a block becomes the last block if
+// its number is higher than the current last block's, which reorganizes the chain when the block
+// isn't built on the last block. Blocks of shorter forks are stored too, so that a fork can take
+// over once it becomes the longest. Finalized blocks are never reorganized.
+func (c *Chain) ImportBlock(block *Block) (ImportResult, error) {
+	if err := c.Check(); err != nil {
+		return ImportResult{}, err
 	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	err := c.storage.SaveBlock(newBlock.Hash, newBlock)
+	known, err := c.storage.Has(block.Hash)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	if known {
+		return ImportResult{Known: true}, nil
+	}
+	if err := c.checkProducer(block); err != nil {
+		return ImportResult{}, err
+	}
+	parentKnown, err := c.storage.Has(block.Link)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	if len(block.Link) == 0 || !parentKnown {
+		return ImportResult{}, fmt.Errorf("%w %x of block %d", ErrUnknownParent, block.Link, block.Block)
+	}
+	parent, err := c.getBlock(block.Link)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	if err := validateBlock(block, parent); err != nil {
+		return ImportResult{}, err
+	}
+	if err := c.checkFinalized(parent); err != nil {
+		return ImportResult{}, fmt.Errorf("%w: block %d %x", err, block.Block, block.Hash)
+	}
+
+	if block.Block <= c.CurrentBlock {
+		// A fork that isn't longer than the chain, keep it in case it grows
+		if err := c.storage.StoreBlock(block.Hash, block); err != nil {
+			return ImportResult{}, err
+		}
+		certificate, err := c.tryFinalize(block.Hash)
+		return ImportResult{Finalized: certificate != nil}, err
+	}
+
+	result := ImportResult{Head: true}
+	if !bytes.Equal(block.Link, c.LastHash) {
+		if result.Reorged, err = c.forkDepth(parent); err != nil {
+			return ImportResult{}, err
+		}
+		logrus.Warnf("[-] Reorganizing the chain: block %d %x replaces %d blocks", block.Block, block.Hash, result.Reorged)
+	}
+	index, err := c.indexHead(block)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	if err := c.storage.SaveBlock(block.Hash, block, index); err != nil {
+		return ImportResult{}, err
+	}
+	c.LastHash = block.Hash
+	c.CurrentBlock = block.Block
+	certificate, err := c.tryFinalize(block.Hash)
+	result.Finalized = certificate != nil
+	return result, err
+}
+
+// checkFinalized checks that a block descends from the last finalized block. The caller holds the lock.
+func (c *Chain) checkFinalized(block *Block) error {
+	if block.Block < c.finalizedBlock {
+		return ErrConflictsWithFinalized
+	}
+	ancestor, err := c.ancestorAt(block, c.finalizedBlock)
 	if err != nil {
-		logrus.Error("[-] Failed to save block into the storage: ", newBlock, err)
 		return err
 	}
-	c.LastHash = newBlock.Hash
-	c.CurrentBlock = nextBlockNumber
+	if !bytes.Equal(ancestor.Hash, c.finalizedHash) {
+		return ErrConflictsWithFinalized
+	}
 	return nil
 }
 
-func (c *Chain) getNextBlockNumber() uint64 {
-	return c.CurrentBlock + 1
+// ancestorAt returns the ancestor of a block with the given number, or the block itself.
+func (c *Chain) ancestorAt(block *Block, number uint64) (*Block, error) {
+	var err error
+	for block.Block > number {
+		if block, err = c.getBlock(block.Link); err != nil {
+			return nil, err
+		}
+	}
+	return block, nil
+}
+
+// IsFinalized returns whether a stored block is the last finalized block or one of its ancestors.
+func (c *Chain) IsFinalized(block *Block) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if block.Block > c.finalizedBlock {
+		return false, nil
+	}
+	finalized, err := c.getBlock(c.finalizedHash)
+	if err != nil {
+		return false, err
+	}
+	ancestor, err := c.ancestorAt(finalized, block.Block)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(ancestor.Hash, block.Hash), nil
+}
+
+// Head returns the number and hash of the last block.
+func (c *Chain) Head() (uint64, []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.CurrentBlock, c.LastHash
+}
+
+// Finalized returns the number and hash of the last finalized block.
+func (c *Chain) Finalized() (uint64, []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.finalizedBlock, c.finalizedHash
+}
+
+// AddVote counts the vote of a validator for a block, and finalizes the block once it has the
+// votes of 2/3 of the validators, returning its finality certificate. Votes for blocks that aren't
+// stored yet are kept until the block is imported. Votes for blocks at or below the last finalized
+// block are ignored.
+func (c *Chain) AddVote(vote Vote) (*Certificate, error) {
+	if err := c.Check(); err != nil {
+		return nil, err
+	}
+	if err := vote.Verify(); err != nil {
+		return nil, err
+	}
+	if c.validators == nil || !c.validators.ContainsAt(vote.Voter, vote.Block) {
+		return nil, fmt.Errorf("%w: %s is not an active validator", ErrInvalidVote, vote.Voter)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if vote.Block <= c.finalizedBlock {
+		return nil, nil
+	}
+	key := voteKey(vote.Hash)
+	if c.votes[key] == nil {
+		if len(c.votes) >= maxPendingVotes {
+			return nil, fmt.Errorf("%w: too many blocks waiting for finality", ErrInvalidVote)
+		}
+		c.votes[key] = make(map[string]Vote)
+	}
+	c.votes[key][vote.Voter] = vote
+	return c.tryFinalize(vote.Hash)
+}
+
+// VoteForHead creates the vote of the peer of the key for the last block of the chain, and stores
+// it as the last vote of this node. It returns nil if the node may not vote for the block: a
+// validator only votes for a block above the block it last voted for, and descending from it
+// unless the chain was finalized at or above it, or the block it last voted for can no longer be
+// finalized and the new block descends from its parent (see lastVoteReleased). It never votes
+// for two conflicting blocks that could both be finalized, even across restarts.
+func (c *Chain) VoteForHead(key crypto.PrivKey) (*Vote, error) {
+	if err := c.Check(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	head, err := c.getBlock(c.LastHash)
+	if err != nil {
+		return nil, err
+	}
+	last, err := c.lastVote()
+	if err != nil {
+		return nil, err
+	}
+	if last != nil {
+		if head.Block <= last.Block {
+			return nil, nil
+		}
+		if last.Block > c.finalizedBlock {
+			ancestor, err := c.ancestorAt(head, last.Block)
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(ancestor.Hash, last.Hash) && !c.lastVoteReleased(last, head) {
+				logrus.Debugf("[-] Not voting for block %d %x: it doesn't descend from the last vote for block %d %x", head.Block, head.Hash, last.Block, last.Hash)
+				return nil, nil
+			}
+		}
+	}
+	vote, err := NewVote(head, key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(vote)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.storage.Set([]byte(KeyLastVote), data); err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+// lastVoteReleased reports whether this node may stop voting on the fork of its last vote for a
+// head that doesn't descend from it, when a split vote left no block at that height with a
+// quorum. It may if the head descends from the parent of the block of the last vote, and the
+// validators that voted for other blocks at or above its height leave it without a possible
+// quorum: validators vote at most once per height and only above their last vote, so that block
+// can never be finalized. This assumes validators don't sign conflicting votes. The caller holds
+// the lock.
+func (c *Chain) lastVoteReleased(last *Vote, head *Block) bool {
+	if last.Block == 0 {
+		return false
+	}
+	parent, err := c.ancestorAt(head, last.Block-1)
+	if err != nil {
+		return false
+	}
+	block, err := c.getBlock(last.Hash)
+	if err != nil || !bytes.Equal(parent.Hash, block.Link) {
+		return false
+	}
+
+	against := make(map[string]bool)
+	for _, votes := range c.votes {
+		for voter, vote := range votes {
+			if vote.Block >= last.Block && !bytes.Equal(vote.Hash, last.Hash) {
+				against[voter] = true
+			}
+		}
+	}
+	for voter, vote := range c.votes[voteKey(last.Hash)] {
+		if vote.Block == last.Block {
+			delete(against, voter)
+		}
+	}
+	validators := c.validators.ValidatorsAt(last.Block)
+	possible := 0
+	for _, validator := range validators {
+		if !against[validator] {
+			possible++
+		}
+	}
+	return !hasQuorum(possible, len(validators))
+}
+
+// lastVote returns the stored last vote of this node, or nil. The caller holds the lock.
+func (c *Chain) lastVote() (*Vote, error) {
+	known, err := c.storage.Has([]byte(KeyLastVote))
+	if err != nil || !known {
+		return nil, err
+	}
+	data, err := c.storage.Get([]byte(KeyLastVote))
+	if err != nil {
+		return nil, err
+	}
+	var vote Vote
+	if err := json.Unmarshal(data, &vote); err != nil {
+		return nil, fmt.Errorf("failed to decode the last vote: %w", err)
+	}
+	return &vote, nil
+}
+
+// ImportCertificate finalizes a block with the finality certificate of another validator, e.g.
+// received while syncing the chain. The block must be stored; certificates of blocks at or below
+// the last finalized block are ignored.
+func (c *Chain) ImportCertificate(certificate *Certificate) error {
+	if err := c.Check(); err != nil {
+		return err
+	}
+	if c.validators == nil {
+		return fmt.Errorf("%w: no validator set", ErrInvalidCertificate)
+	}
+	if err := certificate.Verify(c.validators); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if certificate.Block <= c.finalizedBlock {
+		return nil
+	}
+	key := voteKey(certificate.Hash)
+	if c.votes[key] == nil {
+		c.votes[key] = make(map[string]Vote)
+	}
+	for _, vote := range certificate.Votes {
+		c.votes[key][vote.Voter] = vote
+	}
+	_, err := c.tryFinalize(certificate.Hash)
+	return err
+}
+
+// tryFinalize finalizes a stored block if it has the votes of 2/3 of the validators. If the block
+// isn't part of the chain, the chain is reorganized to end with it. The caller holds the lock.
+func (c *Chain) tryFinalize(hash []byte) (*Certificate, error) {
+	votes := c.votes[voteKey(hash)]
+	if len(votes) == 0 {
+		return nil, nil
+	}
+	known, err := c.storage.Has(hash)
+	if err != nil || !known {
+		return nil, err
+	}
+	block, err := c.getBlock(hash)
+	if err != nil {
+		return nil, err
+	}
+	if block.Block <= c.finalizedBlock {
+		return nil, nil
+	}
+	validators := c.validators.ValidatorsAt(block.Block)
+	certificate := &Certificate{Hash: hash, Block: block.Block}
+	for _, voter := range validators {
+		if vote, ok := votes[voter]; ok && vote.Block == block.Block {
+			certificate.Votes = append(certificate.Votes, vote)
+		}
+	}
+	if !hasQuorum(len(certificate.Votes), len(validators)) {
+		return nil, nil
+	}
+	if err := c.checkFinalized(block); err != nil {
+		return nil, fmt.Errorf("%w: a quorum voted for block %d %x", err, block.Block, block.Hash)
+	}
+
+	head, err := c.getBlock(c.LastHash)
+	if err != nil {
+		return nil, err
+	}
+	onChain := false
+	if head.Block >= block.Block {
+		ancestor, err := c.ancestorAt(head, block.Block)
+		if err != nil {
+			return nil, err
+		}
+		onChain = bytes.Equal(ancestor.Hash, block.Hash)
+	}
+	var index Index
+	if !onChain {
+		if index, err = c.indexHead(block); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.storeCertificate(certificate, index, !onChain); err != nil {
+		return nil, err
+	}
+	if !onChain {
+		logrus.Warnf("[-] Reorganizing the chain: finalized block %d %x isn't part of it", block.Block, block.Hash)
+		c.LastHash = block.Hash
+		c.CurrentBlock = block.Block
+	}
+	c.finalizedHash = block.Hash
+	c.finalizedBlock = block.Block
+	for key, votes := range c.votes {
+		for _, vote := range votes {
+			if vote.Block <= block.Block {
+				delete(c.votes, key)
+			}
+			break
+		}
+	}
+	logrus.Infof("[+] Block %d %x finalized with %d votes", block.Block, block.Hash, len(certificate.Votes))
+	return certificate, nil
+}
+
+// storeCertificate stores the finality certificate of a block along with it. If head is set, the
+// block becomes the last block and the index is updated with it.
+func (c *Chain) storeCertificate(certificate *Certificate, index Index, head bool) error {
+	data, err := json.Marshal(certificate)
+	if err != nil {
+		return err
+	}
+	return c.storage.Finalize(certificate.Hash, certificateKey(certificate.Hash), data, head, index)
+}
+
+// GetCertificate returns the finality certificate of a block, or nil if it wasn't finalized by
+// votes. Blocks below the last finalized block are final without a certificate of their own.
+func (c *Chain) GetCertificate(hash []byte) (*Certificate, error) {
+	if err := c.Check(); err != nil {
+		return nil, err
+	}
+	key := certificateKey(hash)
+	known, err := c.storage.Has(key)
+	if err != nil || !known {
+		return nil, err
+	}
+	data, err := c.storage.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var certificate Certificate
+	if err := json.Unmarshal(data, &certificate); err != nil {
+		return nil, fmt.Errorf("failed to decode the finality certificate of %x: %w", hash, err)
+	}
+	return &certificate, nil
+}
+
+// checkProducer checks that a block is signed by a validator of the set in effect at its number.
+// Blocks built before blocks were signed are only valid in the chain they were stored in, they
+// are never imported.
+func (c *Chain) checkProducer(block *Block) error {
+	if block.Version == LegacyHashVersion && len(block.Signature) == 0 {
+		return fmt.Errorf("%w: unsigned legacy block %d %x", ErrUnauthorizedProducer, block.Block, block.Hash)
+	}
+	if err := block.Verify(); err != nil {
+		return err
+	}
+	if c.validators == nil || !c.validators.ContainsAt(block.Producer, block.Block) {
+		return fmt.Errorf("%w: block %d %x produced by %s", ErrUnauthorizedProducer, block.Block, block.Hash, block.Producer)
+	}
+	return nil
+}
+
+// CheckBlock checks that a block is signed by a validator of the set in effect at its number and
+// that its hash matches its content. Unlike ImportBlock, it doesn't need the parent of the block,
+// e.g. to check the last block a peer claims before syncing from it.
+func (c *Chain) CheckBlock(block *Block) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.checkProducer(block); err != nil {
+		return err
+	}
+	if !bytes.Equal(MerkleRoot(block.Records), block.Root) {
+		return fmt.Errorf("%w: Merkle root of the records of block %d", ErrInvalidBlock, block.Block)
+	}
+	if !bytes.Equal(BlockHash(block, big.NewInt(1)), block.Hash) {
+		return fmt.Errorf("%w: hash %x doesn't match the block %d", ErrInvalidBlock, block.Hash, block.Block)
+	}
+	return nil
+}
+
+// validateBlock checks that a block is correctly built on its parent.
+func validateBlock(block, parent *Block) error {
+	if block.Block != parent.Block+1 {
+		return fmt.Errorf("%w: block %d follows block %d", ErrInvalidBlock, block.Block, parent.Block)
+	}
+	if block.Version > HashVersion {
+		return fmt.Errorf("%w: unknown hash version %d of block %d", ErrInvalidBlock, block.Version, block.Block)
+	}
+	if !bytes.Equal(MerkleRoot(block.Records), block.Root) {
+		return fmt.Errorf("%w: Merkle root of the records of block %d", ErrInvalidBlock, block.Block)
+	}
+	if !bytes.Equal(BlockHash(block, big.NewInt(1)), block.Hash) {
+		return fmt.Errorf("%w: hash %x doesn't match the block %d", ErrInvalidBlock, block.Hash, block.Block)
+	}
+	if !IsValidPoS(block, big.NewInt(1)) {
+		return fmt.Errorf("%w: proof of stake of block %d", ErrInvalidBlock, block.Block)
+	}
+	return nil
+}
+
+// forkDepth returns the number of blocks of the current chain after its common ancestor with the
+// fork ending with the given block. The caller holds the lock.
+func (c *Chain) forkDepth(fork *Block) (int, error) {
+	current, err := c.getBlock(c.LastHash)
+	if err != nil {
+		return 0, err
+	}
+	depth := 0
+	for current.Block > fork.Block {
+		if current, err = c.getBlock(current.Link); err != nil {
+			return 0, err
+		}
+		depth++
+	}
+	for !bytes.Equal(current.Hash, fork.Hash) {
+		if current, err = c.getBlock(current.Link); err != nil {
+			return 0, err
+		}
+		if fork, err = c.getBlock(fork.Link); err != nil {
+			return 0, err
+		}
+		depth++
+	}
+	return depth, nil
 }
 
 // IterateLink iterates through the blockchain, executing provided functions at specific points.
@@ -141,7 +838,9 @@ func (c *Chain) IterateLink(each func(b *Block), pre, post func()) error {
 		return err
 	}
 
+	c.mu.Lock()
 	currentHash := c.LastHash
+	c.mu.Unlock()
 	pre()
 	for len(currentHash) > 0 {
 		data, err := c.storage.Get(currentHash)
@@ -173,11 +872,19 @@ func (c *Chain) GetLastBlock() (*Block, error) {
 		return nil, err
 	}
 
-	return c.GetBlock(c.LastHash)
+	c.mu.Lock()
+	lastHash := c.LastHash
+	c.mu.Unlock()
+	return c.GetBlock(lastHash)
 }
 
 func (c *Chain) GetBlock(hash []byte) (*Block, error) {
 	logrus.Infof("[+] transaction %x", hash)
+	return c.getBlock(hash)
+}
+
+// getBlock reads a stored block.
+func (c *Chain) getBlock(hash []byte) (*Block, error) {
 	data, err := c.storage.Get(hash)
 	if err != nil {
 		return nil, err
@@ -189,6 +896,46 @@ func (c *Chain) GetBlock(hash []byte) (*Block, error) {
 	return block, nil
 }
 
+// HasBlock returns whether a block is stored, whether it is part of the chain or of a fork.
+func (c *Chain) HasBlock(hash []byte) (bool, error) {
+	if err := c.Check(); err != nil {
+		return false, err
+	}
+	return c.storage.Has(hash)
+}
+
+// GetBlocksByHash returns up to count blocks, in order, ending with the block with the given
+// hash. It may be a block of a fork.
+func (c *Chain) GetBlocksByHash(hash []byte, count int) ([]*Block, error) {
+	if err := c.Check(); err != nil {
+		return nil, err
+	}
+	if count <= 0 {
+		return nil, nil
+	}
+	last, err := c.getBlock(hash)
+	if err != nil {
+		return nil, err
+	}
+	return c.getAncestors(last, count)
+}
+
+// getAncestors returns up to count blocks ending with the given block, in order.
+func (c *Chain) getAncestors(last *Block, count int) ([]*Block, error) {
+	blocks := []*Block{last}
+	for len(blocks) < count && len(last.Link) > 0 {
+		var err error
+		if last, err = c.getBlock(last.Link); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, last)
+	}
+	for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+		blocks[i], blocks[j] = blocks[j], blocks[i]
+	}
+	return blocks, nil
+}
+
 // GetBlockByHash retrieves a specific block from the blockchain using its hash.
 //
 // Parameters:
@@ -0,0 +1,616 @@
+package chain_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/dgraph-io/badger"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/masa-finance/masa-oracle/pkg/chain"
+)
+
+func newKey() (crypto.PrivKey, string) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	id, err := peer.IDFromPrivateKey(key)
+	Expect(err).NotTo(HaveOccurred())
+	return key, id.String()
+}
+
+// legacyBlock builds an unsigned block the way nodes did before blocks were signed, with the
+// legacy hash and a nonce searched from the current time. Without a parent, it builds a genesis block.
+func legacyBlock(data []byte, parent *Block) *Block {
+	block := &Block{Data: data, Link: []byte{}}
+	if parent != nil {
+		block.Block, block.Link = parent.Block+1, parent.Hash
+	}
+	stake := big.NewInt(1)
+	pos := &ProofOfStake{Block: block, Target: GetProofOfStakeTarget(stake), Stake: stake}
+	block.Nonce, block.Hash = pos.Run()
+	return block
+}
+
+// writeStore stores the blocks in the chain under dir with the given encoding, the last one being
+// the last block of the chain.
+func writeStore(dir string, encode func(*Block) ([]byte, error), blocks ...*Block) {
+	options := badger.DefaultOptions(filepath.Join(dir, "blocks"))
+	options.Logger = nil
+	db, err := badger.Open(options)
+	Expect(err).NotTo(HaveOccurred())
+	defer db.Close()
+	Expect(db.Update(func(txn *badger.Txn) error {
+		for _, block := range blocks {
+			data, err := encode(block)
+			if err != nil {
+				return err
+			}
+			if err := txn.Set(block.Hash, data); err != nil {
+				return err
+			}
+		}
+		return txn.Set([]byte(KeyLastHash), blocks[len(blocks)-1].Hash)
+	})).To(Succeed())
+}
+
+var _ = Describe("Chain", func() {
+	var key, governanceKey crypto.PrivKey
+	var validator, governance string
+	var validators []string
+
+	BeforeEach(func() {
+		key, validator = newKey()
+		governanceKey, governance = newKey()
+		validators = []string{validator}
+	})
+
+	openChain := func(dir string) *Chain {
+		validators, err := NewValidatorSet(validators, governance)
+		Expect(err).NotTo(HaveOccurred())
+		c := &Chain{}
+		c.SetValidators(validators)
+		Expect(c.Init(dir)).To(Succeed())
+		return c
+	}
+
+	newChain := func() *Chain {
+		dir, err := os.MkdirTemp("", "chain")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		c := openChain(dir)
+		DeferCleanup(c.Close)
+		return c
+	}
+
+	It("starts every chain from the same genesis block", func() {
+		a, b := newChain(), newChain()
+		Expect(a.LastHash).To(Equal(b.LastHash))
+		Expect(a.CurrentBlock).To(BeZero())
+	})
+
+	It("keeps the genesis block of a chain created before it was fixed", func() {
+		dir, err := os.MkdirTemp("", "chain")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		genesis := legacyBlock([]byte("Genesis"), nil)
+		first := legacyBlock([]byte("first"), genesis)
+		writeStore(dir, (*Block).Serialize, genesis, first)
+
+		c := openChain(dir)
+		DeferCleanup(c.Close)
+		number, hash := c.Finalized()
+		Expect(number).To(BeZero())
+		Expect(hash).To(Equal(genesis.Hash))
+		Expect(c.AddBlock([]byte("second"), key)).To(Succeed())
+		Expect(c.CurrentBlock).To(BeEquivalentTo(2))
+	})
+
+	It("imports blocks built by another validator", func() {
+		producer, validator := newChain(), newChain()
+		Expect(producer.AddBlock([]byte("first"), key)).To(Succeed())
+		block, err := producer.GetLastBlock()
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := validator.ImportBlock(block)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Head).To(BeTrue())
+		Expect(validator.LastHash).To(Equal(block.Hash))
+		Expect(validator.CurrentBlock).To(BeEquivalentTo(1))
+
+		result, err = validator.ImportBlock(block)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Known).To(BeTrue())
+	})
+
+	It("rejects invalid blocks and blocks with an unknown parent", func() {
+		producer, validator := newChain(), newChain()
+		Expect(producer.AddBlock([]byte("first"), key)).To(Succeed())
+		first, err := producer.GetLastBlock()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(producer.AddBlock([]byte("second"), key)).To(Succeed())
+		second, err := producer.GetLastBlock()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = validator.ImportBlock(second)
+		Expect(err).To(MatchError(ErrUnknownParent))
+
+		tampered := *first
+		tampered.Data = []byte("tampered")
+		_, err = validator.ImportBlock(&tampered)
+		Expect(err).To(MatchError(ErrInvalidBlock))
+
+		renumbered := *first
+		renumbered.Block = 5
+		Expect(renumbered.Sign(key)).To(Succeed())
+		_, err = validator.ImportBlock(&renumbered)
+		Expect(err).To(MatchError(ErrInvalidBlock))
+		Expect(validator.CurrentBlock).To(BeZero())
+	})
+
+	It("follows the longest chain", func() {
+		a, b := newChain(), newChain()
+		Expect(a.AddBlock([]byte("a1"), key)).To(Succeed())
+		a1, err := a.GetLastBlock()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b.AddBlock([]byte("b1"), key)).To(Succeed())
+		Expect(b.AddBlock([]byte("b2"), key)).To(Succeed())
+		b2, err := b.GetLastBlock()
+		Expect(err).NotTo(HaveOccurred())
+		b1, err := b.GetBlock(b2.Link)
+		Expect(err).NotTo(HaveOccurred())
+
+		// A fork as long as the chain is kept aside
+		result, err := a.ImportBlock(b1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Head).To(BeFalse())
+		Expect(a.LastHash).To(Equal(a1.Hash))
+
+		// It replaces the chain once it is longer
+		result, err = a.ImportBlock(b2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Head).To(BeTrue())
+		Expect(result.Reorged).To(Equal(1))
+		Expect(a.LastHash).To(Equal(b2.Hash))
+		Expect(GetBlockchain(a)).To(HaveLen(3))
+	})
+
+	It("indexes the blocks of the chain by number and by data CID", func() {
+		a, b := newChain(), newChain()
+		for _, data := range []string{"a1", "a2", "a3"} {
+			Expect(a.AddBlock([]byte(data), key)).To(Succeed())
+		}
+		for _, data := range []string{"b1", "b2", "b3", "b4"} {
+			Expect(b.AddBlock([]byte(data), key)).To(Succeed())
+		}
+		fork, err := b.GetBlockRange(1, 4)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fork).To(HaveLen(4))
+		_, err = a.ImportBlock(fork[0])
+		Expect(err).NotTo(HaveOccurred())
+
+		blocks, err := a.GetBlockRange(1, 5)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(blocks).To(HaveLen(3))
+		Expect(blocks[0].Block).To(BeEquivalentTo(1))
+		Expect(blocks[0].Data).To(Equal([]byte("a1")))
+		Expect(blocks[2].Hash).To(Equal(a.LastHash))
+		blocks, err = a.GetBlockRange(4, 5)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(blocks).To(BeEmpty())
+
+		a2, err := a.GetBlockByNumber(2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(a2.Data).To(Equal([]byte("a2")))
+		id, err := DataCID([]byte("a2"))
+		Expect(err).NotTo(HaveOccurred())
+		block, err := a.GetBlockByDataCID(id)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(block.Hash).To(Equal(a2.Hash))
+
+		// Blocks of a fork are only found by hash
+		id, err = DataCID([]byte("b1"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = a.GetBlockByDataCID(id)
+		Expect(err).To(MatchError(ErrBlockNotFound))
+		blocks, err = a.GetBlocksByHash(fork[0].Hash, 5)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(blocks).To(HaveLen(2))
+		Expect(blocks[0].Block).To(BeZero())
+		Expect(blocks[1].Hash).To(Equal(fork[0].Hash))
+		known, err := a.HasBlock(fork[0].Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(known).To(BeTrue())
+
+		// The index follows the longest chain
+		for _, block := range fork[1:] {
+			_, err = a.ImportBlock(block)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		blocks, err = a.GetBlockRange(0, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(blocks).To(HaveLen(5))
+		Expect(blocks[1:]).To(Equal(fork))
+		id, err = DataCID([]byte("b1"))
+		Expect(err).NotTo(HaveOccurred())
+		block, err = a.GetBlockByDataCID(id)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(block.Hash).To(Equal(fork[0].Hash))
+		id, err = DataCID([]byte("a2"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = a.GetBlockByDataCID(id)
+		Expect(err).To(MatchError(ErrBlockNotFound))
+	})
+
+	It("batches records into blocks and proves their inclusion", func() {
+		producer, validator := newChain(), newChain()
+		records := [][]byte{[]byte(`{"work":"a"}`), []byte(`{"work":"b"}`), []byte(`{"work":"c"}`)}
+		block, err := producer.BuildRecordBlock(records, key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(block.Root).To(Equal(MerkleRoot(records)))
+
+		tampered := *block
+		tampered.Records = records[:2]
+		_, err = validator.ImportBlock(&tampered)
+		Expect(err).To(MatchError(ErrInvalidBlock))
+		_, err = validator.ImportBlock(block)
+		Expect(err).NotTo(HaveOccurred())
+
+		id, err := DataCID(records[1])
+		Expect(err).NotTo(HaveOccurred())
+		proof, err := validator.GetRecordProof(id)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proof.Index).To(Equal(1))
+		Expect(VerifyInclusionProof(proof, block.Hash)).To(Succeed())
+
+		proof, err = validator.GetInclusionProof(block.Hash, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proof.Record).To(Equal(records[2]))
+		Expect(VerifyInclusionProof(proof, block.Hash)).To(Succeed())
+
+		id, err = DataCID([]byte(`{"work":"d"}`))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = validator.GetRecordProof(id)
+		Expect(err).To(MatchError(ErrBlockNotFound))
+	})
+
+	It("rejects blocks not signed by an active validator", func() {
+		c := newChain()
+		outsider, _ := newKey()
+		block, err := c.BuildBlock([]byte("first"), outsider)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = c.ImportBlock(block)
+		Expect(err).To(MatchError(ErrUnauthorizedProducer))
+
+		block, err = c.BuildBlock([]byte("first"), key)
+		Expect(err).NotTo(HaveOccurred())
+		forged := *block
+		forged.Producer = governance
+		_, err = c.ImportBlock(&forged)
+		Expect(err).To(MatchError(ErrInvalidBlockSignature))
+
+		unsigned := *block
+		unsigned.Producer, unsigned.Signature = "", nil
+		_, err = c.ImportBlock(&unsigned)
+		Expect(err).To(MatchError(ErrInvalidBlockSignature))
+
+		legacy := legacyBlock([]byte("first"), &Block{Hash: c.LastHash})
+		_, err = c.ImportBlock(legacy)
+		Expect(err).To(MatchError(ErrUnauthorizedProducer), "unsigned legacy blocks are never imported")
+		Expect(c.CurrentBlock).To(BeZero())
+	})
+
+	It("checks blocks without their parent", func() {
+		producer, c := newChain(), newChain()
+		Expect(producer.AddBlock([]byte("first"), key)).To(Succeed())
+		block, err := producer.BuildBlock([]byte("second"), key)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.CheckBlock(block)).To(Succeed())
+
+		tampered := *block
+		tampered.Data = []byte("tampered")
+		Expect(tampered.Sign(key)).To(Succeed())
+		Expect(c.CheckBlock(&tampered)).To(MatchError(ErrInvalidBlock))
+
+		outsider, _ := newKey()
+		block, err = producer.BuildBlock([]byte("second"), outsider)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.CheckBlock(block)).To(MatchError(ErrUnauthorizedProducer))
+	})
+
+	It("changes the validators with signed governance records", func() {
+		dir, err := os.MkdirTemp("", "chain")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		c := openChain(dir)
+		newValidatorKey, newValidator := newKey()
+
+		record := ValidatorSetRecord{Version: 1, Height: 1, Validators: []string{newValidator}}
+		Expect(record.Sign(key)).To(Succeed())
+		Expect(c.ApplyValidatorSet(record)).To(MatchError(ErrInvalidValidatorSet))
+
+		Expect(record.Sign(governanceKey)).To(Succeed())
+		tampered := record
+		tampered.Validators = []string{validator, newValidator}
+		Expect(c.ApplyValidatorSet(tampered)).To(MatchError(ErrInvalidValidatorSet))
+		Expect(c.ApplyValidatorSet(record)).To(Succeed())
+		Expect(c.ApplyValidatorSet(record)).To(MatchError(ErrInvalidValidatorSet), "replayed record")
+
+		Expect(c.AddBlock([]byte("first"), key)).To(MatchError(ErrUnauthorizedProducer))
+		Expect(c.AddBlock([]byte("first"), newValidatorKey)).To(Succeed())
+
+		// The record still applies after a restart
+		Expect(c.Close()).To(Succeed())
+		c = openChain(dir)
+		DeferCleanup(c.Close)
+		Expect(c.Validators().Version()).To(BeEquivalentTo(1))
+		Expect(c.Validators().Validators()).To(Equal([]string{newValidator}))
+		records, err := c.ValidatorSetRecords()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(Equal([]ValidatorSetRecord{record}))
+
+		older := ValidatorSetRecord{Version: 2, Height: 0, Validators: []string{validator}}
+		Expect(older.Sign(governanceKey)).To(Succeed())
+		Expect(c.ApplyValidatorSet(older)).To(MatchError(ErrInvalidValidatorSet), "below the height of the current set")
+	})
+
+	It("checks blocks and certificates against the validators of their number", func() {
+		producer := newChain()
+		Expect(producer.AddBlock([]byte("first"), key)).To(Succeed())
+		first, err := producer.GetLastBlock()
+		Expect(err).NotTo(HaveOccurred())
+		v, err := NewVote(first, key)
+		Expect(err).NotTo(HaveOccurred())
+		certificate, err := producer.AddVote(*v)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(certificate).NotTo(BeNil())
+
+		// The validator is replaced from block 2 on
+		newValidatorKey, newValidator := newKey()
+		record := ValidatorSetRecord{Version: 1, Height: 2, Validators: []string{newValidator}}
+		Expect(record.Sign(governanceKey)).To(Succeed())
+		Expect(producer.ApplyValidatorSet(record)).To(Succeed())
+		Expect(producer.AddBlock([]byte("second"), key)).To(MatchError(ErrUnauthorizedProducer))
+		Expect(producer.AddBlock([]byte("second"), newValidatorKey)).To(Succeed())
+		second, err := producer.GetLastBlock()
+		Expect(err).NotTo(HaveOccurred())
+
+		// The validators of a finalized block can't be changed
+		rewrite := ValidatorSetRecord{Version: 2, Height: 1, Validators: []string{validator}}
+		Expect(rewrite.Sign(governanceKey)).To(Succeed())
+		Expect(producer.ApplyValidatorSet(rewrite)).To(MatchError(ErrInvalidValidatorSet))
+		Expect(producer.Validators().Version()).To(BeEquivalentTo(1))
+
+		// A node that applied the record later still imports the history
+		syncing := newChain()
+		Expect(syncing.ApplyValidatorSet(record)).To(Succeed())
+		for _, block := range []*Block{first, second} {
+			_, err := syncing.ImportBlock(block)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(syncing.ImportCertificate(certificate)).To(Succeed())
+		number, _ := syncing.Finalized()
+		Expect(number).To(BeEquivalentTo(1))
+		Expect(syncing.Validators().ContainsAt(validator, 1)).To(BeTrue())
+		Expect(syncing.Validators().ContainsAt(validator, 2)).To(BeFalse())
+	})
+
+	Context("finality", func() {
+		var keys []crypto.PrivKey
+
+		BeforeEach(func() {
+			keys = []crypto.PrivKey{key}
+			for i := 0; i < 2; i++ {
+				k, id := newKey()
+				keys = append(keys, k)
+				validators = append(validators, id)
+			}
+		})
+
+		vote := func(c *Chain, block *Block, key crypto.PrivKey) *Certificate {
+			v, err := NewVote(block, key)
+			Expect(err).NotTo(HaveOccurred())
+			certificate, err := c.AddVote(*v)
+			Expect(err).NotTo(HaveOccurred())
+			return certificate
+		}
+
+		It("finalizes a block with the votes of 2/3 of the validators", func() {
+			dir, err := os.MkdirTemp("", "chain")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+			c := openChain(dir)
+			Expect(c.AddBlock([]byte("first"), key)).To(Succeed())
+			block, err := c.GetLastBlock()
+			Expect(err).NotTo(HaveOccurred())
+
+			outsider, _ := newKey()
+			v, err := NewVote(block, outsider)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = c.AddVote(*v)
+			Expect(err).To(MatchError(ErrInvalidVote))
+
+			Expect(vote(c, block, keys[0])).To(BeNil())
+			Expect(vote(c, block, keys[0])).To(BeNil(), "a validator only counts once")
+			certificate := vote(c, block, keys[1])
+			Expect(certificate).NotTo(BeNil())
+			Expect(certificate.Votes).To(HaveLen(2))
+			Expect(certificate.Verify(c.Validators())).To(Succeed())
+			number, hash := c.Finalized()
+			Expect(number).To(BeEquivalentTo(1))
+			Expect(hash).To(Equal(block.Hash))
+
+			// The certificate is stored next to the block
+			Expect(c.Close()).To(Succeed())
+			c = openChain(dir)
+			DeferCleanup(c.Close)
+			number, _ = c.Finalized()
+			Expect(number).To(BeEquivalentTo(1))
+			stored, err := c.GetCertificate(block.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(Equal(certificate))
+			final, err := c.IsFinalized(block)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(final).To(BeTrue())
+
+			stored.Votes = stored.Votes[:1]
+			Expect(stored.Verify(c.Validators())).To(MatchError(ErrInvalidCertificate))
+		})
+
+		It("only votes for descendants of its last vote, across restarts", func() {
+			dir, err := os.MkdirTemp("", "chain")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+			a, b := openChain(dir), newChain()
+			Expect(a.AddBlock([]byte("a1"), key)).To(Succeed())
+			v, err := a.VoteForHead(key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).NotTo(BeNil())
+			Expect(v.Block).To(BeEquivalentTo(1))
+			Expect(a.VoteForHead(key)).To(BeNil(), "a single vote per height")
+
+			// A longer fork replaces the block voted for
+			Expect(b.AddBlock([]byte("b1"), key)).To(Succeed())
+			Expect(b.AddBlock([]byte("b2"), key)).To(Succeed())
+			b2, err := b.GetLastBlock()
+			Expect(err).NotTo(HaveOccurred())
+			b1, err := b.GetBlock(b2.Link)
+			Expect(err).NotTo(HaveOccurred())
+			for _, block := range []*Block{b1, b2} {
+				_, err := a.ImportBlock(block)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(a.LastHash).To(Equal(b2.Hash))
+			Expect(a.VoteForHead(key)).To(BeNil())
+
+			Expect(a.Close()).To(Succeed())
+			a = openChain(dir)
+			DeferCleanup(a.Close)
+			Expect(a.VoteForHead(key)).To(BeNil())
+
+			// Until the validators finalize the fork
+			vote(a, b1, keys[1])
+			Expect(vote(a, b1, keys[2])).NotTo(BeNil())
+			v, err = a.VoteForHead(key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).NotTo(BeNil())
+			Expect(v.Hash).To(Equal(b2.Hash))
+		})
+
+		It("votes on another fork once a split vote leaves its last vote without a quorum", func() {
+			k, id := newKey()
+			keys = append(keys, k)
+			validators = append(validators, id)
+			c, a, b := newChain(), newChain(), newChain()
+			Expect(a.AddBlock([]byte("a1"), key)).To(Succeed())
+			Expect(a.AddBlock([]byte("a2"), key)).To(Succeed())
+			a2, err := a.GetLastBlock()
+			Expect(err).NotTo(HaveOccurred())
+			a1, err := a.GetBlock(a2.Link)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.AddBlock([]byte("b1"), key)).To(Succeed())
+			b1, err := b.GetLastBlock()
+			Expect(err).NotTo(HaveOccurred())
+
+			// The validators split 2/2 between a1 and b1
+			_, err = c.ImportBlock(b1)
+			Expect(err).NotTo(HaveOccurred())
+			v, err := c.VoteForHead(keys[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v.Hash).To(Equal(b1.Hash))
+			_, err = c.AddVote(*v)
+			Expect(err).NotTo(HaveOccurred())
+			vote(c, b1, keys[1])
+
+			for _, block := range []*Block{a1, a2} {
+				_, err := c.ImportBlock(block)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(c.LastHash).To(Equal(a2.Hash))
+			vote(c, a1, keys[2])
+			Expect(c.VoteForHead(keys[0])).To(BeNil(), "b1 can still get a quorum")
+			vote(c, a1, keys[3])
+			number, _ := c.Finalized()
+			Expect(number).To(BeZero())
+
+			// b1 can't get a quorum any more, so the validators move on to a2
+			v, err = c.VoteForHead(keys[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).NotTo(BeNil())
+			Expect(v.Hash).To(Equal(a2.Hash))
+			Expect(c.AddVote(*v)).To(BeNil())
+			vote(c, a2, keys[2])
+			Expect(vote(c, a2, keys[3])).NotTo(BeNil())
+			number, hash := c.Finalized()
+			Expect(number).To(BeEquivalentTo(2))
+			Expect(hash).To(Equal(a2.Hash))
+		})
+
+		It("finalizes a block with a certificate from another node", func() {
+			a, b := newChain(), newChain()
+			Expect(a.AddBlock([]byte("a1"), key)).To(Succeed())
+			a1, err := a.GetLastBlock()
+			Expect(err).NotTo(HaveOccurred())
+			vote(a, a1, keys[0])
+			certificate := vote(a, a1, keys[1])
+			Expect(certificate).NotTo(BeNil())
+
+			_, err = b.ImportBlock(a1)
+			Expect(err).NotTo(HaveOccurred())
+			forged := *certificate
+			forged.Votes = forged.Votes[:1]
+			Expect(b.ImportCertificate(&forged)).To(MatchError(ErrInvalidCertificate))
+			Expect(b.ImportCertificate(certificate)).To(Succeed())
+			number, hash := b.Finalized()
+			Expect(number).To(BeEquivalentTo(1))
+			Expect(hash).To(Equal(a1.Hash))
+		})
+
+		It("never reorganizes finalized blocks", func() {
+			a, b := newChain(), newChain()
+			Expect(a.AddBlock([]byte("a1"), key)).To(Succeed())
+			a1, err := a.GetLastBlock()
+			Expect(err).NotTo(HaveOccurred())
+			vote(a, a1, keys[0])
+			Expect(vote(a, a1, keys[1])).NotTo(BeNil())
+
+			Expect(b.AddBlock([]byte("b1"), key)).To(Succeed())
+			Expect(b.AddBlock([]byte("b2"), key)).To(Succeed())
+			b2, err := b.GetLastBlock()
+			Expect(err).NotTo(HaveOccurred())
+			b1, err := b.GetBlock(b2.Link)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = a.ImportBlock(b1)
+			Expect(err).To(MatchError(ErrConflictsWithFinalized))
+			Expect(a.LastHash).To(Equal(a1.Hash))
+		})
+
+		It("switches to a fork finalized by the validators", func() {
+			a, b := newChain(), newChain()
+			Expect(a.AddBlock([]byte("a1"), key)).To(Succeed())
+			Expect(a.AddBlock([]byte("a2"), key)).To(Succeed())
+			Expect(b.AddBlock([]byte("b1"), key)).To(Succeed())
+			b1, err := b.GetLastBlock()
+			Expect(err).NotTo(HaveOccurred())
+
+			// Votes received before the block are kept
+			vote(a, b1, keys[1])
+			vote(a, b1, keys[2])
+			result, err := a.ImportBlock(b1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Finalized).To(BeTrue())
+			Expect(a.LastHash).To(Equal(b1.Hash))
+			Expect(a.CurrentBlock).To(BeEquivalentTo(1))
+			block, err := a.GetBlockByNumber(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(block.Hash).To(Equal(b1.Hash))
+			_, err = a.GetBlockByNumber(2)
+			Expect(err).To(MatchError(ErrBlockNotFound))
+		})
+	})
+})
@@ -0,0 +1,138 @@
+package chain
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	// EncodingVersion is the version of the canonical encoding of the stored blocks.
+	EncodingVersion = 1
+
+	// LegacyHashVersion blocks are hashed from the concatenation of their link, data, Merkle root,
+	// nonce, difficulty and stake. The genesis block and the blocks built before the canonical
+	// encoding keep this hash.
+	LegacyHashVersion = 0
+	// HashVersion blocks are hashed from the canonical encoding of their header followed by the
+	// big endian nonce. It is the version of the blocks built by this node.
+	HashVersion = 1
+)
+
+var (
+	// ErrLegacyEncoding is returned for a block stored in the gob encoding, see Migrate.
+	ErrLegacyEncoding = errors.New("block stored in the legacy encoding")
+	// ErrUnknownEncoding is returned for a block stored in an unknown encoding version.
+	ErrUnknownEncoding = errors.New("unknown block encoding")
+)
+
+// encMode is the core deterministic encoding of RFC 8949: map keys are sorted and integers use
+// their shortest form, so that a value always has the same encoding. Nil and empty byte slices
+// are encoded alike.
+var encMode = func() cbor.EncMode {
+	options := cbor.CoreDetEncOptions()
+	options.NilContainers = cbor.NilContainerAsEmpty
+	mode, err := options.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return mode
+}()
+
+// storedBlock is the canonical encoding of a stored block.
+type storedBlock struct {
+	Encoding  uint64   `cbor:"0,keyasint"`
+	Version   uint64   `cbor:"1,keyasint"`
+	Block     uint64   `cbor:"2,keyasint"`
+	Link      []byte   `cbor:"3,keyasint"`
+	Data      []byte   `cbor:"4,keyasint"`
+	Root      []byte   `cbor:"5,keyasint,omitempty"`
+	Nonce     int64    `cbor:"6,keyasint"`
+	Hash      []byte   `cbor:"7,keyasint"`
+	Producer  string   `cbor:"8,keyasint,omitempty"`
+	Signature []byte   `cbor:"9,keyasint,omitempty"`
+	Records   [][]byte `cbor:"10,keyasint,omitempty"`
+}
+
+// hashedHeader is the canonical encoding of the fields a HashVersion block hash is computed from,
+// besides the nonce.
+type hashedHeader struct {
+	Version    uint64 `cbor:"0,keyasint"`
+	Block      uint64 `cbor:"1,keyasint"`
+	Link       []byte `cbor:"2,keyasint"`
+	Data       []byte `cbor:"3,keyasint"`
+	Root       []byte `cbor:"4,keyasint"`
+	Difficulty int64  `cbor:"5,keyasint"`
+	Stake      []byte `cbor:"6,keyasint"`
+}
+
+// encodeBlock returns the canonical encoding of a block.
+func encodeBlock(b *Block) ([]byte, error) {
+	return encMode.Marshal(storedBlock{
+		Encoding:  EncodingVersion,
+		Version:   b.Version,
+		Block:     b.Block,
+		Link:      b.Link,
+		Data:      b.Data,
+		Root:      b.Root,
+		Nonce:     b.Nonce,
+		Hash:      b.Hash,
+		Producer:  b.Producer,
+		Signature: b.Signature,
+		Records:   b.Records,
+	})
+}
+
+// decodeBlock decodes a block stored in the canonical encoding.
+func decodeBlock(data []byte, b *Block) error {
+	// The canonical encoding is a CBOR map, gob never starts with the map major type
+	if len(data) == 0 || data[0]>>5 != 5 {
+		return ErrLegacyEncoding
+	}
+	var stored storedBlock
+	if err := cbor.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+	if stored.Encoding != EncodingVersion {
+		return fmt.Errorf("%w: version %d", ErrUnknownEncoding, stored.Encoding)
+	}
+	*b = Block{
+		Version:   stored.Version,
+		Block:     stored.Block,
+		Link:      stored.Link,
+		Data:      stored.Data,
+		Root:      stored.Root,
+		Nonce:     stored.Nonce,
+		Hash:      stored.Hash,
+		Producer:  stored.Producer,
+		Signature: stored.Signature,
+		Records:   stored.Records,
+	}
+	return nil
+}
+
+// headerBytes returns the canonical encoding of the header of a HashVersion block.
+func headerBytes(b *Block, stake *big.Int) []byte {
+	data, err := encMode.Marshal(hashedHeader{
+		Version:    b.Version,
+		Block:      b.Block,
+		Link:       b.Link,
+		Data:       b.Data,
+		Root:       b.Root,
+		Difficulty: Difficulty,
+		Stake:      stake.Bytes(),
+	})
+	if err != nil {
+		// Integers and byte strings always have an encoding
+		panic(fmt.Sprintf("failed to encode the header of block %d: %v", b.Block, err))
+	}
+	return data
+}
+
+// appendNonce returns the hashed data of a HashVersion block from the encoding of its header.
+func appendNonce(header []byte, nonce int64) []byte {
+	return binary.BigEndian.AppendUint64(header[:len(header):len(header)], uint64(nonce))
+}
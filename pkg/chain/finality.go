@@ -0,0 +1,132 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/masa-finance/masa-oracle/pkg/consensus"
+)
+
+const (
+	// KeyFinalizedHash is the storage key of the hash of the last finalized block.
+	KeyFinalizedHash = "finalized_hash"
+	// KeyLastVote is the storage key of the last vote of this node, see VoteForHead.
+	KeyLastVote = "last_vote"
+	// certificatePrefix prefixes the storage keys of the finality certificates, followed by the block hash.
+	certificatePrefix = "certificate_"
+	// votePrefix separates the signatures of votes from the signatures of blocks.
+	votePrefix = "masa-finality-vote"
+	// maxPendingVotes is the number of blocks whose votes are kept before they are finalized.
+	maxPendingVotes = 1024
+)
+
+var (
+	// ErrInvalidVote is returned for a vote that isn't signed by an active validator, or that is too old.
+	ErrInvalidVote = errors.New("invalid finality vote")
+	// ErrInvalidCertificate is returned for a finality certificate without a quorum of valid votes.
+	ErrInvalidCertificate = errors.New("invalid finality certificate")
+	// ErrConflictsWithFinalized is returned for a block that isn't a descendant of the last finalized block.
+	ErrConflictsWithFinalized = errors.New("block conflicts with the finalized chain")
+)
+
+// Vote is the signed vote of a validator for a block of the chain.
+type Vote struct {
+	Hash      []byte `json:"hash"`
+	Block     uint64 `json:"block"`
+	Voter     string `json:"voter"`
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// NewVote creates the vote of the peer of the key for the block.
+func NewVote(block *Block, key crypto.PrivKey) (*Vote, error) {
+	if key == nil {
+		return nil, fmt.Errorf("no private key to vote for block %d", block.Block)
+	}
+	id, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	vote := &Vote{Hash: block.Hash, Block: block.Block, Voter: id.String()}
+	vote.Signature, err = consensus.SignData(key, vote.signedBytes())
+	if err != nil {
+		return nil, err
+	}
+	return vote, nil
+}
+
+// signedBytes returns the data signed by the voter.
+func (v *Vote) signedBytes() []byte {
+	data := make([]byte, 0, len(votePrefix)+len(v.Hash)+8+len(v.Voter))
+	data = append(data, votePrefix...)
+	data = append(data, v.Hash...)
+	data = binary.BigEndian.AppendUint64(data, v.Block)
+	return append(data, v.Voter...)
+}
+
+// Verify checks that the vote is signed by its voter.
+func (v *Vote) Verify() error {
+	id, err := peer.Decode(v.Voter)
+	if err != nil {
+		return fmt.Errorf("%w: invalid voter %q", ErrInvalidVote, v.Voter)
+	}
+	pubKey, err := id.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf("%w: failed to extract the public key of %s: %v", ErrInvalidVote, id, err)
+	}
+	if ok, err := pubKey.Verify(v.signedBytes(), v.Signature); err != nil || !ok {
+		return fmt.Errorf("%w: bad signature from %s", ErrInvalidVote, v.Voter)
+	}
+	return nil
+}
+
+// Certificate proves that a block is final: it holds the votes of at least 2/3 of the validators.
+type Certificate struct {
+	Hash  []byte `json:"hash"`
+	Block uint64 `json:"block"`
+	Votes []Vote `json:"votes"`
+}
+
+// Verify checks that the certificate holds valid votes for its block from a quorum of the
+// validators in effect at the block number.
+func (c *Certificate) Verify(validators *ValidatorSet) error {
+	active := validators.ValidatorsAt(c.Block)
+	voters := make(map[string]bool, len(c.Votes))
+	for i := range c.Votes {
+		vote := &c.Votes[i]
+		if !bytes.Equal(vote.Hash, c.Hash) || vote.Block != c.Block {
+			return fmt.Errorf("%w: vote of %s for another block", ErrInvalidCertificate, vote.Voter)
+		}
+		if !validators.ContainsAt(vote.Voter, c.Block) || voters[vote.Voter] {
+			continue
+		}
+		if err := vote.Verify(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidCertificate, err)
+		}
+		voters[vote.Voter] = true
+	}
+	if !hasQuorum(len(voters), len(active)) {
+		return fmt.Errorf("%w: %d votes out of %d validators", ErrInvalidCertificate, len(voters), len(active))
+	}
+	return nil
+}
+
+// hasQuorum returns whether the votes are at least 2/3 of the validators.
+func hasQuorum(votes, validators int) bool {
+	return validators > 0 && votes*3 >= validators*2
+}
+
+// certificateKey returns the storage key of the finality certificate of a block.
+func certificateKey(hash []byte) []byte {
+	return append([]byte(certificatePrefix), hash...)
+}
+
+// voteKey returns the key of the pending votes for a block.
+func voteKey(hash []byte) string {
+	return hex.EncodeToString(hash)
+}
@@ -0,0 +1,227 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// heightPrefix prefixes the keys of the index of the blocks of the chain by number, followed
+	// by the big endian number so that the keys are sorted by number.
+	heightPrefix = "height_"
+	// cidPrefix prefixes the keys of the index of the blocks of the chain by the CID of their data
+	// and of each of their records.
+	cidPrefix = "cid_"
+)
+
+// ErrBlockNotFound is returned for a block that isn't part of the chain.
+var ErrBlockNotFound = errors.New("block not found")
+
+// DataCID returns the CID of the data or of a record of a block, by which the blocks of the chain
+// are indexed.
+func DataCID(data []byte) (string, error) {
+	hash, err := multihash.Sum(data, multihash.SHA2_256, -1)
+	if err != nil {
+		return "", err
+	}
+	return cid.NewCidV1(cid.Raw, hash).String(), nil
+}
+
+// heightKey returns the key of a block number in the height index.
+func heightKey(number uint64) []byte {
+	return binary.BigEndian.AppendUint64([]byte(heightPrefix), number)
+}
+
+// cidKey returns the key of the data or of a record of a block in the CID index.
+func cidKey(data []byte) (string, error) {
+	id, err := DataCID(data)
+	if err != nil {
+		return "", err
+	}
+	return cidPrefix + id, nil
+}
+
+// indexed returns the hash stored under an index key, or nil.
+func (c *Chain) indexed(key []byte) ([]byte, error) {
+	known, err := c.storage.Has(key)
+	if err != nil || !known {
+		return nil, err
+	}
+	return c.storage.Get(key)
+}
+
+// indexHead returns the changes to the indexes when a block becomes the last block of the chain:
+// the blocks of its branch replace those of the current chain after their common ancestor. The
+// caller holds the lock.
+func (c *Chain) indexHead(head *Block) (Index, error) {
+	var branch []*Block
+	block := head
+	for {
+		hash, err := c.indexed(heightKey(block.Block))
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(hash, block.Hash) {
+			break
+		}
+		branch = append(branch, block)
+		if len(block.Link) == 0 {
+			break
+		}
+		if block, err = c.getBlock(block.Link); err != nil {
+			return nil, err
+		}
+	}
+	if len(branch) == 0 {
+		return nil, nil
+	}
+
+	index := Index{}
+	// The blocks of the current chain that aren't part of the branch
+	for number := branch[len(branch)-1].Block; number <= c.CurrentBlock; number++ {
+		hash, err := c.indexed(heightKey(number))
+		if err != nil {
+			return nil, err
+		}
+		if hash == nil {
+			continue
+		}
+		old, err := c.getBlock(hash)
+		if err != nil {
+			return nil, err
+		}
+		for _, payload := range old.payloads() {
+			key, err := cidKey(payload)
+			if err != nil {
+				return nil, err
+			}
+			if indexed, err := c.indexed([]byte(key)); err != nil {
+				return nil, err
+			} else if bytes.Equal(indexed, hash) {
+				index[key] = nil
+			}
+		}
+		if number > head.Block {
+			index[string(heightKey(number))] = nil
+		}
+	}
+	// From the oldest, so that the CID of data found in several blocks maps to the last one
+	for i := len(branch) - 1; i >= 0; i-- {
+		index[string(heightKey(branch[i].Block))] = branch[i].Hash
+		for _, payload := range branch[i].payloads() {
+			key, err := cidKey(payload)
+			if err != nil {
+				return nil, err
+			}
+			index[key] = branch[i].Hash
+		}
+	}
+	return index, nil
+}
+
+// reindex indexes the blocks of the chain ending with the given block that aren't indexed yet,
+// e.g. the blocks stored before the indexes were introduced.
+func (c *Chain) reindex(head *Block) error {
+	index, err := c.indexHead(head)
+	if err != nil || len(index) == 0 {
+		return err
+	}
+	logrus.Infof("[+] Indexing %d entries of the chain...", len(index))
+	return c.storage.SetBatch(index)
+}
+
+// GetBlockByNumber returns the block of the chain with the given number.
+func (c *Chain) GetBlockByNumber(number uint64) (*Block, error) {
+	if err := c.Check(); err != nil {
+		return nil, err
+	}
+	hash, err := c.indexed(heightKey(number))
+	if err != nil {
+		return nil, err
+	}
+	if hash == nil {
+		return nil, fmt.Errorf("%w: number %d", ErrBlockNotFound, number)
+	}
+	return c.getBlock(hash)
+}
+
+// GetBlockByDataCID returns the last block of the chain whose data, or one of whose records, has
+// the given CID, see DataCID.
+func (c *Chain) GetBlockByDataCID(id string) (*Block, error) {
+	if err := c.Check(); err != nil {
+		return nil, err
+	}
+	hash, err := c.indexed([]byte(cidPrefix + id))
+	if err != nil {
+		return nil, err
+	}
+	if hash == nil {
+		return nil, fmt.Errorf("%w: data CID %s", ErrBlockNotFound, id)
+	}
+	return c.getBlock(hash)
+}
+
+// GetInclusionProof returns the inclusion proof of the record at the given index of a stored block.
+func (c *Chain) GetInclusionProof(hash []byte, index int) (*InclusionProof, error) {
+	if err := c.Check(); err != nil {
+		return nil, err
+	}
+	block, err := c.getBlock(hash)
+	if err != nil {
+		return nil, err
+	}
+	return block.Proof(index)
+}
+
+// GetRecordProof returns the inclusion proof of a record of the chain with the given CID, see DataCID.
+func (c *Chain) GetRecordProof(id string) (*InclusionProof, error) {
+	block, err := c.GetBlockByDataCID(id)
+	if err != nil {
+		return nil, err
+	}
+	for i, record := range block.Records {
+		if recordID, err := DataCID(record); err != nil {
+			return nil, err
+		} else if recordID == id {
+			return block.Proof(i)
+		}
+	}
+	return nil, fmt.Errorf("%w: CID %s is the data of block %d", ErrRecordNotFound, id, block.Block)
+}
+
+// GetBlockRange returns the blocks of the chain numbered from "from" to "to" included, in order.
+// The range ends with the last block if "to" is above it.
+func (c *Chain) GetBlockRange(from, to uint64) ([]*Block, error) {
+	if err := c.Check(); err != nil {
+		return nil, err
+	}
+	if to < from {
+		return nil, nil
+	}
+	var hashes [][]byte
+	err := c.storage.IterateFrom([]byte(heightPrefix), heightKey(from), func(key, hash []byte) bool {
+		if binary.BigEndian.Uint64(key[len(heightPrefix):]) > to {
+			return false
+		}
+		hashes = append(hashes, hash)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]*Block, 0, len(hashes))
+	for _, hash := range hashes {
+		block, err := c.getBlock(hash)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
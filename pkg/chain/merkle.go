@@ -0,0 +1,175 @@
+package chain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// leafPrefix and nodePrefix separate the hashes of the records from the hashes of the inner
+	// nodes of a Merkle tree, so that an inner node can't be passed off as a record.
+	leafPrefix = []byte{0}
+	nodePrefix = []byte{1}
+	// rootPrefix separates the root, which commits to the number of records, from the other hashes.
+	rootPrefix = []byte{2}
+)
+
+// ErrInvalidProof is returned for an inclusion proof that doesn't prove that its record is part of the block.
+var ErrInvalidProof = errors.New("invalid inclusion proof")
+
+// MerkleRoot returns the root of the Merkle tree of the records, nil without records. At each
+// level of the tree, a last node without a sibling moves up unchanged. The root is the hash of the
+// number of records and the top of the tree, since trees of different sizes can share their top.
+func MerkleRoot(records [][]byte) []byte {
+	if len(records) == 0 {
+		return nil
+	}
+	level := merkleLeaves(records)
+	for len(level) > 1 {
+		level = merkleLevel(level)
+	}
+	return merkleCountRoot(len(records), level[0])
+}
+
+// merkleCountRoot returns the root committing to the number of records and the top of their tree.
+func merkleCountRoot(count int, top []byte) []byte {
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], uint64(count))
+	return merkleHash(rootPrefix, size[:], top)
+}
+
+// merkleLeaves returns the hashes of the records.
+func merkleLeaves(records [][]byte) [][]byte {
+	leaves := make([][]byte, len(records))
+	for i, record := range records {
+		leaves[i] = merkleHash(leafPrefix, record)
+	}
+	return leaves
+}
+
+// merkleLevel returns the level of the Merkle tree above the given one.
+func merkleLevel(level [][]byte) [][]byte {
+	next := make([][]byte, 0, (len(level)+1)/2)
+	for i := 0; i+1 < len(level); i += 2 {
+		next = append(next, merkleHash(nodePrefix, level[i], level[i+1]))
+	}
+	if len(level)%2 == 1 {
+		next = append(next, level[len(level)-1])
+	}
+	return next
+}
+
+func merkleHash(prefix []byte, data ...[]byte) []byte {
+	hash := sha256.New()
+	hash.Write(prefix)
+	for _, d := range data {
+		hash.Write(d)
+	}
+	return hash.Sum(nil)
+}
+
+// merklePath returns the hashes of the siblings of a record on the way up to the root.
+func merklePath(records [][]byte, index int) [][]byte {
+	var path [][]byte
+	level := merkleLeaves(records)
+	for len(level) > 1 {
+		if sibling := index ^ 1; sibling < len(level) {
+			path = append(path, level[sibling])
+		}
+		level = merkleLevel(level)
+		index /= 2
+	}
+	return path
+}
+
+// merkleRootFromPath returns the root of a Merkle tree of count records from one of its records
+// and the path of the record.
+func merkleRootFromPath(record []byte, index, count int, path [][]byte) ([]byte, error) {
+	if index < 0 || index >= count {
+		return nil, fmt.Errorf("%w: record %d out of %d", ErrInvalidProof, index, count)
+	}
+	hash := merkleHash(leafPrefix, record)
+	for width := count; width > 1; width = (width + 1) / 2 {
+		if sibling := index ^ 1; sibling < width {
+			if len(path) == 0 {
+				return nil, fmt.Errorf("%w: path too short", ErrInvalidProof)
+			}
+			if index%2 == 0 {
+				hash = merkleHash(nodePrefix, hash, path[0])
+			} else {
+				hash = merkleHash(nodePrefix, path[0], hash)
+			}
+			path = path[1:]
+		}
+		index /= 2
+	}
+	if len(path) > 0 {
+		return nil, fmt.Errorf("%w: path too long", ErrInvalidProof)
+	}
+	return merkleCountRoot(count, hash), nil
+}
+
+// BlockHeader holds the fields of a block its hash and signature are computed from, without its
+// records.
+type BlockHeader struct {
+	Version   uint64 `json:"version"`
+	Block     uint64 `json:"block"`
+	Link      []byte `json:"link"`
+	Data      []byte `json:"data,omitempty"`
+	Root      []byte `json:"root"`
+	Nonce     int64  `json:"nonce"`
+	Producer  string `json:"producer"`
+	Signature []byte `json:"signature"`
+}
+
+// InclusionProof proves that a record is part of a block: the Merkle path of the record leads to
+// the root of the block header, and the header hashes to the block hash.
+type InclusionProof struct {
+	Record []byte      `json:"record"`
+	Index  int         `json:"index"`
+	Count  int         `json:"count"`
+	Path   [][]byte    `json:"path"`
+	Header BlockHeader `json:"header"`
+}
+
+// VerifyInclusionProof checks that the record of a proof is part of the block with the given hash,
+// without access to the chain: the path of the record must lead to the root of the header, the
+// header must hash to the block hash with a valid proof of stake, and it must be signed by its
+// producer. Whether the producer is a validator, or the block is final, is up to the caller.
+func VerifyInclusionProof(proof *InclusionProof, blockHash []byte) error {
+	root, err := merkleRootFromPath(proof.Record, proof.Index, proof.Count, proof.Path)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root, proof.Header.Root) {
+		return fmt.Errorf("%w: the record doesn't lead to the Merkle root of the block", ErrInvalidProof)
+	}
+	block := &Block{
+		Version:   proof.Header.Version,
+		Block:     proof.Header.Block,
+		Data:      proof.Header.Data,
+		Hash:      blockHash,
+		Link:      proof.Header.Link,
+		Nonce:     proof.Header.Nonce,
+		Root:      proof.Header.Root,
+		Producer:  proof.Header.Producer,
+		Signature: proof.Header.Signature,
+	}
+	if block.Version > HashVersion {
+		return fmt.Errorf("%w: unknown hash version %d", ErrInvalidProof, block.Version)
+	}
+	if !bytes.Equal(BlockHash(block, big.NewInt(1)), blockHash) {
+		return fmt.Errorf("%w: the header doesn't match the block hash %x", ErrInvalidProof, blockHash)
+	}
+	if !IsValidPoS(block, big.NewInt(1)) {
+		return fmt.Errorf("%w: proof of stake of block %x", ErrInvalidProof, blockHash)
+	}
+	if err := block.Verify(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package chain_test
+
+import (
+	"fmt"
+	"math/big"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/masa-finance/masa-oracle/pkg/chain"
+)
+
+var _ = Describe("Merkle", func() {
+	records := func(n int) [][]byte {
+		var records [][]byte
+		for i := 0; i < n; i++ {
+			records = append(records, []byte(fmt.Sprintf(`{"event":%d}`, i)))
+		}
+		return records
+	}
+
+	It("commits to the records and their order", func() {
+		Expect(MerkleRoot(nil)).To(BeNil())
+		Expect(MerkleRoot(records(1))).To(HaveLen(32))
+		Expect(MerkleRoot(records(3))).To(Equal(MerkleRoot(records(3))))
+		Expect(MerkleRoot(records(3))).NotTo(Equal(MerkleRoot(records(4))))
+
+		swapped := records(3)
+		swapped[0], swapped[1] = swapped[1], swapped[0]
+		Expect(MerkleRoot(swapped)).NotTo(Equal(MerkleRoot(records(3))))
+	})
+
+	Context("inclusion proofs", func() {
+		var block *Block
+
+		BeforeEach(func() {
+			key, _ := newKey()
+			block = &Block{}
+			block.BuildRecords(records(5), []byte("previous block hash"), big.NewInt(1), 7)
+			Expect(block.Sign(key)).To(Succeed())
+		})
+
+		It("proves every record against the block hash", func() {
+			key, _ := newKey()
+			for _, n := range []int{1, 2, 4, 7} {
+				b := &Block{}
+				b.BuildRecords(records(n), []byte("previous block hash"), big.NewInt(1), 7)
+				Expect(b.Sign(key)).To(Succeed())
+				for i := 0; i < n; i++ {
+					proof, err := b.Proof(i)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(proof.Record).To(Equal(records(n)[i]))
+					Expect(VerifyInclusionProof(proof, b.Hash)).To(Succeed(), "record %d of %d", i, n)
+				}
+			}
+			_, err := block.Proof(5)
+			Expect(err).To(MatchError(ErrRecordNotFound))
+		})
+
+		It("rejects proofs that don't match the block", func() {
+			proof, err := block.Proof(2)
+			Expect(err).NotTo(HaveOccurred())
+
+			tampered := *proof
+			tampered.Record = []byte(`{"event":42}`)
+			Expect(VerifyInclusionProof(&tampered, block.Hash)).To(MatchError(ErrInvalidProof))
+
+			tampered = *proof
+			tampered.Index = 3
+			Expect(VerifyInclusionProof(&tampered, block.Hash)).To(MatchError(ErrInvalidProof))
+
+			// The path of the first record is the same in a tree of 8 records
+			first, err := block.Proof(0)
+			Expect(err).NotTo(HaveOccurred())
+			tampered = *first
+			tampered.Count = 8
+			Expect(VerifyInclusionProof(&tampered, block.Hash)).To(MatchError(ErrInvalidProof))
+
+			tampered = *proof
+			tampered.Path = tampered.Path[1:]
+			Expect(VerifyInclusionProof(&tampered, block.Hash)).To(MatchError(ErrInvalidProof))
+
+			tampered = *proof
+			tampered.Header.Block = 8
+			Expect(VerifyInclusionProof(&tampered, block.Hash)).To(MatchError(ErrInvalidProof))
+
+			other := &Block{}
+			other.BuildRecords(records(2), []byte("previous block hash"), big.NewInt(1), 7)
+			Expect(VerifyInclusionProof(proof, other.Hash)).To(MatchError(ErrInvalidProof))
+		})
+	})
+})
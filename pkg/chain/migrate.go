@@ -0,0 +1,189 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+// migrationBatchSize is the number of blocks converted per write batch.
+const migrationBatchSize = 1024
+
+// MigrationReport describes the conversion of a block store to the canonical encoding.
+type MigrationReport struct {
+	// Blocks is the number of blocks stored, of the chain and of forks.
+	Blocks int
+	// Converted is the number of blocks converted from the legacy gob encoding.
+	Converted int
+	// Chain is the number of blocks of the chain, from the last block back to the genesis block.
+	Chain int
+}
+
+// Migrate converts the blocks stored in the legacy gob encoding to the canonical encoding, in
+// place, in the chain under path, the path given to Init. The whole store is verified first, and
+// nothing is written unless every block hashes to its key and the chain leads from the last block
+// back to its genesis block. The converted store is verified again. Block hashes don't change,
+// converted blocks keep their LegacyHashVersion hash. Migrate can be run again if it was
+// interrupted. The chain must not be open, e.g. by a running node.
+func Migrate(path string) (*MigrationReport, error) {
+	dataDir := filepath.Join(path, "./blocks")
+	if _, err := os.Stat(dataDir); err != nil {
+		return nil, fmt.Errorf("no chain in %s: %w", dataDir, err)
+	}
+	storage := &Persistance{}
+	if err := storage.open(dataDir); err != nil {
+		return nil, err
+	}
+	defer storage.Close()
+
+	keys, err := blockKeys(storage)
+	if err != nil {
+		return nil, err
+	}
+	report := &MigrationReport{Blocks: len(keys)}
+	var legacy [][]byte
+	if report.Chain, legacy, err = verifyStore(storage, keys); err != nil {
+		return report, fmt.Errorf("the chain wasn't converted: %w", err)
+	}
+
+	batch := make(map[string][]byte)
+	for _, key := range legacy {
+		block, _, err := loadBlock(storage, key)
+		if err != nil {
+			return report, err
+		}
+		if batch[string(key)], err = encodeBlock(block); err != nil {
+			return report, err
+		}
+		report.Converted++
+		if len(batch) >= migrationBatchSize {
+			if err := storage.SetBatch(batch); err != nil {
+				return report, err
+			}
+			batch = make(map[string][]byte)
+		}
+	}
+	if err := storage.SetBatch(batch); err != nil {
+		return report, err
+	}
+	logrus.Infof("[+] Converted %d of %d blocks to encoding version %d, verifying...", report.Converted, report.Blocks, EncodingVersion)
+
+	if _, legacy, err = verifyStore(storage, keys); err != nil {
+		return report, err
+	}
+	if len(legacy) > 0 {
+		return report, fmt.Errorf("%d blocks are still stored in the legacy encoding", len(legacy))
+	}
+	return report, nil
+}
+
+// blockKeys returns the keys of the stored blocks, which are their hashes.
+func blockKeys(storage *Persistance) ([][]byte, error) {
+	var keys [][]byte
+	err := storage.IterateFrom(nil, nil, func(key, _ []byte) bool {
+		if !isStateKey(key) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+	return keys, err
+}
+
+// isStateKey returns whether a key holds the state of the chain or an index rather than a block.
+func isStateKey(key []byte) bool {
+	switch string(key) {
+	case KeyLastHash, KeyFinalizedHash, KeyLastVote:
+		return true
+	}
+	for _, prefix := range []string{certificatePrefix, heightPrefix, cidPrefix, validatorSetPrefix} {
+		if bytes.HasPrefix(key, []byte(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadBlock returns a stored block, and whether it is stored in the legacy gob encoding.
+func loadBlock(storage *Persistance, hash []byte) (*Block, bool, error) {
+	data, err := storage.Get(hash)
+	if err != nil {
+		return nil, false, err
+	}
+	block := &Block{}
+	err = decodeBlock(data, block)
+	if err == nil {
+		// Only the canonical encoding of the block is valid
+		encoded, err := encodeBlock(block)
+		if err != nil {
+			return nil, false, err
+		}
+		if !bytes.Equal(encoded, data) {
+			return nil, false, fmt.Errorf("block %x isn't stored in its canonical encoding", hash)
+		}
+		return block, false, nil
+	}
+	if !errors.Is(err, ErrLegacyEncoding) {
+		return nil, false, fmt.Errorf("block %x: %w", hash, err)
+	}
+	block = &Block{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(block); err != nil {
+		return nil, false, fmt.Errorf("failed to decode block %x in the legacy encoding: %w", hash, err)
+	}
+	return block, true, nil
+}
+
+// verifyStore checks that the blocks hash to their keys, and returns the number of blocks of the
+// chain, which must lead from the last block back to a genesis block, and the keys of the blocks
+// stored in the legacy encoding. The genesis block is the stored block 0 without a link: chains
+// created before the genesis block was fixed have a genesis block of their own.
+func verifyStore(storage *Persistance, keys [][]byte) (int, [][]byte, error) {
+	var legacy [][]byte
+	for _, key := range keys {
+		block, isLegacy, err := loadBlock(storage, key)
+		if err != nil {
+			return 0, nil, err
+		}
+		if isLegacy {
+			legacy = append(legacy, key)
+		}
+		if block.Version > HashVersion || !bytes.Equal(block.Hash, key) || !bytes.Equal(BlockHash(block, big.NewInt(1)), key) {
+			return 0, nil, fmt.Errorf("block %d doesn't hash to its key %x", block.Block, key)
+		}
+		if !bytes.Equal(MerkleRoot(block.Records), block.Root) {
+			return 0, nil, fmt.Errorf("block %d %x doesn't match the Merkle root of its records", block.Block, key)
+		}
+	}
+
+	hash, err := storage.GetLastHash()
+	if err != nil {
+		return 0, nil, err
+	}
+	count := 0
+	for {
+		block, _, err := loadBlock(storage, hash)
+		if err != nil {
+			return count, nil, err
+		}
+		count++
+		if len(block.Link) == 0 {
+			if block.Block != 0 || block.Version != LegacyHashVersion {
+				return count, nil, fmt.Errorf("the chain starts with block %d %x instead of a genesis block", block.Block, block.Hash)
+			}
+			return count, legacy, nil
+		}
+		parent, _, err := loadBlock(storage, block.Link)
+		if err != nil {
+			return count, nil, err
+		}
+		if parent.Block+1 != block.Block {
+			return count, nil, fmt.Errorf("block %d %x follows block %d", block.Block, block.Hash, parent.Block)
+		}
+		hash = block.Link
+	}
+}
@@ -0,0 +1,94 @@
+package chain_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/masa-finance/masa-oracle/pkg/chain"
+)
+
+var _ = Describe("Migrate", func() {
+	var dir string
+	var legacy []*Block
+	var key crypto.PrivKey
+	var validators *ValidatorSet
+
+	gobEncode := func(block *Block) ([]byte, error) {
+		var data bytes.Buffer
+		err := gob.NewEncoder(&data).Encode(block)
+		return data.Bytes(), err
+	}
+
+	BeforeEach(func() {
+		var validator string
+		key, validator = newKey()
+		_, governance := newKey()
+		var err error
+		validators, err = NewValidatorSet([]string{validator}, governance)
+		Expect(err).NotTo(HaveOccurred())
+
+		dir, err = os.MkdirTemp("", "chain")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+
+		// A chain stored by an older node, with a genesis block of its own and unsigned blocks
+		genesis := legacyBlock([]byte("Genesis"), nil)
+		first := legacyBlock([]byte("first"), genesis)
+		legacy = []*Block{genesis, first, legacyBlock([]byte("second"), first)}
+	})
+
+	It("converts a legacy chain in place and keeps its hashes", func() {
+		writeStore(dir, gobEncode, legacy...)
+		c := &Chain{}
+		c.SetValidators(validators)
+		Expect(c.Init(dir)).To(MatchError(ErrLegacyEncoding))
+
+		report, err := Migrate(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*report).To(Equal(MigrationReport{Blocks: 3, Converted: 3, Chain: 3}))
+
+		Expect(c.Init(dir)).To(Succeed())
+		Expect(c.LastHash).To(Equal(legacy[2].Hash))
+		Expect(c.CurrentBlock).To(BeEquivalentTo(2))
+		for _, block := range legacy {
+			stored, err := c.GetBlockByNumber(block.Block)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Hash).To(Equal(block.Hash))
+			Expect(stored.Version).To(BeEquivalentTo(LegacyHashVersion))
+		}
+
+		Expect(c.AddBlock([]byte("third"), key)).To(Succeed())
+		last, err := c.GetLastBlock()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(last.Version).To(BeEquivalentTo(HashVersion))
+		Expect(last.Link).To(Equal(legacy[2].Hash))
+		Expect(c.Close()).To(Succeed())
+
+		report, err = Migrate(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*report).To(Equal(MigrationReport{Blocks: 4, Converted: 0, Chain: 4}))
+	})
+
+	It("leaves a chain that fails verification untouched", func() {
+		legacy[1].Data = []byte("tampered")
+		writeStore(dir, gobEncode, legacy...)
+
+		_, err := Migrate(dir)
+		Expect(err).To(MatchError(ContainSubstring("doesn't hash to its key")))
+
+		c := &Chain{}
+		c.SetValidators(validators)
+		Expect(c.Init(dir)).To(MatchError(ErrLegacyEncoding))
+	})
+
+	It("fails without a chain", func() {
+		_, err := Migrate(filepath.Join(dir, "missing"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -10,7 +10,28 @@ const (
 )
 
 type Persistance struct {
-	db *badger.DB
+	db     *badger.DB
+	closed bool
+}
+
+// Index maps the keys of the secondary indexes of the chain to block hashes. A nil hash removes
+// the key.
+type Index map[string][]byte
+
+// apply writes the index entries in a transaction.
+func (index Index) apply(transaction *badger.Txn) error {
+	for key, hash := range index {
+		var err error
+		if hash == nil {
+			err = transaction.Delete([]byte(key))
+		} else {
+			err = transaction.Set([]byte(key), hash)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 type Serializable interface {
@@ -18,19 +39,27 @@ type Serializable interface {
 	Deserialize(data []byte) error
 }
 
-func (p *Persistance) Init(path string, genesisFn func() (Serializable, []byte)) ([]byte, error) {
+// open opens the datastore.
+func (p *Persistance) open(path string) error {
 	dbOptions := badger.DefaultOptions(path)
 	dbOptions.Logger = nil
 	db, err := badger.Open(dbOptions)
 	if err != nil {
 		logrus.Error("[-] Failed to initialize datastore: ", db, err)
-		return nil, err
+		return err
 	}
 	p.db = db
+	return nil
+}
+
+func (p *Persistance) Init(path string, genesisFn func() (Serializable, []byte)) ([]byte, error) {
+	if err := p.open(path); err != nil {
+		return nil, err
+	}
 
 	var lastHash []byte
 
-	err = p.db.Update(func(transaction *badger.Txn) error {
+	err := p.db.Update(func(transaction *badger.Txn) error {
 		lastHashKey := KeyLastHash
 		item, err := transaction.Get([]byte(lastHashKey))
 
@@ -97,7 +126,95 @@ func (p *Persistance) GetLastHash() ([]byte, error) {
 	return p.Get([]byte(KeyLastHash))
 }
 
-func (p *Persistance) SaveBlock(hash []byte, block Serializable) error {
+// Has returns whether a value is stored under the key.
+func (p *Persistance) Has(key []byte) (bool, error) {
+	if len(key) == 0 {
+		return false, nil
+	}
+	err := p.db.View(func(transaction *badger.Txn) error {
+		_, err := transaction.Get(key)
+		return err
+	})
+	if err == badger.ErrKeyNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Set stores a value under the key.
+func (p *Persistance) Set(key, value []byte) error {
+	err := p.db.Update(func(transaction *badger.Txn) error {
+		return transaction.Set(key, value)
+	})
+	if err != nil {
+		logrus.Error("[-] Failed to run Set transaction in the datastore: ", err)
+	}
+	return err
+}
+
+// SetBatch writes values by key, in as many transactions as needed. A nil value removes the key.
+func (p *Persistance) SetBatch(values map[string][]byte) error {
+	batch := p.db.NewWriteBatch()
+	defer batch.Cancel()
+	for key, value := range values {
+		var err error
+		if value == nil {
+			err = batch.Delete([]byte(key))
+		} else {
+			err = batch.Set([]byte(key), value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := batch.Flush(); err != nil {
+		logrus.Error("[-] Failed to write a batch in the datastore: ", err)
+		return err
+	}
+	return nil
+}
+
+// Finalize stores the finality certificate of a block and makes it the last finalized block. If
+// head is set, the block also becomes the last block, and the index is updated with it.
+func (p *Persistance) Finalize(hash, certificateKey, certificate []byte, head bool, index Index) error {
+	err := p.db.Update(func(transaction *badger.Txn) error {
+		if err := transaction.Set(certificateKey, certificate); err != nil {
+			return err
+		}
+		if err := transaction.Set([]byte(KeyFinalizedHash), hash); err != nil {
+			return err
+		}
+		if head {
+			if err := index.apply(transaction); err != nil {
+				return err
+			}
+			return transaction.Set([]byte(KeyLastHash), hash)
+		}
+		return nil
+	})
+	if err != nil {
+		logrus.Error("[-] Failed to run Finalize transaction in the datastore: ", err)
+	}
+	return err
+}
+
+// StoreBlock saves a block without making it the last block, e.g. a block of a fork that isn't the longest.
+func (p *Persistance) StoreBlock(hash []byte, block Serializable) error {
+	serialData, err := block.Serialize()
+	if err != nil {
+		return err
+	}
+	err = p.db.Update(func(transaction *badger.Txn) error {
+		return transaction.Set(hash, serialData)
+	})
+	if err != nil {
+		logrus.Error("[-] Failed to run StoreBlock transaction in the datastore: ", err)
+	}
+	return err
+}
+
+// SaveBlock saves a block and makes it the last block, updating the index with it.
+func (p *Persistance) SaveBlock(hash []byte, block Serializable, index Index) error {
 	err := p.db.Update(func(transaction *badger.Txn) error {
 		serialData, err := block.Serialize()
 		if err != nil {
@@ -106,6 +223,9 @@ func (p *Persistance) SaveBlock(hash []byte, block Serializable) error {
 		if err := transaction.Set(hash, serialData); err != nil {
 			return err
 		}
+		if err := index.apply(transaction); err != nil {
+			return err
+		}
 		if err := transaction.Set([]byte(KeyLastHash), hash); err != nil {
 			return err
 		}
@@ -140,3 +260,33 @@ func (p *Persistance) Iterate(prefix []byte, block Serializable, callback func(v
 	}
 	return nil
 }
+
+// IterateFrom calls the callback with the keys and values stored under the prefix, in key order,
+// starting from the start key, until the callback returns false.
+func (p *Persistance) IterateFrom(prefix, start []byte, callback func(key, value []byte) bool) error {
+	return p.db.View(func(transaction *badger.Txn) error {
+		iterator := transaction.NewIterator(badger.DefaultIteratorOptions)
+		defer iterator.Close()
+
+		for iterator.Seek(start); iterator.ValidForPrefix(prefix); iterator.Next() {
+			item := iterator.Item()
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if !callback(item.KeyCopy(nil), value) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// Close closes the datastore, flushing pending writes to disk.
+func (p *Persistance) Close() error {
+	if p.db == nil {
+		return nil
+	}
+	p.closed = true
+	return p.db.Close()
+}
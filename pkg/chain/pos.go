@@ -27,11 +27,24 @@ func GetProofOfStakeTarget(stake *big.Int) *big.Int {
 	return target
 }
 
+// hashedData returns the data hashed with a nonce, according to the hash version of the block.
+func (pos *ProofOfStake) hashedData() func(nonce int64) []byte {
+	if pos.Block.Version == LegacyHashVersion {
+		return pos.joinData
+	}
+	header := headerBytes(pos.Block, pos.Stake)
+	return func(nonce int64) []byte {
+		return appendNonce(header, nonce)
+	}
+}
+
+// joinData returns the data hashed with a nonce for LegacyHashVersion blocks.
 func (pos *ProofOfStake) joinData(timestamp int64) []byte {
 	return bytes.Join(
 		[][]byte{
 			pos.Block.Link,
 			pos.Block.Data,
+			pos.Block.Root,
 			big.NewInt(timestamp).Bytes(),
 			big.NewInt(Difficulty).Bytes(),
 			pos.Stake.Bytes(),
@@ -41,15 +54,20 @@ func (pos *ProofOfStake) joinData(timestamp int64) []byte {
 }
 
 func (pos *ProofOfStake) Run() (int64, []byte) {
+	return pos.runFrom(time.Now().Unix())
+}
+
+// runFrom searches a nonce, starting at the given timestamp, whose hash meets the target.
+func (pos *ProofOfStake) runFrom(currentTime int64) (int64, []byte) {
 	var hash [32]byte
 	var hashInt big.Int
-	currentTime := time.Now().Unix()
 
 	logrus.WithFields(logrus.Fields{"nonce": currentTime}).Info("[+] Running Proof of Stake...")
 	//spinner := []string{"|", "/", "-", "\\"}
+	hashedData := pos.hashedData()
 	i := 0
 	for {
-		data := pos.joinData(currentTime)
+		data := hashedData(currentTime)
 		hash = sha256.Sum256(data)
 		hashInt.SetBytes(hash[:])
 		//	fmt.Printf("\r%s %x", spinner[i%len(spinner)], hash)
@@ -64,10 +82,17 @@ func (pos *ProofOfStake) Run() (int64, []byte) {
 	return currentTime, hash[:]
 }
 
+// BlockHash recomputes the hash of a block from its header and nonce, according to its hash version.
+func BlockHash(block *Block, stake *big.Int) []byte {
+	pos := &ProofOfStake{Block: block, Stake: stake}
+	hash := sha256.Sum256(pos.hashedData()(block.Nonce))
+	return hash[:]
+}
+
 func IsValidPoS(block *Block, stake *big.Int) bool {
 	var hashIntegerRep big.Int
 	pos := &ProofOfStake{Block: block, Target: GetProofOfStakeTarget(stake), Stake: stake}
-	data := pos.joinData(block.Nonce)
+	data := pos.hashedData()(block.Nonce)
 	hash := sha256.Sum256(data)
 	hashIntegerRep.SetBytes(hash[:])
 	return hashIntegerRep.Cmp(pos.Target) == -1
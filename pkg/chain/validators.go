@@ -0,0 +1,229 @@
+package chain
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/masa-finance/masa-oracle/pkg/consensus"
+)
+
+// validatorSetPrefix prefixes the storage keys of the applied validator set records, followed by
+// the big endian version so that the records are sorted by version.
+const validatorSetPrefix = "validator_set_"
+
+// validatorSetKey returns the storage key of the validator set record with the given version.
+func validatorSetKey(version uint64) []byte {
+	return binary.BigEndian.AppendUint64([]byte(validatorSetPrefix), version)
+}
+
+var (
+	// ErrUnauthorizedProducer is returned for a block that isn't signed by an active validator.
+	ErrUnauthorizedProducer = errors.New("block not signed by an active validator")
+	// ErrInvalidValidatorSet is returned for a validator set record that can't be applied.
+	ErrInvalidValidatorSet = errors.New("invalid validator set record")
+)
+
+// ValidatorSetRecord is a governance record replacing the validator set from the block numbered
+// Height on. It must be signed by the governance peer, and only applies if its version is higher
+// than the version of the current set, and its height isn't below the height of the current set.
+// The height should be above the last block of the chain: blocks are checked against the set in
+// effect at their number, so a record doesn't change the validity of blocks built before it.
+type ValidatorSetRecord struct {
+	Version    uint64   `json:"version"`
+	Height     uint64   `json:"height"`
+	Validators []string `json:"validators"`
+	Signer     string   `json:"signer"`
+	Signature  []byte   `json:"signature,omitempty"`
+}
+
+// signedBytes returns the JSON encoding of the record without its signature.
+func (r ValidatorSetRecord) signedBytes() ([]byte, error) {
+	r.Signature = nil
+	return json.Marshal(r)
+}
+
+// Sign signs the record with the private key of the governance peer.
+func (r *ValidatorSetRecord) Sign(key crypto.PrivKey) error {
+	if key == nil {
+		return fmt.Errorf("no private key to sign the validator set")
+	}
+	id, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	r.Signer = id.String()
+	data, err := r.signedBytes()
+	if err != nil {
+		return err
+	}
+	r.Signature, err = consensus.SignData(key, data)
+	return err
+}
+
+// ValidatorSet is the set of peers allowed to produce blocks and vote for them, along with the
+// sets that were in effect at lower block numbers. It is safe for concurrent use.
+type ValidatorSet struct {
+	mu         sync.RWMutex
+	epochs     []validatorEpoch // by increasing height
+	governance peer.ID
+}
+
+// validatorEpoch is a validator set and the number of the first block it applies to.
+type validatorEpoch struct {
+	version    uint64
+	height     uint64
+	validators map[string]bool
+}
+
+// NewValidatorSet creates the initial validator set, at version 0 from the genesis block on.
+// Governance records can only change it if a governance peer is given.
+func NewValidatorSet(validators []string, governance string) (*ValidatorSet, error) {
+	initial, err := parseValidators(validators)
+	if err != nil {
+		return nil, err
+	}
+	set := &ValidatorSet{epochs: []validatorEpoch{{validators: initial}}}
+	if governance != "" {
+		id, err := peer.Decode(governance)
+		if err != nil {
+			return nil, fmt.Errorf("invalid governance peer ID %q: %w", governance, err)
+		}
+		set.governance = id
+	}
+	return set, nil
+}
+
+// parseValidators returns the set of the validator peer IDs.
+func parseValidators(validators []string) (map[string]bool, error) {
+	set := make(map[string]bool, len(validators))
+	for _, v := range validators {
+		id, err := peer.Decode(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator peer ID %q: %w", v, err)
+		}
+		set[id.String()] = true
+	}
+	return set, nil
+}
+
+// current returns the last applied set. The caller holds the lock.
+func (s *ValidatorSet) current() validatorEpoch {
+	return s.epochs[len(s.epochs)-1]
+}
+
+// at returns the set in effect at a block number. The caller holds the lock.
+func (s *ValidatorSet) at(height uint64) validatorEpoch {
+	for i := len(s.epochs) - 1; i > 0; i-- {
+		if s.epochs[i].height <= height {
+			return s.epochs[i]
+		}
+	}
+	return s.epochs[0]
+}
+
+// Contains returns whether the peer is a validator of the last applied set.
+func (s *ValidatorSet) Contains(peerID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current().validators[peerID]
+}
+
+// ContainsAt returns whether the peer is a validator of the set in effect at a block number.
+func (s *ValidatorSet) ContainsAt(peerID string, height uint64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.at(height).validators[peerID]
+}
+
+// Validators returns the peer IDs of the validators of the last applied set, sorted.
+func (s *ValidatorSet) Validators() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return sortedValidators(s.current().validators)
+}
+
+// ValidatorsAt returns the peer IDs of the validators of the set in effect at a block number, sorted.
+func (s *ValidatorSet) ValidatorsAt(height uint64) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return sortedValidators(s.at(height).validators)
+}
+
+func sortedValidators(set map[string]bool) []string {
+	validators := make([]string, 0, len(set))
+	for v := range set {
+		validators = append(validators, v)
+	}
+	sort.Strings(validators)
+	return validators
+}
+
+// Version returns the version of the last applied governance record, 0 for the initial set.
+func (s *ValidatorSet) Version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current().version
+}
+
+// Height returns the number of the first block the last applied set applies to.
+func (s *ValidatorSet) Height() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current().height
+}
+
+// Apply adds the validators of a governance record from its height on, after checking its
+// signature, version and height.
+func (s *ValidatorSet) Apply(record ValidatorSetRecord) error {
+	if err := s.verify(record); err != nil {
+		return err
+	}
+	validators, err := parseValidators(record.Validators)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidValidatorSet, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := s.current()
+	if record.Version <= current.version {
+		return fmt.Errorf("%w: version %d is not newer than %d", ErrInvalidValidatorSet, record.Version, current.version)
+	}
+	if record.Height < current.height {
+		return fmt.Errorf("%w: height %d is below the height %d of version %d", ErrInvalidValidatorSet, record.Height, current.height, current.version)
+	}
+	if record.Height == current.height {
+		s.epochs = s.epochs[:len(s.epochs)-1]
+	}
+	s.epochs = append(s.epochs, validatorEpoch{version: record.Version, height: record.Height, validators: validators})
+	return nil
+}
+
+// verify checks that the record is signed by the governance peer.
+func (s *ValidatorSet) verify(record ValidatorSetRecord) error {
+	if s.governance == "" {
+		return fmt.Errorf("%w: no governance peer configured", ErrInvalidValidatorSet)
+	}
+	if record.Signer != s.governance.String() {
+		return fmt.Errorf("%w: signed by %s instead of the governance peer", ErrInvalidValidatorSet, record.Signer)
+	}
+	pubKey, err := s.governance.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf("%w: failed to extract the public key of %s: %v", ErrInvalidValidatorSet, s.governance, err)
+	}
+	data, err := record.signedBytes()
+	if err != nil {
+		return err
+	}
+	if ok, err := pubKey.Verify(data, record.Signature); err != nil || !ok {
+		return fmt.Errorf("%w: bad signature", ErrInvalidValidatorSet)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
+	"github.com/masa-finance/masa-oracle/pkg/network"
+	"github.com/masa-finance/masa-oracle/pkg/ratelimit"
+)
+
+// LogLevel returns the log level of the node.
+func (c *Client) LogLevel(ctx context.Context) (string, error) {
+	var level api_types.LogLevel
+	if err := c.get(ctx, "/admin/loglevel", nil, &api_types.Response{Data: &level}); err != nil {
+		return "", err
+	}
+	return level.Level, nil
+}
+
+// SetLogLevel changes the log level of the node, e.g. to "debug".
+func (c *Client) SetLogLevel(ctx context.Context, level string) error {
+	return c.send(ctx, http.MethodPut, "/admin/loglevel", api_types.LogLevel{Level: level}, nil)
+}
+
+// Bans lists the peers banned by the node.
+func (c *Client) Bans(ctx context.Context) ([]network.Ban, error) {
+	var bans []network.Ban
+	if err := c.get(ctx, "/admin/bans", nil, &api_types.Response{Data: &bans}); err != nil {
+		return nil, err
+	}
+	return bans, nil
+}
+
+// BanPeer bans a peer. A zero ttl bans the peer until it is unbanned.
+func (c *Client) BanPeer(ctx context.Context, peerID, reason string, ttl time.Duration) (*network.Ban, error) {
+	req := api_types.BanRequest{PeerId: peerID, Reason: reason}
+	if ttl > 0 {
+		req.Duration = ttl.String()
+	}
+	var ban network.Ban
+	if err := c.send(ctx, http.MethodPost, "/admin/bans", req, &api_types.Response{Data: &ban}); err != nil {
+		return nil, err
+	}
+	return &ban, nil
+}
+
+// UnbanPeer lifts the ban of a peer.
+func (c *Client) UnbanPeer(ctx context.Context, peerID string) error {
+	return c.send(ctx, http.MethodDelete, "/admin/bans/"+url.PathEscape(peerID), nil, nil)
+}
+
+// DisconnectPeer closes the connections of the node to a peer.
+func (c *Client) DisconnectPeer(ctx context.Context, peerID string) error {
+	return c.send(ctx, http.MethodPost, "/admin/peers/"+url.PathEscape(peerID)+"/disconnect", nil, nil)
+}
+
+// Capabilities returns whether the node does each type of work, e.g. twitter or web.
+func (c *Client) Capabilities(ctx context.Context) (map[string]bool, error) {
+	var capabilities map[string]bool
+	if err := c.get(ctx, "/admin/capabilities", nil, &api_types.Response{Data: &capabilities}); err != nil {
+		return nil, err
+	}
+	return capabilities, nil
+}
+
+// SetCapability enables or disables a type of work on the node.
+func (c *Client) SetCapability(ctx context.Context, capability string, enabled bool) error {
+	return c.send(ctx, http.MethodPut, "/admin/capabilities/"+url.PathEscape(capability), api_types.CapabilityRequest{Enabled: &enabled}, nil)
+}
+
+// RefreshDHT refreshes the DHT routing table of the node and returns its size.
+func (c *Client) RefreshDHT(ctx context.Context) (int, error) {
+	var count api_types.PeerCount
+	if err := c.send(ctx, http.MethodPost, "/admin/dht/refresh", nil, &api_types.Response{Data: &count}); err != nil {
+		return 0, err
+	}
+	return count.Peers, nil
+}
+
+// ResyncNodeData makes the node exchange its node directory with its peers, and returns the
+// number of peers synced.
+func (c *Client) ResyncNodeData(ctx context.Context) (int, error) {
+	var count api_types.PeerCount
+	if err := c.send(ctx, http.MethodPost, "/admin/nodedata/resync", nil, &api_types.Response{Data: &count}); err != nil {
+		return 0, err
+	}
+	return count.Peers, nil
+}
+
+// RoutingTable returns the DHT routing table of the node.
+func (c *Client) RoutingTable(ctx context.Context) ([]api_types.RoutingTableEntry, error) {
+	var entries []api_types.RoutingTableEntry
+	if err := c.get(ctx, "/admin/routing", nil, &api_types.Response{Data: &entries}); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Connections returns the open libp2p connections of the node.
+func (c *Client) Connections(ctx context.Context) ([]api_types.ConnectionInfo, error) {
+	var connections []api_types.ConnectionInfo
+	if err := c.get(ctx, "/admin/connections", nil, &api_types.Response{Data: &connections}); err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
+// SetValidators replaces the validators allowed to produce blocks from the block numbered height
+// on. The version must be higher than the current one, and the node must be the governance peer,
+// since it signs the record.
+func (c *Client) SetValidators(ctx context.Context, version, height uint64, validators []string) error {
+	return c.send(ctx, http.MethodPost, "/admin/validators", api_types.ValidatorSet{Version: version, Height: height, Validators: validators}, nil)
+}
+
+// APIKeys lists the API keys issued by the node, without the keys themselves.
+func (c *Client) APIKeys(ctx context.Context) ([]apikey.Key, error) {
+	var keys []apikey.Key
+	if err := c.get(ctx, "/apikeys", nil, &api_types.Response{Data: &keys}); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// CreateAPIKey issues an API key. The returned token is the key itself, which can't be retrieved later.
+func (c *Client) CreateAPIKey(ctx context.Context, req api_types.APIKeyRequest) (*apikey.Key, string, error) {
+	var resp api_types.APIKeyResponse
+	if err := c.send(ctx, http.MethodPost, "/apikeys", req, &resp); err != nil {
+		return nil, "", err
+	}
+	return resp.Data, resp.Key, nil
+}
+
+// RevokeAPIKey revokes the API key with the given ID.
+func (c *Client) RevokeAPIKey(ctx context.Context, id string) (*apikey.Key, error) {
+	var key apikey.Key
+	if err := c.send(ctx, http.MethodDelete, "/apikeys/"+url.PathEscape(id), nil, &api_types.Response{Data: &key}); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Usage returns the work units used per API key, work type and day, with the limits of each key.
+func (c *Client) Usage(ctx context.Context, filter ratelimit.UsageFilter) (*api_types.UsageResponse, error) {
+	query := url.Values{}
+	setIfNotEmpty(query, "key", filter.KeyID)
+	setIfNotEmpty(query, "workType", filter.WorkType)
+	setIfNotEmpty(query, "from", filter.From)
+	setIfNotEmpty(query, "to", filter.To)
+	var resp api_types.UsageResponse
+	if err := c.get(ctx, "/usage", query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/chain"
+)
+
+// BlockRange selects a page of blocks by number. Zero values are left to the defaults of the node:
+// without From, the page ends with To, the last block if To is nil too.
+type BlockRange struct {
+	From  *uint64
+	To    *uint64
+	Limit int
+}
+
+// Blocks lists a page of the blocks of the chain, the last one first. Only validators serve the chain.
+func (c *Client) Blocks(ctx context.Context, page BlockRange) (*api_types.BlocksResponse, error) {
+	query := url.Values{}
+	if page.From != nil {
+		query.Set("from", strconv.FormatUint(*page.From, 10))
+	}
+	if page.To != nil {
+		query.Set("to", strconv.FormatUint(*page.To, 10))
+	}
+	if page.Limit > 0 {
+		query.Set("limit", strconv.Itoa(page.Limit))
+	}
+	var resp api_types.BlocksResponse
+	if err := c.get(ctx, "/blocks", query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Block returns the block with the given hex encoded hash.
+func (c *Client) Block(ctx context.Context, hash string) (*api_types.BlockData, error) {
+	var block api_types.BlockData
+	if err := c.get(ctx, "/blocks/"+url.PathEscape(hash), nil, &block); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// Certificate returns the finality certificate of the block with the given hex encoded hash, with
+// the votes of the validators that finalized it, which chain.Certificate.Verify checks.
+// Blocks finalized along with a descendant have no certificate: the Error has StatusCode 404.
+func (c *Client) Certificate(ctx context.Context, hash string) (*chain.Certificate, error) {
+	var certificate chain.Certificate
+	if err := c.get(ctx, "/blocks/"+url.PathEscape(hash)+"/certificate", nil, &api_types.Response{Data: &certificate}); err != nil {
+		return nil, err
+	}
+	return &certificate, nil
+}
+
+// PublishRecords batches records into one block built by the node, which must be a validator.
+// The CIDs of the records in the response retrieve their inclusion proofs, see RecordProof.
+func (c *Client) PublishRecords(ctx context.Context, records []json.RawMessage) (*api_types.RecordsBlock, error) {
+	var block api_types.RecordsBlock
+	if err := c.send(ctx, http.MethodPost, "/admin/blocks", api_types.RecordsRequest{Records: records}, &api_types.Response{Data: &block}); err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// InclusionProof returns the inclusion proof of the record of a block at the given index. It is
+// checked against the block hash with chain.VerifyInclusionProof.
+func (c *Client) InclusionProof(ctx context.Context, hash string, index int) (*chain.InclusionProof, error) {
+	var proof chain.InclusionProof
+	path := "/blocks/" + url.PathEscape(hash) + "/records/" + strconv.Itoa(index) + "/proof"
+	if err := c.get(ctx, path, nil, &api_types.Response{Data: &proof}); err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
+// RecordProof returns the inclusion proof of the record of the chain with the given CID.
+func (c *Client) RecordProof(ctx context.Context, cid string) (*chain.InclusionProof, error) {
+	var proof chain.InclusionProof
+	if err := c.get(ctx, "/records/"+url.PathEscape(cid)+"/proof", nil, &api_types.Response{Data: &proof}); err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
+// Validators returns the validators allowed to produce blocks.
+func (c *Client) Validators(ctx context.Context) (*api_types.ValidatorSet, error) {
+	var validators api_types.ValidatorSet
+	if err := c.get(ctx, "/validators", nil, &api_types.Response{Data: &validators}); err != nil {
+		return nil, err
+	}
+	return &validators, nil
+}
+
+// ChainStatus returns the last block and the last finalized block of the chain.
+func (c *Client) ChainStatus(ctx context.Context) (*api_types.ChainStatus, error) {
+	var status api_types.ChainStatus
+	if err := c.get(ctx, "/chain", nil, &api_types.Response{Data: &status}); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ChainSync returns the progress of the sync of the chain from the peers of the node.
+func (c *Client) ChainSync(ctx context.Context) (*api_types.SyncStatus, error) {
+	var status api_types.SyncStatus
+	if err := c.get(ctx, "/chain/sync", nil, &api_types.Response{Data: &status}); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
@@ -0,0 +1,267 @@
+// Package client is a Go client for the REST API of a Masa node.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+)
+
+const (
+	// DefaultMaxAttempts is the number of times a request is sent before its error is returned.
+	DefaultMaxAttempts = 3
+	DefaultMinBackoff  = 500 * time.Millisecond
+	DefaultMaxBackoff  = 10 * time.Second
+
+	// IdempotencyKeyHeader is the header of the key that makes the node replay the response of a retried request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	apiPrefix = "/api/v1"
+)
+
+// idempotentPaths are the POST routes the node deduplicates by Idempotency-Key, see API.idempotent.
+var idempotentPaths = map[string]bool{
+	apiPrefix + "/data/twitter/tweets/recent": true,
+	apiPrefix + "/data/web":                   true,
+	apiPrefix + "/dht":                        true,
+}
+
+// RetryPolicy controls how requests failing with a retryable error (see Error.Retryable) or a
+// network error are retried. POST requests the node doesn't deduplicate are only retried when
+// they were rate limited. The backoff doubles on each attempt, with jitter, unless the node asks
+// to wait for a given time with Retry-After.
+type RetryPolicy struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Client calls the REST API of a node. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	apiKey     string
+	userAgent  string
+	retry      RetryPolicy
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithAPIKey authenticates the requests with the given API key.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithHTTPClient sends the requests with the given HTTP client, e.g. one configured with a
+// client certificate for nodes requiring mutual TLS.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetryPolicy replaces the default retry policy. A MaxAttempts of 1 disables retries.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New creates a client for the node API served at baseURL, e.g. "http://localhost:8080".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: the scheme must be http or https", baseURL)
+	}
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{},
+		userAgent:  "masa-oracle-client",
+		retry: RetryPolicy{
+			MaxAttempts: DefaultMaxAttempts,
+			MinBackoff:  DefaultMinBackoff,
+			MaxBackoff:  DefaultMaxBackoff,
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.retry.MaxAttempts < 1 {
+		c.retry.MaxAttempts = 1
+	}
+	return c, nil
+}
+
+// get sends a GET request to an /api/v1 path and decodes the response into out.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	return c.do(ctx, http.MethodGet, apiPrefix+path, query, nil, nil, out)
+}
+
+// send sends a request with a JSON body to an /api/v1 path and decodes the response into out.
+func (c *Client) send(ctx context.Context, method, path string, body, out interface{}) error {
+	return c.do(ctx, method, apiPrefix+path, nil, nil, body, out)
+}
+
+// do sends a request, retrying it according to the retry policy, and decodes the response into out.
+// POST requests to the routes the node deduplicates are sent with an Idempotency-Key, generated
+// unless given in header, so that the node doesn't do the work again when they are retried. Other
+// POST requests are only retried when they were rate limited, since the node then rejects them
+// before doing anything.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body, out interface{}) error {
+	idempotent := method != http.MethodPost || idempotentPaths[path]
+	if method == http.MethodPost && idempotentPaths[path] && header.Get(IdempotencyKeyHeader) == "" {
+		header = header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		header.Set(IdempotencyKeyHeader, uuid.New().String())
+	}
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("error encoding the request: %w", err)
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		status, respHeader, data, err := c.roundTrip(ctx, method, path, query, header, payload)
+		if err == nil && status >= http.StatusBadRequest {
+			err = newError(status, respHeader, data)
+		}
+		if err == nil {
+			if out == nil {
+				return nil
+			}
+			if err := json.Unmarshal(data, out); err != nil {
+				return fmt.Errorf("error decoding the response: %w", err)
+			}
+			return nil
+		}
+		if attempt >= c.retry.MaxAttempts || !c.shouldRetry(ctx, err, idempotent) {
+			return err
+		}
+		select {
+		case <-time.After(c.backoff(attempt, err)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// roundTrip sends a request once and reads the whole response.
+func (c *Client) roundTrip(ctx context.Context, method, path string, query url.Values, header http.Header, payload []byte) (int, http.Header, []byte, error) {
+	req, err := c.newRequest(ctx, method, path, query, header, payload)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("error reading the response: %w", err)
+	}
+	return resp.StatusCode, resp.Header, data, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, header http.Header, payload []byte) (*http.Request, error) {
+	u := *c.baseURL
+	u.Path += path
+	u.RawQuery = query.Encode()
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	// Let the node join the trace of the caller, if any
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return req, nil
+}
+
+// shouldRetry reports whether a failed request is worth sending again: the node returned a
+// retryable error, or the request failed before getting a response. Requests that aren't
+// idempotent are only retried when they were rate limited.
+func (c *Client) shouldRetry(ctx context.Context, err error, idempotent bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		if !idempotent {
+			return apiErr.Code == api_types.CodeRateLimited
+		}
+		return apiErr.Retryable()
+	}
+	return idempotent
+}
+
+func (c *Client) backoff(attempt int, err error) time.Duration {
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return min(apiErr.RetryAfter, c.retry.MaxBackoff)
+	}
+	backoff := c.retry.MinBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > c.retry.MaxBackoff {
+		backoff = c.retry.MaxBackoff
+	}
+	// Full jitter over the upper half, so that clients rejected together don't retry together
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// retryAfter parses a Retry-After header given in seconds.
+func retryAfter(header http.Header) time.Duration {
+	seconds, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/masa-finance/masa-oracle/pkg/api"
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/health"
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+)
+
+var fastRetries = WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond})
+
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c, err := New(server.URL, append([]Option{fastRetries}, opts...)...)
+	require.NoError(t, err)
+	return c
+}
+
+func TestRetry(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("retryable errors", func(t *testing.T) {
+		var attempts atomic.Int32
+		c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			switch attempts.Add(1) {
+			case 1:
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = io.WriteString(w, `{"error":"Rate limit exceeded","code":"rate_limited"}`)
+			case 2:
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = io.WriteString(w, `{"error":"No available workers to process the request","code":"no_workers"}`)
+			default:
+				_, _ = io.WriteString(w, `{"success":true,"data":[{"peerId":"peer1"}],"totalCount":1}`)
+			}
+		}), WithAPIKey("secret"))
+
+		peers, err := c.Peers(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []api_types.Peer{{PeerId: "peer1"}}, peers)
+		assert.EqualValues(t, 3, attempts.Load())
+	})
+
+	t.Run("non-retryable errors", func(t *testing.T) {
+		var attempts atomic.Int32
+		c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = io.WriteString(w, `{"error":"Daily quota of 10 web work units exceeded","code":"quota_exceeded"}`)
+		}))
+
+		_, err := c.Peers(context.Background())
+		assert.True(t, IsCode(err, api_types.CodeQuotaExceeded))
+		assert.EqualValues(t, 1, attempts.Load())
+	})
+
+	t.Run("retries reuse the idempotency key", func(t *testing.T) {
+		var keys []string
+		c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+			if len(keys) == 1 {
+				w.WriteHeader(http.StatusGatewayTimeout)
+				return
+			}
+			_, _ = io.WriteString(w, `{"success":true,"message":"key"}`)
+		}))
+
+		require.NoError(t, c.PutDHT(context.Background(), "key", "value"))
+		require.Len(t, keys, 2)
+		assert.NotEmpty(t, keys[0])
+		assert.Equal(t, keys[0], keys[1])
+	})
+
+	t.Run("other POST requests are only retried when rate limited", func(t *testing.T) {
+		var attempts atomic.Int32
+		c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.Header.Get(IdempotencyKeyHeader))
+			switch attempts.Add(1) {
+			case 1:
+				w.WriteHeader(http.StatusTooManyRequests)
+				_, _ = io.WriteString(w, `{"error":"Rate limit exceeded","code":"rate_limited"}`)
+			default:
+				w.WriteHeader(http.StatusGatewayTimeout)
+			}
+		}))
+
+		err := c.PostToTopic(context.Background(), "chat", "hello")
+		assert.True(t, IsCode(err, api_types.CodeTimeout))
+		assert.EqualValues(t, 2, attempts.Load())
+	})
+
+	t.Run("errors without a code", func(t *testing.T) {
+		c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"success":false,"message":"Node not found"}`)
+		}))
+
+		_, err := c.Node(context.Background(), "peer1")
+		var apiErr *Error
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, api_types.CodeNotFound, apiErr.Code)
+		assert.Equal(t, "Node not found", apiErr.Message)
+	})
+}
+
+func TestStreamTopic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/topic/:name/stream", func(c *gin.Context) {
+		c.SSEvent("ping", time.Now().UTC())
+		c.SSEvent("message", pubsub.StreamMessage{Topic: c.Param("name"), From: "peer1", Data: "hello"})
+		c.SSEvent("message", pubsub.StreamMessage{Topic: c.Param("name"), From: "peer2", Data: "world", Dropped: 2})
+	})
+	c := newTestClient(t, router)
+
+	stream, err := c.StreamTopic(context.Background(), "chat", 0)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	msg, err := stream.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "chat", msg.Topic)
+	assert.Equal(t, "hello", msg.Data)
+	msg, err = stream.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "world", msg.Data)
+	assert.EqualValues(t, 2, msg.Dropped)
+	_, err = stream.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestClientWithServer(t *testing.T) {
+	readiness := health.NewChecker(time.Second)
+	readiness.Register("dht", true, func(context.Context) error { return assert.AnError })
+	router := api.SetupRoutes(nil, nil, nil, api.WithHealthCheckers(nil, readiness))
+	c := newTestClient(t, router)
+
+	report, err := c.Liveness(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, health.StatusUp, report.Status)
+
+	report, err = c.Readiness(context.Background())
+	assert.True(t, IsCode(err, api_types.CodeUnavailable))
+	require.NotNil(t, report)
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, assert.AnError.Error(), report.Components[0].LastError)
+
+	_, err = c.LogLevel(context.Background())
+	assert.True(t, IsCode(err, api_types.CodeForbidden), "admin routes require authentication")
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
+)
+
+// DataOptions are the per-request options of the data requests.
+type DataOptions struct {
+	// Sealing selects sealed, unsealed or both results. Empty uses the node's default.
+	Sealing data_types.SealingMode
+	// TeeEndpoint is the name of the tee endpoint, configured on the node, used to unseal the result.
+	TeeEndpoint string
+	// IdempotencyKey identifies a POST request across its retries, so that the node returns the original
+	// response instead of doing the work again. A key is generated for each call if empty.
+	IdempotencyKey string
+}
+
+func (o *DataOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if o.Sealing != "" {
+		query.Set("sealing", string(o.Sealing))
+	}
+	if o.TeeEndpoint != "" {
+		query.Set("teeEndpoint", o.TeeEndpoint)
+	}
+	return query
+}
+
+func (o *DataOptions) header() http.Header {
+	if o == nil || o.IdempotencyKey == "" {
+		return nil
+	}
+	return http.Header{IdempotencyKeyHeader: {o.IdempotencyKey}}
+}
+
+// TwitterProfile retrieves the profile of a Twitter user.
+func (c *Client) TwitterProfile(ctx context.Context, username string, opts *DataOptions) (*data_types.WorkResponse, error) {
+	var resp data_types.WorkResponse
+	if err := c.get(ctx, "/data/twitter/profile/"+url.PathEscape(username), opts.query(), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// TwitterFollowers retrieves the followers of a Twitter user.
+func (c *Client) TwitterFollowers(ctx context.Context, username string, opts *DataOptions) (*data_types.WorkResponse, error) {
+	var resp data_types.WorkResponse
+	if err := c.get(ctx, "/data/twitter/followers/"+url.PathEscape(username), opts.query(), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RecentTweets searches recent tweets with the Twitter advanced search syntax.
+func (c *Client) RecentTweets(ctx context.Context, query string, count int, opts *DataOptions) (*data_types.WorkResponse, error) {
+	var resp data_types.WorkResponse
+	body := api_types.RecentTweetsRequest{Query: query, Count: count}
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/data/twitter/tweets/recent", opts.query(), opts.header(), body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// WebData scrapes a web page.
+func (c *Client) WebData(ctx context.Context, request data_types.WebRequest, opts *DataOptions) (*data_types.WorkResponse, error) {
+	var resp data_types.WorkResponse
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/data/web", opts.query(), opts.header(), request, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
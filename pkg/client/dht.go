@@ -0,0 +1,23 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+)
+
+// GetDHT reads the value stored in the DHT under a key. JSON values are returned decoded.
+func (c *Client) GetDHT(ctx context.Context, key string) (interface{}, error) {
+	var resp api_types.DHTResponse
+	if err := c.get(ctx, "/dht", url.Values{"key": {key}}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Message, nil
+}
+
+// PutDHT stores a value, JSON encoded, in the DHT under a key.
+func (c *Client) PutDHT(ctx context.Context, key string, value interface{}) error {
+	return c.send(ctx, http.MethodPost, "/dht", api_types.DHTRequest{Key: key, Value: value}, nil)
+}
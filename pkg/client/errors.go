@@ -0,0 +1,70 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+)
+
+// Error is an error response of the node API.
+type Error struct {
+	StatusCode int
+	Code       api_types.ErrorCode
+	Message    string
+	// Details is the error reported by the worker, for failed data requests.
+	Details      string
+	WorkerPeerId string
+	// RetryAfter is the time the node asked to wait before retrying, if any.
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	message := fmt.Sprintf("masa api: %d %s: %s", e.StatusCode, e.Code, e.Message)
+	if e.Details != "" {
+		message += ": " + e.Details
+	}
+	return message
+}
+
+// Retryable reports whether the request may succeed if sent again: the caller was rate limited,
+// no worker was available, or the node timed out or isn't ready. Exhausted daily quotas and
+// rate limits of the data sources are not retried, since they last longer than a retry policy.
+func (e *Error) Retryable() bool {
+	switch e.Code {
+	case api_types.CodeRateLimited, api_types.CodeNoWorkers, api_types.CodeTimeout, api_types.CodeUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsCode reports whether err is an API error with the given code.
+func IsCode(err error, code api_types.ErrorCode) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+func newError(status int, header http.Header, body []byte) *Error {
+	e := &Error{StatusCode: status, RetryAfter: retryAfter(header)}
+	var resp api_types.ErrorResponse
+	if json.Unmarshal(body, &resp) == nil {
+		e.Code = resp.Code
+		e.Message = resp.Error
+		e.Details = resp.Details
+		e.WorkerPeerId = resp.WorkerPeerId
+		if e.Message == "" {
+			e.Message = resp.Message
+		}
+	}
+	if e.Code == "" {
+		e.Code = api_types.CodeForStatus(status)
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(status)
+	}
+	return e
+}
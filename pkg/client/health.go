@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/health"
+)
+
+// Liveness runs the liveness checks of the node. When a critical component is down, the report
+// is returned along with an error.
+func (c *Client) Liveness(ctx context.Context) (*health.Report, error) {
+	return c.healthReport(ctx, "/healthz")
+}
+
+// Readiness runs the readiness checks of the node, which fail while it can't serve data
+// requests. When a critical component is down, the report is returned along with an error.
+func (c *Client) Readiness(ctx context.Context) (*health.Report, error) {
+	return c.healthReport(ctx, "/readyz")
+}
+
+// healthReport requests a health report once, since a failing check is an answer rather than an error to retry.
+func (c *Client) healthReport(ctx context.Context, path string) (*health.Report, error) {
+	status, header, data, err := c.roundTrip(ctx, http.MethodGet, path, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var report health.Report
+	if err := json.Unmarshal(data, &api_types.Response{Data: &report}); err != nil {
+		if status >= http.StatusBadRequest {
+			return nil, newError(status, header, data)
+		}
+		return nil, fmt.Errorf("error decoding the response: %w", err)
+	}
+	if status >= http.StatusBadRequest {
+		return &report, newError(status, header, data)
+	}
+	return &report, nil
+}
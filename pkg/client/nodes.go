@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/audit"
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+	"github.com/masa-finance/masa-oracle/pkg/workers"
+)
+
+// NodeQuery filters, sorts and pages the node directory. Zero values don't filter.
+type NodeQuery struct {
+	Staked         *bool
+	Active         *bool
+	Validator      *bool
+	Capability     string // work type, e.g. twitter or web
+	Version        string
+	MinUptime      time.Duration
+	MinReliability float64
+	Sort           string // e.g. peerId, uptime or reliability
+	Descending     bool
+	Limit          int
+	Cursor         string   // NextCursor of the previous page
+	Fields         []string // fields returned for each node, all of them if empty
+}
+
+func (q NodeQuery) values() url.Values {
+	query := url.Values{}
+	for name, filter := range map[string]*bool{"staked": q.Staked, "active": q.Active, "validator": q.Validator} {
+		if filter != nil {
+			query.Set(name, strconv.FormatBool(*filter))
+		}
+	}
+	setIfNotEmpty(query, "capability", q.Capability)
+	setIfNotEmpty(query, "version", q.Version)
+	setIfNotEmpty(query, "sort", q.Sort)
+	setIfNotEmpty(query, "cursor", q.Cursor)
+	setIfNotEmpty(query, "fields", strings.Join(q.Fields, ","))
+	if q.MinUptime > 0 {
+		query.Set("minUptime", q.MinUptime.String())
+	}
+	if q.MinReliability > 0 {
+		query.Set("minReliability", strconv.FormatFloat(q.MinReliability, 'f', -1, 64))
+	}
+	if q.Descending {
+		query.Set("order", "desc")
+	}
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+	return query
+}
+
+func setIfNotEmpty(query url.Values, name, value string) {
+	if value != "" {
+		query.Set(name, value)
+	}
+}
+
+// Peers lists the peers known to the node.
+func (c *Client) Peers(ctx context.Context) ([]api_types.Peer, error) {
+	var peers []api_types.Peer
+	if err := c.get(ctx, "/peers", nil, &api_types.PeersResponse{Data: &peers}); err != nil {
+		return nil, err
+	}
+	return peers, nil
+}
+
+// PeerAddresses lists the peers known to the node with their addresses.
+func (c *Client) PeerAddresses(ctx context.Context) ([]api_types.PeerAddresses, error) {
+	var peers []api_types.PeerAddresses
+	if err := c.get(ctx, "/peer/addresses", nil, &api_types.PeersResponse{Data: &peers}); err != nil {
+		return nil, err
+	}
+	return peers, nil
+}
+
+// NodeData returns a page of the node data known to the node. Pages start at 0.
+func (c *Client) NodeData(ctx context.Context, pageNbr, pageSize int) (*api_types.NodeDataPageResponse, error) {
+	query := url.Values{"pageNbr": {strconv.Itoa(pageNbr)}}
+	if pageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(pageSize))
+	}
+	var resp api_types.NodeDataPageResponse
+	if err := c.get(ctx, "/node/data", query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Node returns the node data of a peer.
+func (c *Client) Node(ctx context.Context, peerID string) (*pubsub.NodeData, error) {
+	var nodeData pubsub.NodeData
+	if err := c.get(ctx, "/node/data/"+url.PathEscape(peerID), nil, &api_types.Response{Data: &nodeData}); err != nil {
+		return nil, err
+	}
+	return &nodeData, nil
+}
+
+// QueryNodes queries the node directory. The next page is requested with the NextCursor of the response.
+func (c *Client) QueryNodes(ctx context.Context, query NodeQuery) (*api_types.NodesResponse, error) {
+	var resp api_types.NodesResponse
+	if err := c.get(ctx, "/nodes", query.values(), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateNodeStatus publishes node data on the node gossip topic.
+func (c *Client) UpdateNodeStatus(ctx context.Context, nodeData pubsub.NodeData) error {
+	return c.send(ctx, http.MethodPost, "/node/status", nodeData, nil)
+}
+
+// WorkerDiagnostics returns the eligible workers per category with their latency, and the
+// metrics of the local work handlers.
+func (c *Client) WorkerDiagnostics(ctx context.Context) (*workers.WorkerDiagnostics, error) {
+	var diagnostics workers.WorkerDiagnostics
+	if err := c.get(ctx, "/workers/diagnostics", nil, &api_types.Response{Data: &diagnostics}); err != nil {
+		return nil, err
+	}
+	return &diagnostics, nil
+}
+
+// AuditLog queries the audit log of the work requests the node originated or executed.
+func (c *Client) AuditLog(ctx context.Context, filter audit.Filter) ([]audit.WorkEvent, error) {
+	query := url.Values{}
+	if !filter.From.IsZero() {
+		query.Set("from", filter.From.Format(time.RFC3339))
+	}
+	if !filter.To.IsZero() {
+		query.Set("to", filter.To.Format(time.RFC3339))
+	}
+	setIfNotEmpty(query, "peer", filter.PeerId)
+	setIfNotEmpty(query, "workType", filter.WorkType)
+	setIfNotEmpty(query, "outcome", string(filter.Outcome))
+	setIfNotEmpty(query, "role", string(filter.Role))
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	var events []audit.WorkEvent
+	if err := c.get(ctx, "/audit", query, &api_types.Response{Data: &events}); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// PublicKeys lists the public keys published by the nodes.
+func (c *Client) PublicKeys(ctx context.Context) ([]pubsub.PublicKeyMessage, error) {
+	var resp api_types.PublicKeysResponse
+	if err := c.get(ctx, "/publickeys", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.PublicKeys, nil
+}
+
+// PublishPublicKey makes the node publish its signed public key.
+func (c *Client) PublishPublicKey(ctx context.Context) error {
+	return c.send(ctx, http.MethodPost, "/publickey/publish", nil, nil)
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	api_types "github.com/masa-finance/masa-oracle/pkg/api/types"
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+)
+
+// CreateTopic creates a topic and subscribes the node to it.
+func (c *Client) CreateTopic(ctx context.Context, name string) error {
+	return c.send(ctx, http.MethodPost, "/topic/create", api_types.TopicRequest{TopicName: name}, nil)
+}
+
+// PostToTopic publishes a message on a topic.
+func (c *Client) PostToTopic(ctx context.Context, name, message string) error {
+	return c.send(ctx, http.MethodPost, "/topic/post", api_types.TopicRequest{TopicName: name, Message: message}, nil)
+}
+
+// TopicStream is a stream of the messages of a topic, read as server-sent events.
+type TopicStream struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+}
+
+// StreamTopic streams the messages of a topic until the context is cancelled or the stream is
+// closed. The buffer is the number of messages the node buffers for the stream, 0 for the default.
+func (c *Client) StreamTopic(ctx context.Context, name string, buffer int) (*TopicStream, error) {
+	query := url.Values{}
+	if buffer > 0 {
+		query.Set("buffer", strconv.Itoa(buffer))
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, apiPrefix+"/topic/"+url.PathEscape(name)+"/stream", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return nil, newError(resp.StatusCode, resp.Header, data)
+	}
+	return &TopicStream{body: resp.Body, reader: bufio.NewReader(resp.Body)}, nil
+}
+
+// Next blocks until the next message of the topic is received. It returns io.EOF once the
+// stream has ended. Heartbeats are skipped.
+func (s *TopicStream) Next() (*pubsub.StreamMessage, error) {
+	for {
+		event, data, err := s.readEvent()
+		if err != nil {
+			return nil, err
+		}
+		if event != "message" {
+			continue
+		}
+		var msg pubsub.StreamMessage
+		if err := json.Unmarshal([]byte(data), &msg); err != nil {
+			return nil, fmt.Errorf("error decoding the topic message: %w", err)
+		}
+		return &msg, nil
+	}
+}
+
+// Close ends the stream.
+func (s *TopicStream) Close() error {
+	return s.body.Close()
+}
+
+// readEvent reads a server-sent event, returning its type and data.
+func (s *TopicStream) readEvent() (string, string, error) {
+	var event string
+	var data []string
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return "", "", err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if data == nil {
+				continue
+			}
+			return event, strings.Join(data, "\n"), nil
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+}
@@ -59,6 +59,67 @@ type AppConfig struct {
 	TelegramScraper    bool   `mapstructure:"telegramScraper"`
 	WebScraper         bool   `mapstructure:"webScraper"`
 	APIEnabled         bool   `mapstructure:"api_enabled"`
+	WorkerMaxRTT       string `mapstructure:"workerMaxRtt"`
+
+	Attestation             string `mapstructure:"attestation"`
+	AttestationVerifier     string `mapstructure:"attestationVerifier"`
+	AttestationMeasurements string `mapstructure:"attestationMeasurements"`
+	RequireAttestation      bool   `mapstructure:"requireAttestation"`
+	AttestationRootCA       string `mapstructure:"attestationRootCa"`
+	AttestationDev          bool   `mapstructure:"attestationDev"`
+
+	// Content policy of the web scraper worker
+	WebAllowDomains    string `mapstructure:"webAllowDomains"`
+	WebDenyDomains     string `mapstructure:"webDenyDomains"`
+	WebAllowNetworks   string `mapstructure:"webAllowNetworks"`
+	WebDenyNetworks    string `mapstructure:"webDenyNetworks"`
+	WebAllowPrivate    bool   `mapstructure:"webAllowPrivateNetworks"`
+	WebRespectRobots   bool   `mapstructure:"webRespectRobots"`
+	WebMaxResponseSize int    `mapstructure:"webMaxResponseSize"`
+
+	AuditRetention string `mapstructure:"auditRetention"`
+
+	// API key authentication, and key management from the command line
+	APIAuth       bool   `mapstructure:"apiAuth"`
+	APIKeyCreate  string `mapstructure:"apikeyCreate"`
+	APIKeyScopes  string `mapstructure:"apikeyScopes"`
+	APIKeyExpires string `mapstructure:"apikeyExpires"`
+	APIKeyRevoke  string `mapstructure:"apikeyRevoke"`
+	APIKeyList    bool   `mapstructure:"apikeyList"`
+
+	// Per API key limits, zero means no limit
+	APIRateLimit     float64 `mapstructure:"apiRateLimit"`
+	APIRateBurst     int     `mapstructure:"apiRateBurst"`
+	APIMaxConcurrent int     `mapstructure:"apiMaxConcurrent"`
+	APIDailyUnits    string  `mapstructure:"apiDailyUnits"`
+
+	// How long responses are replayed to requests retried with the same Idempotency-Key, 0 to disable
+	APIIdempotency string `mapstructure:"apiIdempotencyWindow"`
+
+	// Address of the gRPC API, which is disabled if empty
+	GRPCListenAddress string `mapstructure:"grpcListenAddress"`
+
+	// TLS for the REST and gRPC APIs. Without a certificate and key, a self-signed pair is generated in MasaDir.
+	// A client CA bundle requires client certificates, whose identities can be mapped to scopes.
+	APITLS          bool   `mapstructure:"apiTls"`
+	APITLSCert      string `mapstructure:"apiTlsCert"`
+	APITLSKey       string `mapstructure:"apiTlsKey"`
+	APIClientCA     string `mapstructure:"apiTlsClientCa"`
+	APIClientScopes string `mapstructure:"apiTlsClientScopes"`
+
+	// How long in-flight requests are given to complete when the node shuts down
+	ShutdownTimeout string `mapstructure:"shutdownTimeout"`
+
+	// OTLP gRPC collector the trace spans are exported to, spans aren't recorded if empty
+	TracingEndpoint string `mapstructure:"tracingOtlpEndpoint"`
+	TracingInsecure bool   `mapstructure:"tracingOtlpInsecure"`
+
+	// Comma-separated peer IDs of the validators allowed to produce blocks, only this node if empty.
+	// The governance peer can replace them with signed validator set records.
+	ValidatorSet        string `mapstructure:"validatorSet"`
+	ValidatorGovernance string `mapstructure:"validatorGovernancePeer"`
+	// Convert the chain stored by an older version to the current block encoding, then exit
+	MigrateChain bool `mapstructure:"migrateChain"`
 
 	KeyManager   *masacrypto.KeyManager
 	TelegramStop bg.StopFunc
@@ -130,6 +191,10 @@ func (c *AppConfig) setDefaultConfig() {
 	viper.SetDefault(PrivKeyFile, DefaultPrivKeyFile)
 
 	viper.SetDefault(APIEnabled, false)
+	viper.SetDefault(APIAuth, true)
+	viper.SetDefault(AuditRetention, "720h")
+	viper.SetDefault(ShutdownTimeout, "30s")
+	viper.SetDefault(APIIdempotency, "24h")
 }
 
 // setFileConfig loads configuration from a YAML file.
@@ -181,6 +246,9 @@ func (c *AppConfig) setCommandLineConfig() error {
 	pflag.StringVar(&c.LogFilePath, "logFilePath", viper.GetString(LogFilePath), "The log file path")
 	pflag.StringVar(&c.FilePath, "filePath", viper.GetString(FilePath), "The node file path")
 	pflag.BoolVar(&c.Validator, "validator", viper.GetBool(Validator), "Approved validator node boolean")
+	pflag.StringVar(&c.ValidatorSet, "validatorSet", viper.GetString(ValidatorSet), "Comma-separated peer IDs of the validators allowed to produce blocks (only this node if empty)")
+	pflag.StringVar(&c.ValidatorGovernance, "validatorGovernancePeer", viper.GetString(ValidatorGovernance), "Peer ID allowed to change the validator set with signed records")
+	pflag.BoolVar(&c.MigrateChain, "migrateChain", false, "Convert the chain in the masa directory to the current block encoding, verify it and exit")
 	pflag.StringVar(&c.CachePath, "cachePath", viper.GetString(CachePath), "The cache path")
 	pflag.StringVar(&c.TwitterUsername, "twitterUsername", viper.GetString(TwitterUsername), "Twitter Username")
 	pflag.StringVar(&c.TwitterPassword, "twitterPassword", viper.GetString(TwitterPassword), "Twitter Password")
@@ -193,6 +261,41 @@ func (c *AppConfig) setCommandLineConfig() error {
 	pflag.BoolVar(&c.Faucet, "faucet", viper.GetBool(Faucet), "Faucet")
 	pflag.BoolVar(&c.APIEnabled, "api-enabled", viper.GetBool(APIEnabled), "Enable API server")
 	pflag.StringVar(&c.APIListenAddress, "api-port", viper.GetString(APIListenAddress), "API Listening address")
+	pflag.BoolVar(&c.APIAuth, "apiAuth", viper.GetBool(APIAuth), "Require an API key on every API request")
+	pflag.StringVar(&c.APIKeyCreate, "apikeyCreate", "", "Issue an API key with the given label and exit")
+	pflag.StringVar(&c.APIKeyScopes, "apikeyScopes", "data:read", "Comma-separated scopes of the issued API key: data:read, dht:write, topics:publish, admin")
+	pflag.StringVar(&c.APIKeyExpires, "apikeyExpires", "", "Lifetime of the issued API key, e.g. 720h (never expires if empty)")
+	pflag.StringVar(&c.APIKeyRevoke, "apikeyRevoke", "", "Revoke the API key with the given ID and exit")
+	pflag.BoolVar(&c.APIKeyList, "apikeyList", false, "List the issued API keys and exit")
+	pflag.Float64Var(&c.APIRateLimit, "apiRateLimit", viper.GetFloat64(APIRateLimit), "Requests per second allowed for each API key (0 for no limit)")
+	pflag.IntVar(&c.APIRateBurst, "apiRateBurst", viper.GetInt(APIRateBurst), "Requests each API key can burst above its rate limit")
+	pflag.IntVar(&c.APIMaxConcurrent, "apiMaxConcurrent", viper.GetInt(APIMaxConcurrent), "Concurrent requests allowed for each API key (0 for no limit)")
+	pflag.StringVar(&c.APIDailyUnits, "apiDailyUnits", viper.GetString(APIDailyUnits), "Daily work units allowed for each API key per work type, e.g. twitter=1000,web=200")
+	pflag.StringVar(&c.APIIdempotency, "apiIdempotencyWindow", viper.GetString(APIIdempotency), "How long responses are replayed to requests retried with the same Idempotency-Key, e.g. 24h (0 to disable)")
+	pflag.StringVar(&c.GRPCListenAddress, "grpcListenAddress", viper.GetString(GRPCListenAddress), "gRPC API listening address, e.g. 127.0.0.1:9090 (disabled if empty)")
+	pflag.BoolVar(&c.APITLS, "apiTls", viper.GetBool(APITLS), "Serve the API over TLS")
+	pflag.StringVar(&c.APITLSCert, "apiTlsCert", viper.GetString(APITLSCert), "API certificate file (a self-signed certificate is generated if empty)")
+	pflag.StringVar(&c.APITLSKey, "apiTlsKey", viper.GetString(APITLSKey), "API private key file")
+	pflag.StringVar(&c.APIClientCA, "apiTlsClientCa", viper.GetString(APIClientCA), "CA bundle that API client certificates must be signed by (mutual TLS)")
+	pflag.StringVar(&c.APIClientScopes, "apiTlsClientScopes", viper.GetString(APIClientScopes), "Scopes granted to client certificate identities, e.g. dashboard=data:read;ops=admin")
+	pflag.StringVar(&c.ShutdownTimeout, "shutdownTimeout", viper.GetString(ShutdownTimeout), "How long in-flight API and work requests are given to complete on shutdown, e.g. 30s")
+	pflag.StringVar(&c.TracingEndpoint, "tracingOtlpEndpoint", viper.GetString(TracingEndpoint), "OTLP gRPC collector to export trace spans to, e.g. localhost:4317 (disabled if empty)")
+	pflag.BoolVar(&c.TracingInsecure, "tracingOtlpInsecure", viper.GetBool(TracingInsecure), "Connect to the OTLP collector without TLS")
+	pflag.StringVar(&c.WorkerMaxRTT, "workerMaxRtt", viper.GetString(MaxRTT), "Maximum round-trip time to remote workers per category, e.g. twitter=300ms,web=1s")
+	pflag.StringVar(&c.Attestation, "attestation", viper.GetString(Attestation), "Attach attestation reports to results: tee-worker, or software with attestationDev")
+	pflag.StringVar(&c.AttestationVerifier, "attestationVerifier", viper.GetString(AttestationVerifier), "Verifier used to check the attestation of results from remote workers: sgx-dcap, or software with attestationDev")
+	pflag.StringVar(&c.AttestationMeasurements, "attestationMeasurements", viper.GetString(AttestationMeasurements), "Comma-separated allowlist of enclave measurements")
+	pflag.BoolVar(&c.RequireAttestation, "requireAttestation", viper.GetBool(RequireAttestation), "Reject results from remote workers without a verified attestation")
+	pflag.StringVar(&c.AttestationRootCA, "attestationRootCa", viper.GetString(AttestationRootCA), "PEM file of the Intel SGX root CA certificate used by the sgx-dcap verifier")
+	pflag.BoolVar(&c.AttestationDev, "attestationDev", viper.GetBool(AttestationDev), "Allow the forgeable software attestation, for local development only")
+	pflag.StringVar(&c.WebAllowDomains, "webAllowDomains", viper.GetString(WebAllowDomains), "Comma-separated list of domains the web scraper may fetch (all if empty)")
+	pflag.StringVar(&c.WebDenyDomains, "webDenyDomains", viper.GetString(WebDenyDomains), "Comma-separated list of domains the web scraper must not fetch")
+	pflag.StringVar(&c.WebAllowNetworks, "webAllowNetworks", viper.GetString(WebAllowNetworks), "Comma-separated list of CIDRs the web scraper may connect to (all public if empty)")
+	pflag.StringVar(&c.WebDenyNetworks, "webDenyNetworks", viper.GetString(WebDenyNetworks), "Comma-separated list of CIDRs the web scraper must not connect to")
+	pflag.BoolVar(&c.WebAllowPrivate, "webAllowPrivateNetworks", viper.GetBool(WebAllowPrivate), "Allow the web scraper to fetch private, loopback and link-local addresses")
+	pflag.BoolVar(&c.WebRespectRobots, "webRespectRobots", viper.GetBool(WebRespectRobots), "Make the web scraper comply with robots.txt")
+	pflag.StringVar(&c.AuditRetention, "auditRetention", viper.GetString(AuditRetention), "How long work request audit records are kept, e.g. 720h (0 keeps them forever)")
+	pflag.IntVar(&c.WebMaxResponseSize, "webMaxResponseSize", viper.GetInt(WebMaxResponseSize), "Maximum size in bytes of a web scraper result (0 for no limit)")
 
 	pflag.Parse()
 
@@ -210,6 +313,15 @@ func (c *AppConfig) setCommandLineConfig() error {
 	return nil
 }
 
+// NodeDatastorePath returns the location of the node datastore, which backs the resolver cache and
+// keeps the API keys.
+func (c *AppConfig) NodeDatastorePath() string {
+	if c.CachePath == "" {
+		return c.MasaDir + "/cache"
+	}
+	return c.CachePath
+}
+
 // LogConfig logs the non-sensitive parts of the AppConfig.
 // Adjust the fields being logged according to your application's needs.
 func (c *AppConfig) LogConfig() {
@@ -15,7 +15,7 @@ var _ = Describe("InitOptions", func() {
 			TCP:             true,
 			Bootnodes:       []string{"boot1", "boot2"},
 			Environment:     "test",
-			MasaDir:         "dir",
+			MasaDir:         GinkgoT().TempDir(),
 			Validator:       true,
 			CachePath:       "cache",
 			TwitterScraper:  true,
@@ -57,7 +57,9 @@ var _ = Describe("InitOptions", func() {
 			CachePath:            conf.CachePath,
 			OracleProtocol:       OracleProtocol,
 			NodeDataSyncProtocol: NodeDataSyncProtocol,
+			ChainSyncProtocol:    ChainSyncProtocol,
 			NodeGossipTopic:      NodeGossipTopic,
+			NodeLifecycleTopic:   NodeLifecycleTopic,
 			Rendezvous:           Rendezvous,
 			WorkerProtocol:       WorkerProtocol,
 			PageSize:             PageSize,
@@ -66,6 +68,7 @@ var _ = Describe("InitOptions", func() {
 			Services:             actual.Services,
 			PubSubHandles:        actual.PubSubHandles,
 			MasaProtocolHandlers: actual.MasaProtocolHandlers,
+			BanList:              actual.BanList,
 		}
 
 		Expect(*actual).To(Equal(expected))
@@ -20,14 +20,39 @@ const (
 	Validator   = "VALIDATOR"
 	CachePath   = "CACHE_PATH"
 	Faucet      = "FAUCET"
+	MaxRTT      = "WORKER_MAX_RTT"
+
+	ValidatorSet        = "VALIDATOR_SET"
+	ValidatorGovernance = "VALIDATOR_GOVERNANCE_PEER"
+
+	Attestation             = "TEE_ATTESTATION"
+	AttestationVerifier     = "TEE_ATTESTATION_VERIFIER"
+	AttestationMeasurements = "TEE_MEASUREMENTS"
+	RequireAttestation      = "TEE_REQUIRE_ATTESTATION"
+	AttestationRootCA       = "TEE_SGX_ROOT_CA"
+	AttestationDev          = "TEE_ATTESTATION_DEV"
+
+	WebAllowDomains    = "WEB_ALLOW_DOMAINS"
+	WebDenyDomains     = "WEB_DENY_DOMAINS"
+	WebAllowNetworks   = "WEB_ALLOW_NETWORKS"
+	WebDenyNetworks    = "WEB_DENY_NETWORKS"
+	WebAllowPrivate    = "WEB_ALLOW_PRIVATE_NETWORKS"
+	WebRespectRobots   = "WEB_RESPECT_ROBOTS"
+	WebMaxResponseSize = "WEB_MAX_RESPONSE_SIZE"
+
+	AuditRetention = "AUDIT_RETENTION"
 
 	OracleProtocol       = "oracle_protocol"
 	WorkerProtocol       = "worker_protocol"
 	NodeDataSyncProtocol = "nodeDataSync"
+	ChainSyncProtocol    = "chainSync"
 	NodeGossipTopic      = "gossip"
+	NodeLifecycleTopic   = "nodeLifecycle"
 	PublicKeyTopic       = "bootNodePublicKey"
 	WorkerTopic          = "workerTopic"
 	BlockTopic           = "blockTopic"
+	ValidatorSetTopic    = "validatorSet"
+	BlockVoteTopic       = "blockVotes"
 	Rendezvous           = "masa-mdns"
 	PageSize             = 25
 
@@ -41,5 +66,20 @@ const (
 	WebScraper         = "WEB_SCRAPER"
 	APIEnabled         = "API_ENABLED"
 	APIListenAddress   = "API_LISTEN_ADDRESS"
+	APIAuth            = "API_AUTH_ENABLED"
+	APIRateLimit       = "API_RATE_LIMIT"
+	APIRateBurst       = "API_RATE_BURST"
+	APIMaxConcurrent   = "API_MAX_CONCURRENT"
+	APIDailyUnits      = "API_DAILY_UNITS"
+	GRPCListenAddress  = "GRPC_LISTEN_ADDRESS"
+	APITLS             = "API_TLS_ENABLED"
+	APITLSCert         = "API_TLS_CERT"
+	APITLSKey          = "API_TLS_KEY"
+	APIClientCA        = "API_TLS_CLIENT_CA"
+	APIClientScopes    = "API_TLS_CLIENT_SCOPES"
+	ShutdownTimeout    = "SHUTDOWN_TIMEOUT"
+	APIIdempotency     = "API_IDEMPOTENCY_WINDOW"
+	TracingEndpoint    = "TRACING_OTLP_ENDPOINT"
+	TracingInsecure    = "TRACING_OTLP_INSECURE"
 	DefaultPrivKeyFile = "masa_oracle_key"
 )
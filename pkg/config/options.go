@@ -1,15 +1,29 @@
 package config
 
 import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
 	"github.com/masa-finance/masa-oracle/node"
+	"github.com/masa-finance/masa-oracle/pkg/audit"
+	"github.com/masa-finance/masa-oracle/pkg/idempotency"
+	"github.com/masa-finance/masa-oracle/pkg/network"
 	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+	"github.com/masa-finance/masa-oracle/pkg/ratelimit"
+	"github.com/masa-finance/masa-oracle/pkg/tee"
 	"github.com/masa-finance/masa-oracle/pkg/workers"
+	"github.com/masa-finance/masa-oracle/pkg/workers/policy"
 )
 
 var constantOptions = []node.Option{
 	node.WithOracleProtocol(OracleProtocol),
 	node.WithNodeDataSyncProtocol(NodeDataSyncProtocol),
+	node.WithChainSyncProtocol(ChainSyncProtocol),
 	node.WithNodeGossipTopic(NodeGossipTopic),
+	node.WithNodeLifecycleTopic(NodeLifecycleTopic),
 	node.WithRendezvous(Rendezvous),
 	node.WithPageSize(PageSize),
 }
@@ -26,9 +40,67 @@ func InitOptions(cfg *AppConfig) ([]node.Option, *workers.WorkHandlerManager, *p
 		workers.WithMasaDir(cfg.MasaDir),
 	}
 
-	cachePath := cfg.CachePath
-	if cachePath == "" {
-		cachePath = cfg.MasaDir + "/cache"
+	maxLatencies, err := workers.ParseMaxLatencies(cfg.WorkerMaxRTT)
+	if err != nil {
+		logrus.Fatalf("[-] Invalid worker max RTT configuration: %v", err)
+	}
+	for category, d := range maxLatencies {
+		workerManagerOptions = append(workerManagerOptions, workers.WithMaxWorkerLatency(category, d))
+	}
+
+	switch cfg.Attestation {
+	case "":
+	case "tee-worker":
+		attester, err := tee.NewAttester()
+		if err != nil {
+			logrus.Fatalf("[-] Invalid tee-worker attestation: %v", err)
+		}
+		workerManagerOptions = append(workerManagerOptions, workers.WithAttester(attester))
+	case tee.SoftwareFormat:
+		if !cfg.AttestationDev {
+			logrus.Fatal("[-] Software attestation can be forged, it's only allowed with TEE_ATTESTATION_DEV=true")
+		}
+		logrus.Warn("[-] Using software attestation, results are not backed by an enclave")
+		workerManagerOptions = append(workerManagerOptions, workers.WithAttester(tee.SoftwareAttester{}))
+	default:
+		logrus.Fatalf("[-] Unknown attestation mode %q, use tee-worker", cfg.Attestation)
+	}
+
+	if cfg.AttestationVerifier != "" {
+		var verifier tee.Verifier
+		var err error
+		switch cfg.AttestationVerifier {
+		case tee.SGXFormat:
+			verifier, err = tee.LoadSGXVerifier(cfg.AttestationRootCA)
+		case tee.SoftwareFormat:
+			if !cfg.AttestationDev {
+				logrus.Fatal("[-] Software attestation can be forged, it's only allowed with TEE_ATTESTATION_DEV=true")
+			}
+			logrus.Warn("[-] Accepting software attestation, verified results are not backed by an enclave")
+			verifier = tee.SoftwareVerifier{}
+		default:
+			verifier, err = tee.GetVerifier(cfg.AttestationVerifier)
+		}
+		if err != nil {
+			logrus.Fatalf("[-] Invalid attestation verifier: %v", err)
+		}
+		workerManagerOptions = append(workerManagerOptions, workers.WithAttestationVerifier(
+			tee.NewAttestationVerifier(verifier, strings.Split(cfg.AttestationMeasurements, ",")),
+			cfg.RequireAttestation,
+		))
+	} else if cfg.RequireAttestation {
+		logrus.Fatal("[-] Attestation can't be required without a verifier, set TEE_ATTESTATION_VERIFIER")
+	}
+
+	workerManagerOptions = append(workerManagerOptions, workers.WithContentPolicy(contentPolicy(cfg)))
+
+	if auditStore := openAuditStore(cfg); auditStore != nil {
+		workerManagerOptions = append(workerManagerOptions, workers.WithAuditStore(auditStore))
+	}
+
+	banList, err := network.NewBanList(filepath.Join(cfg.MasaDir, "banned_peers.json"))
+	if err != nil {
+		logrus.Fatalf("[-] %v", err)
 	}
 
 	masaNodeOptions := WithConstantOptions(
@@ -39,9 +111,10 @@ func InitOptions(cfg *AppConfig) ([]node.Option, *workers.WorkHandlerManager, *p
 		node.WithPort(cfg.PortNbr),
 		node.WithBootNodes(cfg.Bootnodes...),
 		node.WithMasaDir(cfg.MasaDir),
-		node.WithCachePath(cachePath),
+		node.WithCachePath(cfg.NodeDatastorePath()),
 		node.WithKeyManager(cfg.KeyManager),
 		node.WithWorkerProtocol(WorkerProtocol),
+		node.WithBanList(banList),
 	)
 
 	if cfg.TwitterScraper {
@@ -65,7 +138,8 @@ func InitOptions(cfg *AppConfig) ([]node.Option, *workers.WorkHandlerManager, *p
 	}
 
 	workHandlerManager := workers.NewWorkHandlerManager(workerManagerOptions...)
-	blockChainEventTracker := node.NewBlockChain()
+	validators := strings.FieldsFunc(cfg.ValidatorSet, func(r rune) bool { return r == ',' || r == ' ' })
+	blockChainEventTracker := node.NewBlockChain(validators, cfg.ValidatorGovernance)
 	pubKeySub := &pubsub.PublicKeySubscriptionHandler{}
 
 	masaNodeOptions = append(masaNodeOptions, []node.Option{
@@ -76,6 +150,8 @@ func InitOptions(cfg *AppConfig) ([]node.Option, *workers.WorkHandlerManager, *p
 		),
 		node.WithPubSubHandler(PublicKeyTopic, pubKeySub, false),
 		node.WithPubSubHandler(BlockTopic, blockChainEventTracker, true),
+		node.WithPubSubHandler(ValidatorSetTopic, blockChainEventTracker.ValidatorSetHandler(), false),
+		node.WithPubSubHandler(BlockVoteTopic, blockChainEventTracker.VoteHandler(BlockVoteTopic), false),
 	}...)
 
 	if cfg.Validator {
@@ -102,3 +178,83 @@ func InitOptions(cfg *AppConfig) ([]node.Option, *workers.WorkHandlerManager, *p
 
 	return masaNodeOptions, workHandlerManager, pubKeySub
 }
+
+// contentPolicy builds the content policy of the worker handlers from the configuration.
+// Invalid network lists stop the node, since ignoring them could expose the operator's network.
+func contentPolicy(cfg *AppConfig) *policy.Policy {
+	opts := []policy.Option{
+		policy.WithAllowDomains(policy.ParseDomains(cfg.WebAllowDomains)...),
+		policy.WithDenyDomains(policy.ParseDomains(cfg.WebDenyDomains)...),
+		policy.WithMaxResponseSize(cfg.WebMaxResponseSize),
+	}
+	if networks, err := policy.ParseNetworks(cfg.WebAllowNetworks); err != nil {
+		logrus.Fatalf("[-] Invalid web allow networks: %v", err)
+	} else {
+		opts = append(opts, policy.WithAllowNetworks(networks...))
+	}
+	if networks, err := policy.ParseNetworks(cfg.WebDenyNetworks); err != nil {
+		logrus.Fatalf("[-] Invalid web deny networks: %v", err)
+	} else {
+		opts = append(opts, policy.WithDenyNetworks(networks...))
+	}
+	if cfg.WebAllowPrivate {
+		opts = append(opts, policy.AllowPrivateAddresses)
+	}
+	if cfg.WebRespectRobots {
+		opts = append(opts, policy.RespectRobots)
+	}
+	return policy.New(opts...)
+}
+
+// InitRateLimiter creates the per API key rate limiter, whose usage accounting is stored in the masa directory.
+func InitRateLimiter(cfg *AppConfig) *ratelimit.Limiter {
+	dailyUnits, err := ratelimit.ParseDailyUnits(cfg.APIDailyUnits)
+	if err != nil {
+		logrus.Fatalf("[-] Invalid API daily units: %v", err)
+	}
+	usage, err := ratelimit.OpenUsageStore(filepath.Join(cfg.MasaDir, "usage"))
+	if err != nil {
+		logrus.Fatalf("[-] %v", err)
+	}
+	return ratelimit.New(ratelimit.Limits{
+		RequestsPerSecond: cfg.APIRateLimit,
+		Burst:             cfg.APIRateBurst,
+		MaxConcurrent:     cfg.APIMaxConcurrent,
+		DailyUnits:        dailyUnits,
+	}, usage)
+}
+
+// InitIdempotency creates the store of the responses replayed to requests retried with the same
+// Idempotency-Key. It returns nil if the window is 0.
+func InitIdempotency(cfg *AppConfig) *idempotency.Store {
+	if cfg.APIIdempotency == "" {
+		return nil
+	}
+	window, err := time.ParseDuration(cfg.APIIdempotency)
+	if err != nil || window < 0 {
+		logrus.Fatalf("[-] Invalid API idempotency window %q", cfg.APIIdempotency)
+	}
+	if window == 0 {
+		return nil
+	}
+	return idempotency.New(window, idempotency.DefaultMaxBytes)
+}
+
+// openAuditStore opens the work request audit store in the masa directory.
+// Auditing is disabled, with an error logged, if the store cannot be opened.
+func openAuditStore(cfg *AppConfig) *audit.Store {
+	var retention time.Duration
+	if cfg.AuditRetention != "" && cfg.AuditRetention != "0" {
+		var err error
+		retention, err = time.ParseDuration(cfg.AuditRetention)
+		if err != nil {
+			logrus.Warnf("[-] Invalid audit retention %q, keeping audit records forever: %v", cfg.AuditRetention, err)
+		}
+	}
+	store, err := audit.Open(filepath.Join(cfg.MasaDir, "audit"), retention)
+	if err != nil {
+		logrus.Errorf("[-] Audit log disabled: %v", err)
+		return nil
+	}
+	return store
+}
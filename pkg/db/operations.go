@@ -3,25 +3,20 @@ package db
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
+	ds "github.com/ipfs/go-datastore"
 	"github.com/masa-finance/masa-oracle/node"
 	"github.com/sirupsen/logrus"
 )
 
-type WorkEvent struct {
-	CID       string          `json:"cid"`
-	PeerId    string          `json:"peer_id"`
-	Payload   json.RawMessage `json:"payload"`
-	Duration  float64         `json:"duration"`
-	Timestamp int64           `json:"timestamp"`
-}
-
 // WriteData encapsulates the logic for writing data to the database,
 // including access control checks from access_control.go.
 func WriteData(node *node.OracleNode, key string, value []byte) error {
+	if isPrivate(ds.NewKey(key)) {
+		return ErrPrivateKey
+	}
 	if !isAuthorized(node.Host.ID().String()) {
 		logrus.WithFields(logrus.Fields{
 			"nodeID":       node.Host.ID().String(),
@@ -55,6 +50,9 @@ func WriteData(node *node.OracleNode, key string, value []byte) error {
 // ReadData reads the value for the given key from the database.
 // It requires the host for access control verification before reading.
 func ReadData(node *node.OracleNode, key string) ([]byte, error) {
+	if isPrivate(ds.NewKey(key)) {
+		return nil, ErrPrivateKey
+	}
 	logrus.WithFields(logrus.Fields{
 		"nodeID":       node.Host.ID().String(),
 		"isAuthorized": true,
@@ -3,10 +3,12 @@ package db
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
 	"github.com/masa-finance/masa-oracle/pkg/consensus"
 	"github.com/masa-finance/masa-oracle/pkg/masacrypto"
 	"github.com/masa-finance/masa-oracle/pkg/pubsub"
@@ -23,6 +25,23 @@ import (
 var cache ds.Datastore
 var nodeDataChan = make(chan *pubsub.NodeData)
 
+// privatePrefixes are the namespaces of the resolver cache holding state of this node, such as its
+// API keys, which is neither published to the DHT nor read or written through the cache functions.
+var privatePrefixes = []ds.Key{ds.NewKey(apikey.DatastorePrefix)}
+
+// ErrPrivateKey is returned when accessing a key of the private namespaces of the resolver cache.
+var ErrPrivateKey = errors.New("key is reserved for the node")
+
+// isPrivate returns whether the key belongs to one of the private namespaces of the resolver cache.
+func isPrivate(key ds.Key) bool {
+	for _, prefix := range privatePrefixes {
+		if key.Equal(prefix) || prefix.IsAncestorOf(key) {
+			return true
+		}
+	}
+	return false
+}
+
 type Record struct {
 	Key   string
 	Value []byte
@@ -74,6 +93,29 @@ func InitResolverCache(node *node.OracleNode, keyManager *masacrypto.KeyManager,
 	}
 }
 
+// Datastore returns the node datastore backing the resolver cache, or nil if it isn't initialized.
+// Node state stored in it must use one of the private namespaces, e.g. apikey.DatastorePrefix.
+func Datastore() ds.Datastore {
+	return cache
+}
+
+// CloseResolverCache closes the resolver cache, if it was initialized.
+func CloseResolverCache() error {
+	if cache == nil {
+		return nil
+	}
+	return cache.Close()
+}
+
+// CheckResolverCache returns an error if the resolver cache isn't initialized or has been closed.
+func CheckResolverCache(ctx context.Context) error {
+	if cache == nil {
+		return fmt.Errorf("resolver cache not initialized")
+	}
+	_, err := cache.Has(ctx, ds.NewKey("/healthcheck"))
+	return err
+}
+
 // PutCache puts a key-value pair into the resolver cache.
 //
 // It takes a context, a key as a string, and a value as a byte slice.
@@ -82,7 +124,11 @@ func InitResolverCache(node *node.OracleNode, keyManager *masacrypto.KeyManager,
 //
 // It returns the original key string and a possible error.
 func PutCache(ctx context.Context, keyStr string, value []byte) (any, error) {
-	err := cache.Put(ctx, ds.NewKey(keyStr), value)
+	key := ds.NewKey(keyStr)
+	if isPrivate(key) {
+		return nil, ErrPrivateKey
+	}
+	err := cache.Put(ctx, key, value)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +139,11 @@ func PutCache(ctx context.Context, keyStr string, value []byte) (any, error) {
 // It takes a context and a key string, converts the key into a datastore key,
 // gets the value from the cache, and returns the value byte slice and a possible error.
 func GetCache(ctx context.Context, keyStr string) ([]byte, error) {
-	value, err := cache.Get(ctx, ds.NewKey(keyStr))
+	key := ds.NewKey(keyStr)
+	if isPrivate(key) {
+		return nil, ErrPrivateKey
+	}
+	value, err := cache.Get(ctx, key)
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +156,9 @@ func GetCache(ctx context.Context, keyStr string) ([]byte, error) {
 // It returns a bool indicating if the deletion succeeded.
 func DelCache(ctx context.Context, keyStr string) bool {
 	key := ds.NewKey(keyStr)
+	if isPrivate(key) {
+		return false
+	}
 	err := cache.Delete(ctx, key)
 	if err != nil {
 		return false
@@ -122,6 +175,9 @@ func DelCache(ctx context.Context, keyStr string) bool {
 func UpdateCache(ctx context.Context, keyStr string, newValue []byte) (bool, error) {
 	// Check if the key exists
 	key := ds.NewKey(keyStr)
+	if isPrivate(key) {
+		return false, ErrPrivateKey
+	}
 	res, err := cache.Has(ctx, key)
 	if err != nil {
 		return false, fmt.Errorf("error checking key existence: %w", err)
@@ -139,7 +195,7 @@ func UpdateCache(ctx context.Context, keyStr string, newValue []byte) (bool, err
 	return true, nil
 }
 
-// QueryAll queries the resolver cache for all records and returns them as a slice of Record structs.
+// QueryAll queries the resolver cache for all records outside of the private namespaces and returns them as a slice of Record structs.
 // It executes a query.Query{} to get all results, closes the results when done, iterates through
 // the results, appending each record to a slice, and returns the slice.
 func QueryAll(ctx context.Context) ([]Record, error) {
@@ -157,6 +213,9 @@ func QueryAll(ctx context.Context) ([]Record, error) {
 			logrus.Errorf("[-] Error iterating query results: %v", result.Error)
 			return nil, result.Error
 		}
+		if isPrivate(ds.RawKey(result.Entry.Key)) {
+			continue
+		}
 		// Append the record to the slice
 		records = append(records, Record{Key: result.Entry.Key, Value: result.Entry.Value})
 	}
@@ -204,25 +263,6 @@ func iterateAndPublish(ctx context.Context, node *node.OracleNode) {
 		}
 		logrus.Printf("syncing %s", key)
 		_ = WriteData(node, key, record.Value)
-
-		// sync blocks
-		blocks, err := node.DHT.GetValue(ctx, "/db/blocks")
-		if err != nil {
-			logrus.Debugf("[-] Error getting block data: %v", err)
-			continue
-		}
-
-		if err := WriteData(node, "blocks", blocks); err != nil {
-			logrus.Debugf("[-] Error writing block data: %v", err)
-		}
-
-		// sync ipfs
-		ipfs, e := node.DHT.GetValue(ctx, "/db/ipfs")
-		if e != nil {
-			logrus.Debugf("[-] Error unmarshalling IPFS data: %v", e)
-		} else {
-			_ = WriteData(node, "ipfs", ipfs)
-		}
 	}
 }
 
@@ -0,0 +1,182 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/masa-finance/masa-oracle/pkg/api"
+	"github.com/masa-finance/masa-oracle/pkg/grpcapi/masav1"
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
+)
+
+var sealingModes = map[masav1.SealingMode]string{
+	masav1.SealingMode_SEALING_MODE_UNSPECIFIED: "",
+	masav1.SealingMode_SEALING_MODE_SEALED:      string(data_types.SealingSealed),
+	masav1.SealingMode_SEALING_MODE_UNSEALED:    string(data_types.SealingUnsealed),
+	masav1.SealingMode_SEALING_MODE_BOTH:        string(data_types.SealingBoth),
+}
+
+func sealingOptions(s *masav1.Sealing) (data_types.SealingOptions, error) {
+	mode, ok := sealingModes[s.GetMode()]
+	if !ok {
+		return data_types.SealingOptions{}, status.Errorf(codes.InvalidArgument, "invalid sealing mode %s", s.GetMode())
+	}
+	opts, err := api.ParseSealingOptions(mode, s.GetTeeEndpoint())
+	if err != nil {
+		return data_types.SealingOptions{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return opts, nil
+}
+
+// workResult converts a work response into a WorkResult, or its error into a gRPC status
+// matching the HTTP status the REST API returns.
+func workResult(response data_types.WorkResponse, workType data_types.WorkerType) (*masav1.WorkResult, error) {
+	if response.Error != "" {
+		logrus.Errorf("[+] Work error: %s", response.Error)
+		httpStatus, message := api.WorkErrorStatus(response.Error)
+		return nil, status.Error(grpcCode(httpStatus), message+": "+response.Error)
+	}
+	if response.Data == nil || response.Data == "" {
+		return nil, status.Error(codes.NotFound, "No data returned")
+	}
+
+	data, err := jsonValue(response.Data)
+	if err != nil {
+		logrus.Errorf("[-] Error converting work result: %v", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+	result := &masav1.WorkResult{
+		WorkType:     string(workType),
+		WorkerPeerId: response.WorkerPeerId,
+		Data:         data,
+	}
+	if response.WorkRequest != nil {
+		result.RequestId = response.WorkRequest.RequestId
+	}
+	if sealed := response.Sealed; sealed != nil {
+		result.Sealed = &masav1.SealedData{
+			Payload:      sealed.Payload,
+			Format:       sealed.Format,
+			TeeEndpoint:  sealed.TeeEndpoint,
+			WorkType:     string(sealed.WorkType),
+			RequestId:    sealed.RequestId,
+			WorkerPeerId: sealed.WorkerPeerId,
+			SealedAt:     timestamp(sealed.SealedAt),
+		}
+	}
+	if attestation := response.AttestationResult; attestation != nil {
+		result.Attestation = &masav1.AttestationResult{
+			Verified:    attestation.Verified,
+			Format:      attestation.Format,
+			Measurement: attestation.Measurement,
+			Error:       attestation.Error,
+			VerifiedAt:  timestamp(attestation.VerifiedAt),
+		}
+	}
+	return result, nil
+}
+
+// jsonValue converts arbitrary data into a protobuf Value through its JSON representation.
+// Strings holding JSON documents are decoded.
+func jsonValue(data interface{}) (*structpb.Value, error) {
+	if s, ok := data.(string); ok {
+		var decoded interface{}
+		if json.Unmarshal([]byte(s), &decoded) == nil {
+			return structpb.NewValue(decoded)
+		}
+		return structpb.NewStringValue(s), nil
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, err
+	}
+	return structpb.NewValue(decoded)
+}
+
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+// contextError converts the error of a call that didn't complete into a gRPC status.
+func contextError(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "Request timed out in API layer")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "Request cancelled")
+	default:
+		logrus.Errorf("[-] Work request failed: %v", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+}
+
+func nodeMessage(nd *pubsub.NodeData) *masav1.Node {
+	node := &masav1.Node{
+		PeerId:                   nd.PeerId.String(),
+		Multiaddrs:               make([]string, 0, len(nd.Multiaddrs)),
+		EthAddress:               nd.EthAddress,
+		Version:                  nd.Version,
+		IsActive:                 nd.IsActive,
+		IsStaked:                 nd.IsStaked,
+		IsValidator:              nd.IsValidator,
+		IsTwitterScraper:         nd.IsTwitterScraper,
+		IsWebScraper:             nd.IsWebScraper,
+		FirstJoined:              unixTimestamp(nd.FirstJoinedUnix),
+		LastJoined:               unixTimestamp(nd.LastJoinedUnix),
+		LastUpdated:              unixTimestamp(nd.LastUpdatedUnix),
+		CurrentUptimeSeconds:     int64(nd.GetCurrentUptime().Seconds()),
+		AccumulatedUptimeSeconds: int64(nd.GetAccumulatedUptime().Seconds()),
+		ReturnedTweets:           int64(nd.ReturnedTweets),
+		TweetTimeouts:            int64(nd.TweetTimeouts),
+		NotFoundCount:            int64(nd.NotFoundCount),
+		AttestedResults:          int64(nd.AttestedResults),
+		AttestationFailures:      int64(nd.AttestationFailures),
+	}
+	for _, ma := range nd.Multiaddrs {
+		node.Multiaddrs = append(node.Multiaddrs, ma.String())
+	}
+	return node
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func unixTimestamp(unix int64) *timestamppb.Timestamp {
+	if unix == 0 {
+		return nil
+	}
+	return timestamppb.New(time.Unix(unix, 0))
+}
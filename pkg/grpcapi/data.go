@@ -0,0 +1,196 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/masa-finance/masa-oracle/pkg/grpcapi/masav1"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
+)
+
+const (
+	maxBatchItems = 100
+	// maxBatchConcurrency is the number of batch items processed at the same time.
+	maxBatchConcurrency = 10
+)
+
+type dataService struct {
+	masav1.UnimplementedDataServiceServer
+	server *Server
+}
+
+// workCall is a validated work request, ready to be distributed.
+type workCall struct {
+	workType data_types.WorkerType
+	payload  []byte
+	sealing  data_types.SealingOptions
+}
+
+// newWorkCall validates a work item and builds the same payload the REST handlers send to workers.
+func (s *dataService) newWorkCall(item *masav1.WorkItem) (workCall, error) {
+	var call workCall
+	var body interface{}
+	var sealing *masav1.Sealing
+
+	switch req := item.GetRequest().(type) {
+	case *masav1.WorkItem_SearchRecentTweets:
+		r := req.SearchRecentTweets
+		if r.GetQuery() == "" || r.GetCount() <= 0 {
+			return call, status.Error(codes.InvalidArgument, "Query and count must be provided and valid")
+		}
+		call.workType = data_types.Twitter
+		body = struct {
+			Query string `json:"query"`
+			Count int    `json:"count"`
+		}{r.GetQuery(), int(r.GetCount())}
+		sealing = r.GetSealing()
+
+	case *masav1.WorkItem_TwitterProfile:
+		r := req.TwitterProfile
+		if r.GetUsername() == "" {
+			return call, status.Error(codes.InvalidArgument, "Username must be provided and valid")
+		}
+		call.workType = data_types.TwitterProfile
+		body = struct {
+			Username string `json:"username"`
+		}{r.GetUsername()}
+		sealing = r.GetSealing()
+
+	case *masav1.WorkItem_TwitterFollowers:
+		r := req.TwitterFollowers
+		if r.GetUsername() == "" {
+			return call, status.Error(codes.InvalidArgument, "Username parameter is missing")
+		}
+		count := int(r.GetCount())
+		if count == 0 {
+			count = 20
+		}
+		call.workType = data_types.TwitterFollowers
+		body = struct {
+			Username string `json:"username"`
+			Count    int    `json:"count"`
+		}{r.GetUsername(), count}
+		sealing = r.GetSealing()
+
+	case *masav1.WorkItem_Web:
+		r := req.Web
+		if s.server.api.Node != nil && !s.server.api.Node.Options.IsStaked {
+			return call, status.Error(codes.FailedPrecondition, "Node has not staked and cannot participate")
+		}
+		web := data_types.WebRequest{
+			Url:   r.GetUrl(),
+			Depth: int(r.GetDepth()),
+		}
+		if err := web.Validate(); err != nil {
+			return call, status.Error(codes.InvalidArgument, err.Error())
+		}
+		call.workType = data_types.Web
+		body = web
+		sealing = r.GetSealing()
+
+	default:
+		return call, status.Error(codes.InvalidArgument, "Work item has no request")
+	}
+
+	var err error
+	if call.payload, err = json.Marshal(body); err != nil {
+		return call, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if call.sealing, err = sealingOptions(sealing); err != nil {
+		return call, err
+	}
+	return call, nil
+}
+
+// execute validates and executes a work item. Batch items are charged to the daily quota here;
+// the other calls are charged by the unary interceptor.
+func (s *dataService) execute(ctx context.Context, item *masav1.WorkItem, chargeQuota bool) (*masav1.WorkResult, error) {
+	call, err := s.newWorkCall(item)
+	if err != nil {
+		return nil, err
+	}
+	if s.server.api.Node == nil || s.server.api.WorkManager == nil {
+		return nil, status.Error(codes.Unavailable, "Node is not initialized")
+	}
+	complete := func(bool) {}
+	if chargeQuota {
+		if complete, err = s.server.reserveWorkUnit(ctx, call.workType, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	response, err := s.server.api.ExecuteWork(ctx, call.workType, call.payload, call.sealing)
+	if err != nil {
+		complete(false)
+		return nil, contextError(err)
+	}
+	result, err := workResult(response, call.workType)
+	complete(err == nil)
+	return result, err
+}
+
+func (s *dataService) SearchRecentTweets(ctx context.Context, req *masav1.SearchRecentTweetsRequest) (*masav1.WorkResult, error) {
+	return s.execute(ctx, &masav1.WorkItem{Request: &masav1.WorkItem_SearchRecentTweets{SearchRecentTweets: req}}, false)
+}
+
+func (s *dataService) GetTwitterProfile(ctx context.Context, req *masav1.TwitterProfileRequest) (*masav1.WorkResult, error) {
+	return s.execute(ctx, &masav1.WorkItem{Request: &masav1.WorkItem_TwitterProfile{TwitterProfile: req}}, false)
+}
+
+func (s *dataService) GetTwitterFollowers(ctx context.Context, req *masav1.TwitterFollowersRequest) (*masav1.WorkResult, error) {
+	return s.execute(ctx, &masav1.WorkItem{Request: &masav1.WorkItem_TwitterFollowers{TwitterFollowers: req}}, false)
+}
+
+func (s *dataService) ScrapeWeb(ctx context.Context, req *masav1.WebRequest) (*masav1.WorkResult, error) {
+	return s.execute(ctx, &masav1.WorkItem{Request: &masav1.WorkItem_Web{Web: req}}, false)
+}
+
+// Batch processes the items concurrently and streams each result as soon as it is ready.
+// A failed item is reported in its result and doesn't end the stream.
+func (s *dataService) Batch(req *masav1.BatchRequest, stream masav1.DataService_BatchServer) error {
+	items := req.GetItems()
+	if len(items) == 0 || len(items) > maxBatchItems {
+		return status.Errorf(codes.InvalidArgument, "a batch must have between 1 and %d items", maxBatchItems)
+	}
+	ctx := stream.Context()
+
+	var sendMu sync.Mutex
+	var sendErr error
+	send := func(result *masav1.BatchResult) {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		if sendErr == nil {
+			sendErr = stream.Send(result)
+		}
+	}
+
+	semaphore := make(chan struct{}, maxBatchConcurrency)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		go func(index int, item *masav1.WorkItem) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result, err := s.execute(ctx, item, true)
+			batchResult := &masav1.BatchResult{Index: int32(index)}
+			if err != nil {
+				st := status.Convert(err)
+				batchResult.Outcome = &masav1.BatchResult_Error{Error: &masav1.Error{
+					Code:    int32(st.Code()),
+					Message: st.Message(),
+				}}
+			} else {
+				batchResult.Outcome = &masav1.BatchResult_Result{Result: result}
+			}
+			send(batchResult)
+		}(i, item)
+	}
+	wg.Wait()
+	return sendErr
+}
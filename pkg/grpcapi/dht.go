@@ -0,0 +1,70 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/masa-finance/masa-oracle/pkg/api"
+	"github.com/masa-finance/masa-oracle/pkg/db"
+	"github.com/masa-finance/masa-oracle/pkg/grpcapi/masav1"
+)
+
+type dhtService struct {
+	masav1.UnimplementedDHTServiceServer
+	api *api.API
+}
+
+// Get reads a value from the DHT. Like the REST API, JSON values (possibly base64 encoded) are
+// decoded and other values are returned as strings.
+func (s *dhtService) Get(_ context.Context, req *masav1.DHTGetRequest) (*masav1.DHTRecord, error) {
+	if s.api.Node == nil || s.api.Node.DHT == nil {
+		return nil, status.Error(codes.Unavailable, "Node is not initialized")
+	}
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing key")
+	}
+	value, err := db.ReadData(s.api.Node, req.GetKey())
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"key":   req.GetKey(),
+			"error": err,
+		}).Debug("[-] Failed to read data from DHT")
+		return nil, status.Error(codes.NotFound, "no data")
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(value, &decoded); err != nil {
+		if b, decodeErr := base64.StdEncoding.DecodeString(string(value)); decodeErr != nil || json.Unmarshal(b, &decoded) != nil {
+			decoded = string(value)
+		}
+	}
+	record, err := structpb.NewValue(decoded)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "value cannot be represented")
+	}
+	return &masav1.DHTRecord{Key: req.GetKey(), Value: record}, nil
+}
+
+// Put writes a value to the DHT as JSON.
+func (s *dhtService) Put(_ context.Context, req *masav1.DHTPutRequest) (*masav1.DHTPutResponse, error) {
+	if s.api.Node == nil || s.api.Node.DHT == nil {
+		return nil, status.Error(codes.Unavailable, "Node is not initialized")
+	}
+	if req.GetKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing key")
+	}
+	value, err := json.Marshal(req.GetValue().AsInterface())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid value")
+	}
+	if err := db.WriteData(s.api.Node, req.GetKey(), value); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to write %s: %v", req.GetKey(), err)
+	}
+	return &masav1.DHTPutResponse{Key: req.GetKey()}, nil
+}
@@ -0,0 +1,233 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/masa-finance/masa-oracle/pkg/api"
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
+	"github.com/masa-finance/masa-oracle/pkg/grpcapi/masav1"
+	"github.com/masa-finance/masa-oracle/pkg/ratelimit"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
+)
+
+// methodScopes is the scope each method requires, matching the scopes of the REST routes.
+// Methods that aren't listed are rejected when authentication is enabled.
+var methodScopes = map[string]apikey.Scope{
+	masav1.DataService_SearchRecentTweets_FullMethodName:  apikey.ScopeDataRead,
+	masav1.DataService_GetTwitterProfile_FullMethodName:   apikey.ScopeDataRead,
+	masav1.DataService_GetTwitterFollowers_FullMethodName: apikey.ScopeDataRead,
+	masav1.DataService_ScrapeWeb_FullMethodName:           apikey.ScopeDataRead,
+	masav1.DataService_Batch_FullMethodName:               apikey.ScopeDataRead,
+	masav1.NodeService_ListNodes_FullMethodName:           apikey.ScopeDataRead,
+	masav1.NodeService_GetNode_FullMethodName:             apikey.ScopeDataRead,
+	masav1.NodeService_ListPeers_FullMethodName:           apikey.ScopeDataRead,
+	masav1.DHTService_Get_FullMethodName:                  apikey.ScopeDataRead,
+	masav1.DHTService_Put_FullMethodName:                  apikey.ScopeDHTWrite,
+	masav1.TopicService_CreateTopic_FullMethodName:        apikey.ScopeTopicsPublish,
+	masav1.TopicService_Publish_FullMethodName:            apikey.ScopeTopicsPublish,
+	masav1.TopicService_Subscribe_FullMethodName:          apikey.ScopeDataRead,
+}
+
+// methodWorkTypes is the work type charged to the daily quota by each data method.
+// Batch charges each of its items separately.
+var methodWorkTypes = map[string]data_types.WorkerType{
+	masav1.DataService_SearchRecentTweets_FullMethodName:  data_types.Twitter,
+	masav1.DataService_GetTwitterProfile_FullMethodName:   data_types.TwitterProfile,
+	masav1.DataService_GetTwitterFollowers_FullMethodName: data_types.TwitterFollowers,
+	masav1.DataService_ScrapeWeb_FullMethodName:           data_types.Web,
+}
+
+type apiKeyContextKey struct{}
+
+// callerID returns the ID of the API key that authenticated the call.
+func callerID(ctx context.Context) string {
+	if key, ok := ctx.Value(apiKeyContextKey{}).(*apikey.Key); ok {
+		return key.ID
+	}
+	return api.AnonymousKeyID
+}
+
+// authenticate checks the API key in the call metadata, or the client certificate of the connection,
+// and the scope the method requires, and returns a context carrying the key. It lets every call
+// through when authentication is disabled.
+func (s *Server) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if !s.api.AuthEnabled() {
+		return ctx, nil
+	}
+	key, err := s.callerKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scope, ok := methodScopes[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "method %s is not available", method)
+	}
+	if !key.HasScope(scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "API key lacks the %s scope", scope)
+	}
+	return context.WithValue(ctx, apiKeyContextKey{}, key), nil
+}
+
+// callerKey returns the key granted to the client certificate of the connection, or else
+// the API key in the call metadata.
+func (s *Server) callerKey(ctx context.Context) (*apikey.Key, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			if key, ok := s.api.ClientCertKey(&info.State); ok {
+				return key, nil
+			}
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	token := first(md.Get("x-api-key"))
+	if token == "" {
+		const BearerSchema = "Bearer "
+		authHeader := first(md.Get("authorization"))
+		if !strings.HasPrefix(authHeader, BearerSchema) {
+			return nil, status.Error(codes.Unauthenticated, "API key required")
+		}
+		token = strings.TrimSpace(authHeader[len(BearerSchema):])
+	}
+	if s.api.APIKeys == nil {
+		return nil, status.Error(codes.Unauthenticated, "Invalid API key")
+	}
+
+	key, err := s.api.APIKeys.Authenticate(token)
+	if err != nil {
+		message := "Invalid API key"
+		if errors.Is(err, apikey.ErrExpiredKey) || errors.Is(err, apikey.ErrRevokedKey) {
+			message = err.Error()
+		}
+		return nil, status.Error(codes.Unauthenticated, message)
+	}
+	return key, nil
+}
+
+// limit applies the caller's request rate and concurrency limits. The returned function
+// releases the concurrency slot once the call is done.
+func (s *Server) limit(ctx context.Context, setHeader func(metadata.MD) error) (func(), error) {
+	if s.api.Limiter == nil {
+		return func() {}, nil
+	}
+	id := callerID(ctx)
+	decision := s.api.Limiter.Allow(id)
+	setRateLimitHeader(setHeader, decision)
+	if !decision.Allowed {
+		return nil, status.Error(codes.ResourceExhausted, "Rate limit exceeded")
+	}
+	release, ok := s.api.Limiter.Acquire(id)
+	if !ok {
+		return nil, status.Error(codes.ResourceExhausted, "Too many concurrent requests")
+	}
+	return release, nil
+}
+
+// reserveWorkUnit reserves a work unit of the work type in the caller's daily quota. The returned
+// function charges it, or releases it if the call failed.
+func (s *Server) reserveWorkUnit(ctx context.Context, workType data_types.WorkerType, setHeader func(metadata.MD) error) (func(success bool), error) {
+	if s.api.Limiter == nil {
+		return func(bool) {}, nil
+	}
+	id := callerID(ctx)
+	decision, err := s.api.Limiter.Reserve(id, string(workType))
+	if err != nil {
+		logrus.Errorf("[-] Failed to check the daily quota: %v", err)
+		return nil, status.Error(codes.Internal, "Internal server error")
+	}
+	if setHeader != nil {
+		setRateLimitHeader(setHeader, decision)
+	}
+	if !decision.Allowed {
+		if err := s.api.Limiter.Reject(id, string(workType)); err != nil {
+			logrus.Errorf("[-] Error recording usage: %v", err)
+		}
+		return nil, status.Errorf(codes.ResourceExhausted, "Daily quota of %d %s work units exceeded", decision.Limit, workType)
+	}
+	return func(success bool) {
+		if err := s.api.Limiter.Complete(id, string(workType), success); err != nil {
+			logrus.Errorf("[-] Error recording usage: %v", err)
+		}
+	}, nil
+}
+
+func (s *Server) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	setHeader := func(md metadata.MD) error { return grpc.SetHeader(ctx, md) }
+	release, err := s.limit(ctx, setHeader)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	workType, ok := methodWorkTypes[info.FullMethod]
+	if !ok {
+		return handler(ctx, req)
+	}
+	complete, err := s.reserveWorkUnit(ctx, workType, setHeader)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := handler(ctx, req)
+	complete(err == nil)
+	return resp, err
+}
+
+func (s *Server) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	release, err := s.limit(ctx, ss.SetHeader)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// contextStream replaces the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// setRateLimitHeader sends the ratelimit-* headers of the REST API as response metadata.
+func setRateLimitHeader(setHeader func(metadata.MD) error, d ratelimit.Decision) {
+	if d.Limit <= 0 {
+		return
+	}
+	_ = setHeader(metadata.Pairs(
+		"ratelimit-limit", strconv.Itoa(d.Limit),
+		"ratelimit-remaining", strconv.Itoa(d.Remaining),
+		"ratelimit-reset", strconv.Itoa(int(math.Ceil(d.Reset.Seconds()))),
+		"ratelimit-policy", fmt.Sprintf("%d;w=%d", d.Limit, int(math.Ceil(d.Window.Seconds()))),
+	))
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,805 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v5.27.3
+// source: masa.proto
+
+package masav1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	DataService_SearchRecentTweets_FullMethodName  = "/masa.v1.DataService/SearchRecentTweets"
+	DataService_GetTwitterProfile_FullMethodName   = "/masa.v1.DataService/GetTwitterProfile"
+	DataService_GetTwitterFollowers_FullMethodName = "/masa.v1.DataService/GetTwitterFollowers"
+	DataService_ScrapeWeb_FullMethodName           = "/masa.v1.DataService/ScrapeWeb"
+	DataService_Batch_FullMethodName               = "/masa.v1.DataService/Batch"
+)
+
+// DataServiceClient is the client API for DataService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DataService requests data from the network's workers. Requires the data:read scope.
+type DataServiceClient interface {
+	SearchRecentTweets(ctx context.Context, in *SearchRecentTweetsRequest, opts ...grpc.CallOption) (*WorkResult, error)
+	GetTwitterProfile(ctx context.Context, in *TwitterProfileRequest, opts ...grpc.CallOption) (*WorkResult, error)
+	GetTwitterFollowers(ctx context.Context, in *TwitterFollowersRequest, opts ...grpc.CallOption) (*WorkResult, error)
+	ScrapeWeb(ctx context.Context, in *WebRequest, opts ...grpc.CallOption) (*WorkResult, error)
+	// Batch runs the requests concurrently and streams each result as soon as it is ready.
+	// Each request is charged to the daily quota of its work type.
+	Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (DataService_BatchClient, error)
+}
+
+type dataServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDataServiceClient(cc grpc.ClientConnInterface) DataServiceClient {
+	return &dataServiceClient{cc}
+}
+
+func (c *dataServiceClient) SearchRecentTweets(ctx context.Context, in *SearchRecentTweetsRequest, opts ...grpc.CallOption) (*WorkResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkResult)
+	err := c.cc.Invoke(ctx, DataService_SearchRecentTweets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceClient) GetTwitterProfile(ctx context.Context, in *TwitterProfileRequest, opts ...grpc.CallOption) (*WorkResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkResult)
+	err := c.cc.Invoke(ctx, DataService_GetTwitterProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceClient) GetTwitterFollowers(ctx context.Context, in *TwitterFollowersRequest, opts ...grpc.CallOption) (*WorkResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkResult)
+	err := c.cc.Invoke(ctx, DataService_GetTwitterFollowers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceClient) ScrapeWeb(ctx context.Context, in *WebRequest, opts ...grpc.CallOption) (*WorkResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkResult)
+	err := c.cc.Invoke(ctx, DataService_ScrapeWeb_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceClient) Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (DataService_BatchClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DataService_ServiceDesc.Streams[0], DataService_Batch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dataServiceBatchClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DataService_BatchClient interface {
+	Recv() (*BatchResult, error)
+	grpc.ClientStream
+}
+
+type dataServiceBatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *dataServiceBatchClient) Recv() (*BatchResult, error) {
+	m := new(BatchResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DataServiceServer is the server API for DataService service.
+// All implementations must embed UnimplementedDataServiceServer
+// for forward compatibility
+//
+// DataService requests data from the network's workers. Requires the data:read scope.
+type DataServiceServer interface {
+	SearchRecentTweets(context.Context, *SearchRecentTweetsRequest) (*WorkResult, error)
+	GetTwitterProfile(context.Context, *TwitterProfileRequest) (*WorkResult, error)
+	GetTwitterFollowers(context.Context, *TwitterFollowersRequest) (*WorkResult, error)
+	ScrapeWeb(context.Context, *WebRequest) (*WorkResult, error)
+	// Batch runs the requests concurrently and streams each result as soon as it is ready.
+	// Each request is charged to the daily quota of its work type.
+	Batch(*BatchRequest, DataService_BatchServer) error
+	mustEmbedUnimplementedDataServiceServer()
+}
+
+// UnimplementedDataServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDataServiceServer struct {
+}
+
+func (UnimplementedDataServiceServer) SearchRecentTweets(context.Context, *SearchRecentTweetsRequest) (*WorkResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchRecentTweets not implemented")
+}
+func (UnimplementedDataServiceServer) GetTwitterProfile(context.Context, *TwitterProfileRequest) (*WorkResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTwitterProfile not implemented")
+}
+func (UnimplementedDataServiceServer) GetTwitterFollowers(context.Context, *TwitterFollowersRequest) (*WorkResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTwitterFollowers not implemented")
+}
+func (UnimplementedDataServiceServer) ScrapeWeb(context.Context, *WebRequest) (*WorkResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScrapeWeb not implemented")
+}
+func (UnimplementedDataServiceServer) Batch(*BatchRequest, DataService_BatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Batch not implemented")
+}
+func (UnimplementedDataServiceServer) mustEmbedUnimplementedDataServiceServer() {}
+
+// UnsafeDataServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DataServiceServer will
+// result in compilation errors.
+type UnsafeDataServiceServer interface {
+	mustEmbedUnimplementedDataServiceServer()
+}
+
+func RegisterDataServiceServer(s grpc.ServiceRegistrar, srv DataServiceServer) {
+	s.RegisterService(&DataService_ServiceDesc, srv)
+}
+
+func _DataService_SearchRecentTweets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRecentTweetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).SearchRecentTweets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_SearchRecentTweets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).SearchRecentTweets(ctx, req.(*SearchRecentTweetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataService_GetTwitterProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwitterProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).GetTwitterProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_GetTwitterProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).GetTwitterProfile(ctx, req.(*TwitterProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataService_GetTwitterFollowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwitterFollowersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).GetTwitterFollowers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_GetTwitterFollowers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).GetTwitterFollowers(ctx, req.(*TwitterFollowersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataService_ScrapeWeb_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceServer).ScrapeWeb(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DataService_ScrapeWeb_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceServer).ScrapeWeb(ctx, req.(*WebRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataService_Batch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DataServiceServer).Batch(m, &dataServiceBatchServer{ServerStream: stream})
+}
+
+type DataService_BatchServer interface {
+	Send(*BatchResult) error
+	grpc.ServerStream
+}
+
+type dataServiceBatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *dataServiceBatchServer) Send(m *BatchResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// DataService_ServiceDesc is the grpc.ServiceDesc for DataService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DataService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "masa.v1.DataService",
+	HandlerType: (*DataServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchRecentTweets",
+			Handler:    _DataService_SearchRecentTweets_Handler,
+		},
+		{
+			MethodName: "GetTwitterProfile",
+			Handler:    _DataService_GetTwitterProfile_Handler,
+		},
+		{
+			MethodName: "GetTwitterFollowers",
+			Handler:    _DataService_GetTwitterFollowers_Handler,
+		},
+		{
+			MethodName: "ScrapeWeb",
+			Handler:    _DataService_ScrapeWeb_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Batch",
+			Handler:       _DataService_Batch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "masa.proto",
+}
+
+const (
+	NodeService_ListNodes_FullMethodName = "/masa.v1.NodeService/ListNodes"
+	NodeService_GetNode_FullMethodName   = "/masa.v1.NodeService/GetNode"
+	NodeService_ListPeers_FullMethodName = "/masa.v1.NodeService/ListPeers"
+)
+
+// NodeServiceClient is the client API for NodeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// NodeService reads the node directory. Requires the data:read scope.
+type NodeServiceClient interface {
+	ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*ListNodesResponse, error)
+	GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*Node, error)
+	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
+}
+
+type nodeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNodeServiceClient(cc grpc.ClientConnInterface) NodeServiceClient {
+	return &nodeServiceClient{cc}
+}
+
+func (c *nodeServiceClient) ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*ListNodesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNodesResponse)
+	err := c.cc.Invoke(ctx, NodeService_ListNodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) GetNode(ctx context.Context, in *GetNodeRequest, opts ...grpc.CallOption) (*Node, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Node)
+	err := c.cc.Invoke(ctx, NodeService_GetNode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPeersResponse)
+	err := c.cc.Invoke(ctx, NodeService_ListPeers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NodeServiceServer is the server API for NodeService service.
+// All implementations must embed UnimplementedNodeServiceServer
+// for forward compatibility
+//
+// NodeService reads the node directory. Requires the data:read scope.
+type NodeServiceServer interface {
+	ListNodes(context.Context, *ListNodesRequest) (*ListNodesResponse, error)
+	GetNode(context.Context, *GetNodeRequest) (*Node, error)
+	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
+	mustEmbedUnimplementedNodeServiceServer()
+}
+
+// UnimplementedNodeServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedNodeServiceServer struct {
+}
+
+func (UnimplementedNodeServiceServer) ListNodes(context.Context, *ListNodesRequest) (*ListNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNodes not implemented")
+}
+func (UnimplementedNodeServiceServer) GetNode(context.Context, *GetNodeRequest) (*Node, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNode not implemented")
+}
+func (UnimplementedNodeServiceServer) ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPeers not implemented")
+}
+func (UnimplementedNodeServiceServer) mustEmbedUnimplementedNodeServiceServer() {}
+
+// UnsafeNodeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NodeServiceServer will
+// result in compilation errors.
+type UnsafeNodeServiceServer interface {
+	mustEmbedUnimplementedNodeServiceServer()
+}
+
+func RegisterNodeServiceServer(s grpc.ServiceRegistrar, srv NodeServiceServer) {
+	s.RegisterService(&NodeService_ServiceDesc, srv)
+}
+
+func _NodeService_ListNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ListNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_ListNodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ListNodes(ctx, req.(*ListNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_GetNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).GetNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_GetNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).GetNode(ctx, req.(*GetNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ListPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_ListPeers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ListPeers(ctx, req.(*ListPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NodeService_ServiceDesc is the grpc.ServiceDesc for NodeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NodeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "masa.v1.NodeService",
+	HandlerType: (*NodeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListNodes",
+			Handler:    _NodeService_ListNodes_Handler,
+		},
+		{
+			MethodName: "GetNode",
+			Handler:    _NodeService_GetNode_Handler,
+		},
+		{
+			MethodName: "ListPeers",
+			Handler:    _NodeService_ListPeers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "masa.proto",
+}
+
+const (
+	DHTService_Get_FullMethodName = "/masa.v1.DHTService/Get"
+	DHTService_Put_FullMethodName = "/masa.v1.DHTService/Put"
+)
+
+// DHTServiceClient is the client API for DHTService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// DHTService reads (data:read scope) and writes (dht:write scope) the DHT.
+type DHTServiceClient interface {
+	Get(ctx context.Context, in *DHTGetRequest, opts ...grpc.CallOption) (*DHTRecord, error)
+	Put(ctx context.Context, in *DHTPutRequest, opts ...grpc.CallOption) (*DHTPutResponse, error)
+}
+
+type dHTServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDHTServiceClient(cc grpc.ClientConnInterface) DHTServiceClient {
+	return &dHTServiceClient{cc}
+}
+
+func (c *dHTServiceClient) Get(ctx context.Context, in *DHTGetRequest, opts ...grpc.CallOption) (*DHTRecord, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DHTRecord)
+	err := c.cc.Invoke(ctx, DHTService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTServiceClient) Put(ctx context.Context, in *DHTPutRequest, opts ...grpc.CallOption) (*DHTPutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DHTPutResponse)
+	err := c.cc.Invoke(ctx, DHTService_Put_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DHTServiceServer is the server API for DHTService service.
+// All implementations must embed UnimplementedDHTServiceServer
+// for forward compatibility
+//
+// DHTService reads (data:read scope) and writes (dht:write scope) the DHT.
+type DHTServiceServer interface {
+	Get(context.Context, *DHTGetRequest) (*DHTRecord, error)
+	Put(context.Context, *DHTPutRequest) (*DHTPutResponse, error)
+	mustEmbedUnimplementedDHTServiceServer()
+}
+
+// UnimplementedDHTServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDHTServiceServer struct {
+}
+
+func (UnimplementedDHTServiceServer) Get(context.Context, *DHTGetRequest) (*DHTRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedDHTServiceServer) Put(context.Context, *DHTPutRequest) (*DHTPutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Put not implemented")
+}
+func (UnimplementedDHTServiceServer) mustEmbedUnimplementedDHTServiceServer() {}
+
+// UnsafeDHTServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DHTServiceServer will
+// result in compilation errors.
+type UnsafeDHTServiceServer interface {
+	mustEmbedUnimplementedDHTServiceServer()
+}
+
+func RegisterDHTServiceServer(s grpc.ServiceRegistrar, srv DHTServiceServer) {
+	s.RegisterService(&DHTService_ServiceDesc, srv)
+}
+
+func _DHTService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DHTGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DHTService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTServiceServer).Get(ctx, req.(*DHTGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTService_Put_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DHTPutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTServiceServer).Put(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DHTService_Put_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTServiceServer).Put(ctx, req.(*DHTPutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DHTService_ServiceDesc is the grpc.ServiceDesc for DHTService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DHTService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "masa.v1.DHTService",
+	HandlerType: (*DHTServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _DHTService_Get_Handler,
+		},
+		{
+			MethodName: "Put",
+			Handler:    _DHTService_Put_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "masa.proto",
+}
+
+const (
+	TopicService_CreateTopic_FullMethodName = "/masa.v1.TopicService/CreateTopic"
+	TopicService_Publish_FullMethodName     = "/masa.v1.TopicService/Publish"
+	TopicService_Subscribe_FullMethodName   = "/masa.v1.TopicService/Subscribe"
+)
+
+// TopicServiceClient is the client API for TopicService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TopicService creates and publishes to (topics:publish scope) and subscribes to
+// (data:read scope) pubsub topics.
+type TopicServiceClient interface {
+	CreateTopic(ctx context.Context, in *CreateTopicRequest, opts ...grpc.CallOption) (*CreateTopicResponse, error)
+	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
+	// Subscribe streams the messages of a topic until the call is cancelled.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (TopicService_SubscribeClient, error)
+}
+
+type topicServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTopicServiceClient(cc grpc.ClientConnInterface) TopicServiceClient {
+	return &topicServiceClient{cc}
+}
+
+func (c *topicServiceClient) CreateTopic(ctx context.Context, in *CreateTopicRequest, opts ...grpc.CallOption) (*CreateTopicResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTopicResponse)
+	err := c.cc.Invoke(ctx, TopicService_CreateTopic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topicServiceClient) Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublishResponse)
+	err := c.cc.Invoke(ctx, TopicService_Publish_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topicServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (TopicService_SubscribeClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TopicService_ServiceDesc.Streams[0], TopicService_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &topicServiceSubscribeClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TopicService_SubscribeClient interface {
+	Recv() (*TopicMessage, error)
+	grpc.ClientStream
+}
+
+type topicServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *topicServiceSubscribeClient) Recv() (*TopicMessage, error) {
+	m := new(TopicMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TopicServiceServer is the server API for TopicService service.
+// All implementations must embed UnimplementedTopicServiceServer
+// for forward compatibility
+//
+// TopicService creates and publishes to (topics:publish scope) and subscribes to
+// (data:read scope) pubsub topics.
+type TopicServiceServer interface {
+	CreateTopic(context.Context, *CreateTopicRequest) (*CreateTopicResponse, error)
+	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
+	// Subscribe streams the messages of a topic until the call is cancelled.
+	Subscribe(*SubscribeRequest, TopicService_SubscribeServer) error
+	mustEmbedUnimplementedTopicServiceServer()
+}
+
+// UnimplementedTopicServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTopicServiceServer struct {
+}
+
+func (UnimplementedTopicServiceServer) CreateTopic(context.Context, *CreateTopicRequest) (*CreateTopicResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTopic not implemented")
+}
+func (UnimplementedTopicServiceServer) Publish(context.Context, *PublishRequest) (*PublishResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
+func (UnimplementedTopicServiceServer) Subscribe(*SubscribeRequest, TopicService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedTopicServiceServer) mustEmbedUnimplementedTopicServiceServer() {}
+
+// UnsafeTopicServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TopicServiceServer will
+// result in compilation errors.
+type UnsafeTopicServiceServer interface {
+	mustEmbedUnimplementedTopicServiceServer()
+}
+
+func RegisterTopicServiceServer(s grpc.ServiceRegistrar, srv TopicServiceServer) {
+	s.RegisterService(&TopicService_ServiceDesc, srv)
+}
+
+func _TopicService_CreateTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTopicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopicServiceServer).CreateTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopicService_CreateTopic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopicServiceServer).CreateTopic(ctx, req.(*CreateTopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopicService_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopicServiceServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopicService_Publish_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopicServiceServer).Publish(ctx, req.(*PublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopicService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TopicServiceServer).Subscribe(m, &topicServiceSubscribeServer{ServerStream: stream})
+}
+
+type TopicService_SubscribeServer interface {
+	Send(*TopicMessage) error
+	grpc.ServerStream
+}
+
+type topicServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *topicServiceSubscribeServer) Send(m *TopicMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// TopicService_ServiceDesc is the grpc.ServiceDesc for TopicService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TopicService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "masa.v1.TopicService",
+	HandlerType: (*TopicServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTopic",
+			Handler:    _TopicService_CreateTopic_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _TopicService_Publish_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _TopicService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "masa.proto",
+}
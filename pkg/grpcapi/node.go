@@ -0,0 +1,78 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/masa-finance/masa-oracle/pkg/api"
+	"github.com/masa-finance/masa-oracle/pkg/config"
+	"github.com/masa-finance/masa-oracle/pkg/grpcapi/masav1"
+)
+
+const maxNodesPageSize = 1000
+
+type nodeService struct {
+	masav1.UnimplementedNodeServiceServer
+	api *api.API
+}
+
+func (s *nodeService) ListNodes(_ context.Context, req *masav1.ListNodesRequest) (*masav1.ListNodesResponse, error) {
+	if s.api.Node == nil || s.api.Node.NodeTracker == nil {
+		return nil, status.Error(codes.Unavailable, "Node is not initialized")
+	}
+	page, pageSize := int(req.GetPage()), int(req.GetPageSize())
+	if page < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page must not be negative")
+	}
+	if pageSize <= 0 {
+		pageSize = config.PageSize
+	}
+	if pageSize > maxNodesPageSize {
+		pageSize = maxNodesPageSize
+	}
+
+	allNodeData := s.api.Node.NodeTracker.GetAllNodeData()
+	total := len(allNodeData)
+	start := min(page*pageSize, total)
+	end := min(start+pageSize, total)
+
+	response := &masav1.ListNodesResponse{
+		Nodes:        make([]*masav1.Node, 0, end-start),
+		Page:         int32(page),
+		TotalPages:   int32((total + pageSize - 1) / pageSize),
+		TotalRecords: int32(total),
+	}
+	for i := start; i < end; i++ {
+		response.Nodes = append(response.Nodes, nodeMessage(&allNodeData[i]))
+	}
+	return response, nil
+}
+
+func (s *nodeService) GetNode(_ context.Context, req *masav1.GetNodeRequest) (*masav1.Node, error) {
+	if s.api.Node == nil || s.api.Node.NodeTracker == nil {
+		return nil, status.Error(codes.Unavailable, "Node is not initialized")
+	}
+	nodeData := s.api.Node.NodeTracker.GetNodeData(req.GetPeerId())
+	if nodeData == nil {
+		return nil, status.Error(codes.NotFound, "Node not found")
+	}
+	return nodeMessage(nodeData), nil
+}
+
+func (s *nodeService) ListPeers(_ context.Context, _ *masav1.ListPeersRequest) (*masav1.ListPeersResponse, error) {
+	if s.api.Node == nil || s.api.Node.NodeTracker == nil {
+		return nil, status.Error(codes.Unavailable, "Node is not initialized")
+	}
+	allNodeData := s.api.Node.NodeTracker.GetAllNodeData()
+	response := &masav1.ListPeersResponse{Peers: make([]*masav1.Peer, 0, len(allNodeData))}
+	for _, nd := range allNodeData {
+		peer := &masav1.Peer{PeerId: nd.PeerId.String()}
+		for _, ma := range nd.Multiaddrs {
+			peer.Addresses = append(peer.Addresses, ma.String())
+		}
+		response.Peers = append(response.Peers, peer)
+	}
+	return response, nil
+}
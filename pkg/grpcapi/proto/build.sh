@@ -0,0 +1,9 @@
+#!/bin/sh
+# Generates the Go code of the gRPC API. Requires protoc, protoc-gen-go and protoc-gen-go-grpc:
+#   go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.34.2
+#   go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.4.0
+set -e
+cd "$(dirname "$0")"
+protoc --go_out=../masav1 --go_opt=paths=source_relative \
+  --go-grpc_out=../masav1 --go-grpc_opt=paths=source_relative \
+  masa.proto
@@ -0,0 +1,74 @@
+// Package grpcapi serves the node's API over gRPC, as an alternative to the REST API for
+// clients that prefer typed messages. It shares the REST API's key store and rate limiter.
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/masa-finance/masa-oracle/pkg/api"
+	"github.com/masa-finance/masa-oracle/pkg/grpcapi/masav1"
+)
+
+// Server is the gRPC API server.
+type Server struct {
+	api    *api.API
+	server *grpc.Server
+}
+
+// NewServer creates a gRPC server for the API. Authentication and rate limiting use the API's
+// key store and limiter, so keys, scopes and quotas are shared with the REST API.
+func NewServer(a *api.API, opts ...grpc.ServerOption) *Server {
+	s := &Server{api: a}
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(s.unaryInterceptor),
+		grpc.ChainStreamInterceptor(s.streamInterceptor),
+	)
+	s.server = grpc.NewServer(opts...)
+	masav1.RegisterDataServiceServer(s.server, &dataService{server: s})
+	masav1.RegisterNodeServiceServer(s.server, &nodeService{api: a})
+	masav1.RegisterDHTServiceServer(s.server, &dhtService{api: a})
+	masav1.RegisterTopicServiceServer(s.server, &topicService{api: a})
+	return s
+}
+
+// Serve accepts connections on the listener until the server is stopped.
+func (s *Server) Serve(listener net.Listener) error {
+	return s.server.Serve(listener)
+}
+
+// ListenAndServe listens on the TCP address and serves connections until the server is stopped.
+func (s *Server) ListenAndServe(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("error listening for gRPC connections: %w", err)
+	}
+	logrus.Infof("[+] gRPC API listening on %s", listener.Addr())
+	return s.Serve(listener)
+}
+
+// GracefulStop stops accepting connections and waits for the pending calls to finish.
+func (s *Server) GracefulStop() {
+	s.server.GracefulStop()
+}
+
+// Shutdown stops accepting connections and waits for the pending calls to finish until the
+// context is done, after which the remaining calls, such as topic subscriptions, are cancelled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
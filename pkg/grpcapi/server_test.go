@@ -0,0 +1,99 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/masa-finance/masa-oracle/pkg/api"
+	"github.com/masa-finance/masa-oracle/pkg/apikey"
+	"github.com/masa-finance/masa-oracle/pkg/grpcapi/masav1"
+	"github.com/masa-finance/masa-oracle/pkg/ratelimit"
+)
+
+func newTestClient(t *testing.T, opts ...api.Option) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(api.NewAPI(nil, nil, nil, opts...))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.GracefulStop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func newTestKeys(t *testing.T) *apikey.Store {
+	store, err := apikey.NewStore(dssync.MutexWrap(ds.NewMapDatastore()))
+	require.NoError(t, err)
+	return store
+}
+
+func withKey(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", token)
+}
+
+func TestAuthentication(t *testing.T) {
+	keys := newTestKeys(t)
+	_, readToken, err := keys.Issue("reader", []apikey.Scope{apikey.ScopeDataRead}, 0)
+	require.NoError(t, err)
+	dht := masav1.NewDHTServiceClient(newTestClient(t, api.WithAPIKeys(keys)))
+
+	_, err = dht.Get(context.Background(), &masav1.DHTGetRequest{Key: "k"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = dht.Get(withKey("masa_unknown_secret"), &masav1.DHTGetRequest{Key: "k"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = dht.Put(withKey(readToken), &masav1.DHTPutRequest{Key: "k"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Authenticated calls reach the service, which has no node
+	_, err = dht.Get(withKey(readToken), &masav1.DHTGetRequest{Key: "k"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	bearer := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+readToken)
+	_, err = dht.Get(bearer, &masav1.DHTGetRequest{Key: "k"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestRateLimit(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Limits{RequestsPerSecond: 0.001, Burst: 1},
+		ratelimit.NewUsageStore(dssync.MutexWrap(ds.NewMapDatastore())))
+	nodes := masav1.NewNodeServiceClient(newTestClient(t, api.WithRateLimiter(limiter)))
+
+	var header metadata.MD
+	_, err := nodes.ListPeers(context.Background(), &masav1.ListPeersRequest{}, grpc.Header(&header))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, []string{"1"}, header.Get("ratelimit-limit"))
+	assert.Equal(t, []string{"0"}, header.Get("ratelimit-remaining"))
+
+	_, err = nodes.ListPeers(context.Background(), &masav1.ListPeersRequest{})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestInvalidWorkRequest(t *testing.T) {
+	data := masav1.NewDataServiceClient(newTestClient(t))
+
+	_, err := data.ScrapeWeb(context.Background(), &masav1.WebRequest{Url: "ftp://example.com"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = data.SearchRecentTweets(context.Background(), &masav1.SearchRecentTweetsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
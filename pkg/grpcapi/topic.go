@@ -0,0 +1,86 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/masa-finance/masa-oracle/pkg/api"
+	"github.com/masa-finance/masa-oracle/pkg/grpcapi/masav1"
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+)
+
+const maxSubscribeBuffer = 4096
+
+type topicService struct {
+	masav1.UnimplementedTopicServiceServer
+	api *api.API
+}
+
+func (s *topicService) CreateTopic(_ context.Context, req *masav1.CreateTopicRequest) (*masav1.CreateTopicResponse, error) {
+	if s.api.Node == nil || s.api.Node.PubSubManager == nil {
+		return nil, status.Error(codes.Unavailable, "Node or PubSubManager is not initialized")
+	}
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing topic name")
+	}
+	if err := s.api.Node.SubscribeTopic(req.GetName(), pubsub.NewTopicHandler(), false); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &masav1.CreateTopicResponse{}, nil
+}
+
+func (s *topicService) Publish(_ context.Context, req *masav1.PublishRequest) (*masav1.PublishResponse, error) {
+	if s.api.Node == nil || s.api.Node.PubSubManager == nil {
+		return nil, status.Error(codes.Unavailable, "Node or PubSubManager is not initialized")
+	}
+	if req.GetTopic() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing topic name")
+	}
+	if err := s.api.Node.PublishTopicMessage(req.GetTopic(), req.GetMessage()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &masav1.PublishResponse{}, nil
+}
+
+// Subscribe streams the messages of a topic until the call is cancelled. Like the REST stream,
+// messages are dropped when the client falls behind, and the next message reports how many.
+func (s *topicService) Subscribe(req *masav1.SubscribeRequest, stream masav1.TopicService_SubscribeServer) error {
+	if s.api.Node == nil || s.api.Node.PubSubManager == nil {
+		return status.Error(codes.Unavailable, "Node or PubSubManager is not initialized")
+	}
+	if req.GetTopic() == "" {
+		return status.Error(codes.InvalidArgument, "missing topic name")
+	}
+	if req.GetBuffer() < 0 || req.GetBuffer() > maxSubscribeBuffer {
+		return status.Errorf(codes.InvalidArgument, "buffer must be between 0 and %d", maxSubscribeBuffer)
+	}
+
+	client, err := s.api.Node.StreamTopic(req.GetTopic(), int(req.GetBuffer()))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer client.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case msg, ok := <-client.Messages():
+			if !ok {
+				return status.Error(codes.Unavailable, "topic subscription ended")
+			}
+			if err := stream.Send(&masav1.TopicMessage{
+				Topic:     msg.Topic,
+				From:      msg.From,
+				Data:      msg.Data,
+				Timestamp: timestamppb.New(msg.Timestamp),
+				Dropped:   msg.Dropped,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
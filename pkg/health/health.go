@@ -0,0 +1,146 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status is the state of a component or of the node as a whole.
+type Status string
+
+const (
+	StatusUp      Status = "up"
+	StatusDown    Status = "down"
+	StatusSkipped Status = "skipped"
+)
+
+// DefaultTimeout is the time given to each check before it is reported down.
+const DefaultTimeout = 5 * time.Second
+
+// ErrSkipped is returned, possibly wrapped with the reason, by checks that don't apply to the node,
+// e.g. bootnode connectivity on a node without bootnodes.
+var ErrSkipped = errors.New("check skipped")
+
+// CheckFunc checks a component, returning nil if the component is working.
+type CheckFunc func(ctx context.Context) error
+
+// ComponentStatus is the result of the last check of a component. The last error is kept after
+// the component recovers, so that intermittent failures remain visible.
+type ComponentStatus struct {
+	Name          string     `json:"name"`
+	Status        Status     `json:"status"`
+	Critical      bool       `json:"critical"`
+	Message       string     `json:"message,omitempty"`
+	LastError     string     `json:"lastError,omitempty"`
+	LastErrorAt   *time.Time `json:"lastErrorAt,omitempty"`
+	LastCheckedAt time.Time  `json:"lastCheckedAt"`
+}
+
+// Report is the result of running all the checks of a Checker. The status is down if any
+// critical component is down.
+type Report struct {
+	Status     Status            `json:"status"`
+	Components []ComponentStatus `json:"components"`
+}
+
+type check struct {
+	name     string
+	critical bool
+	fn       CheckFunc
+}
+
+// Checker runs a set of component checks and remembers their results.
+type Checker struct {
+	timeout time.Duration
+	checks  []check
+	state   map[string]ComponentStatus
+	mu      sync.Mutex
+	now     func() time.Time
+}
+
+// NewChecker creates a checker that gives each check the given time to complete.
+// A zero timeout uses DefaultTimeout.
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{timeout: timeout, state: make(map[string]ComponentStatus), now: time.Now}
+}
+
+// Register adds a component check. The report is down when a critical component is down,
+// while non-critical components are only reported.
+func (c *Checker) Register(name string, critical bool, fn CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, check{name: name, critical: critical, fn: fn})
+}
+
+// Run runs all the checks concurrently and returns their results, sorted by component name.
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.Lock()
+	checks := append([]check(nil), c.checks...)
+	c.mu.Unlock()
+
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, chk := range checks {
+		wg.Add(1)
+		go func(i int, chk check) {
+			defer wg.Done()
+			errs[i] = c.runCheck(ctx, chk)
+		}(i, chk)
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	report := Report{Status: StatusUp, Components: make([]ComponentStatus, 0, len(checks))}
+	now := c.now().UTC()
+	for i, chk := range checks {
+		status := c.state[chk.name]
+		status.Name = chk.name
+		status.Critical = chk.critical
+		status.LastCheckedAt = now
+		status.Message = ""
+		switch err := errs[i]; {
+		case err == nil:
+			status.Status = StatusUp
+		case errors.Is(err, ErrSkipped):
+			status.Status = StatusSkipped
+			status.Message = err.Error()
+		default:
+			status.Status = StatusDown
+			status.LastError = err.Error()
+			status.LastErrorAt = &now
+			if chk.critical {
+				report.Status = StatusDown
+			}
+		}
+		c.state[chk.name] = status
+		report.Components = append(report.Components, status)
+	}
+	sort.Slice(report.Components, func(i, j int) bool {
+		return report.Components[i].Name < report.Components[j].Name
+	})
+	return report
+}
+
+// runCheck runs a check with the checker timeout, reporting a check that doesn't return in time as failed.
+func (c *Checker) runCheck(ctx context.Context, chk check) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- chk.fn(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,64 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker(t *testing.T) {
+	var cacheErr error
+	checker := NewChecker(50 * time.Millisecond)
+	checker.Register("host", true, func(context.Context) error { return nil })
+	checker.Register("cache", true, func(context.Context) error { return cacheErr })
+	checker.Register("bootnodes", false, func(context.Context) error { return errors.New("not connected") })
+	checker.Register("tee", false, func(context.Context) error { return fmt.Errorf("%w: not a worker", ErrSkipped) })
+
+	report := checker.Run(context.Background())
+	assert.Equal(t, StatusUp, report.Status, "a non-critical failure doesn't fail the report")
+	require.Len(t, report.Components, 4)
+	assert.Equal(t, []string{"bootnodes", "cache", "host", "tee"}, componentNames(report))
+	assert.Equal(t, StatusDown, report.Components[0].Status)
+	assert.Equal(t, "not connected", report.Components[0].LastError)
+	assert.NotNil(t, report.Components[0].LastErrorAt)
+	assert.Equal(t, StatusSkipped, report.Components[3].Status)
+	assert.Contains(t, report.Components[3].Message, "not a worker")
+
+	cacheErr = errors.New("closed")
+	report = checker.Run(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, StatusDown, report.Components[1].Status)
+
+	cacheErr = nil
+	report = checker.Run(context.Background())
+	assert.Equal(t, StatusUp, report.Status)
+	assert.Equal(t, StatusUp, report.Components[1].Status)
+	assert.Equal(t, "closed", report.Components[1].LastError, "the last error is kept after recovery")
+}
+
+func TestCheckerTimeout(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	checker.Register("slow", true, func(context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	start := time.Now()
+	report := checker.Run(context.Background())
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Components[0].LastError)
+}
+
+func componentNames(report Report) []string {
+	names := make([]string, 0, len(report.Components))
+	for _, c := range report.Components {
+		names = append(names, c.Name)
+	}
+	return names
+}
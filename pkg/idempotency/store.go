@@ -0,0 +1,154 @@
+// Package idempotency remembers the responses of requests sent with an idempotency key, so that a
+// client retrying a request gets the original response instead of having the work done twice.
+package idempotency
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultMaxBytes is the default size of the responses kept by a Store.
+	DefaultMaxBytes = 64 << 20
+	// entryOverhead approximates the memory used by a kept response besides its key and body.
+	entryOverhead = 256
+)
+
+var (
+	// ErrMismatch is returned when a key is reused for a different request.
+	ErrMismatch = errors.New("the idempotency key was already used for a different request")
+	// ErrAbandoned is returned to the retries of a request that ended without a response.
+	ErrAbandoned = errors.New("the original request ended without a response")
+)
+
+// Response is a response as sent to the client.
+type Response struct {
+	Status      int
+	ContentType string
+	Body        []byte
+}
+
+// Store keeps the responses for the configured window after they are sent, up to a total size:
+// once it is reached, the oldest responses are dropped early. It is safe for concurrent use.
+type Store struct {
+	window   time.Duration
+	maxBytes int
+	size     int               // of the kept responses
+	entries  map[string]*entry // by key, in flight or kept
+	kept     *list.List        // of the keys of the kept responses, oldest first
+	mu       sync.Mutex
+	now      func() time.Time
+}
+
+type entry struct {
+	fingerprint string
+	done        chan struct{}
+	response    *Response
+	expires     time.Time     // zero while the request is in flight
+	kept        *list.Element // nil while the request is in flight
+	size        int
+}
+
+// Call is a request registered under an idempotency key.
+type Call struct {
+	store *Store
+	key   string
+	entry *entry
+}
+
+// New creates a Store keeping the responses for window, up to maxBytes in total.
+func New(window time.Duration, maxBytes int) *Store {
+	return &Store{
+		window:   window,
+		maxBytes: maxBytes,
+		entries:  make(map[string]*entry),
+		kept:     list.New(),
+		now:      time.Now,
+	}
+}
+
+// Window returns how long the responses are kept.
+func (s *Store) Window() time.Duration {
+	return s.window
+}
+
+// Begin registers a request under key. The fingerprint identifies the request, so that a key
+// reused for a different request is rejected with ErrMismatch. The first request is the owner of
+// the call and must Complete it; the others Wait for its response.
+func (s *Store) Begin(key, fingerprint string) (call *Call, owner bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(s.now())
+	if e, ok := s.entries[key]; ok {
+		if e.fingerprint != fingerprint {
+			return nil, false, ErrMismatch
+		}
+		return &Call{store: s, key: key, entry: e}, false, nil
+	}
+	e := &entry{fingerprint: fingerprint, done: make(chan struct{})}
+	s.entries[key] = e
+	return &Call{store: s, key: key, entry: e}, true, nil
+}
+
+// sweep removes the expired responses. Responses are kept for the same window, so the oldest
+// expire first. The caller holds the lock.
+func (s *Store) sweep(now time.Time) {
+	for front := s.kept.Front(); front != nil; front = s.kept.Front() {
+		if now.Before(s.entries[front.Value.(string)].expires) {
+			return
+		}
+		s.drop(front.Value.(string))
+	}
+}
+
+// drop removes a kept response. The caller holds the lock.
+func (s *Store) drop(key string) {
+	e := s.entries[key]
+	s.kept.Remove(e.kept)
+	s.size -= e.size
+	delete(s.entries, key)
+}
+
+// Complete hands the response to the requests waiting for it. When keep is false, e.g. for errors
+// worth retrying, the response isn't kept and the next request with the key is done again.
+// A nil response abandons the call.
+func (c *Call) Complete(response *Response, keep bool) {
+	s := c.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c.entry.response = response
+	size := 0
+	if response != nil {
+		size = len(c.key) + len(c.entry.fingerprint) + len(response.ContentType) + len(response.Body) + entryOverhead
+	}
+	if keep && response != nil && size <= s.maxBytes {
+		c.entry.expires = s.now().Add(s.window)
+		c.entry.size = size
+		c.entry.kept = s.kept.PushBack(c.key)
+		s.size += size
+		for s.size > s.maxBytes {
+			s.drop(s.kept.Front().Value.(string))
+		}
+	} else if s.entries[c.key] == c.entry {
+		delete(s.entries, c.key)
+	}
+	close(c.entry.done)
+}
+
+// Wait blocks until the owner of the call completes it, returning its response, or until ctx is done.
+func (c *Call) Wait(ctx context.Context) (*Response, error) {
+	select {
+	case <-c.entry.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if c.entry.response == nil {
+		return nil, ErrAbandoned
+	}
+	return c.entry.response, nil
+}
@@ -0,0 +1,122 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(window time.Duration) (*Store, *time.Time) {
+	s := New(window, DefaultMaxBytes)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestStore(t *testing.T) {
+	s, now := newTestStore(time.Hour)
+
+	call, owner, err := s.Begin("key", "request")
+	require.NoError(t, err)
+	require.True(t, owner)
+
+	retry, owner, err := s.Begin("key", "request")
+	require.NoError(t, err)
+	require.False(t, owner)
+	_, _, err = s.Begin("key", "other request")
+	assert.ErrorIs(t, err, ErrMismatch)
+
+	// A retry in flight gets the response once the original request completes
+	waited := make(chan *Response)
+	go func() {
+		response, err := retry.Wait(context.Background())
+		assert.NoError(t, err)
+		waited <- response
+	}()
+	call.Complete(&Response{Status: 200, Body: []byte("ok")}, true)
+	assert.Equal(t, []byte("ok"), (<-waited).Body)
+
+	retry, owner, err = s.Begin("key", "request")
+	require.NoError(t, err)
+	require.False(t, owner)
+	response, err := retry.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 200, response.Status)
+
+	// The key can be used again once the window is over
+	*now = now.Add(time.Hour)
+	_, owner, err = s.Begin("key", "other request")
+	require.NoError(t, err)
+	assert.True(t, owner)
+}
+
+func TestStoreNotKept(t *testing.T) {
+	s, _ := newTestStore(time.Hour)
+
+	call, _, err := s.Begin("key", "request")
+	require.NoError(t, err)
+	retry, _, err := s.Begin("key", "request")
+	require.NoError(t, err)
+	call.Complete(&Response{Status: 503}, false)
+
+	// The retry in flight still gets the error, the next one is done again
+	response, err := retry.Wait(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 503, response.Status)
+	call, owner, err := s.Begin("key", "request")
+	require.NoError(t, err)
+	assert.True(t, owner)
+
+	retry, _, err = s.Begin("key", "request")
+	require.NoError(t, err)
+	call.Complete(nil, false)
+	_, err = retry.Wait(context.Background())
+	assert.ErrorIs(t, err, ErrAbandoned)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = s.Begin("key", "request")
+	require.NoError(t, err)
+	retry, _, err = s.Begin("key", "request")
+	require.NoError(t, err)
+	_, err = retry.Wait(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestStoreMaxBytes(t *testing.T) {
+	s, _ := newTestStore(time.Hour)
+	s.maxBytes = 2*entryOverhead + 200
+
+	complete := func(key string, size int) {
+		call, owner, err := s.Begin(key, "request")
+		require.NoError(t, err)
+		require.True(t, owner)
+		call.Complete(&Response{Status: 200, Body: make([]byte, size)}, true)
+	}
+	kept := func(key string) bool {
+		call, owner, err := s.Begin(key, "request")
+		require.NoError(t, err)
+		if owner {
+			call.Complete(nil, false)
+		}
+		return !owner
+	}
+
+	complete("a", 50)
+	complete("b", 50)
+	assert.True(t, kept("a"))
+	assert.True(t, kept("b"))
+
+	// The oldest response is dropped to make room, and a response larger than the store isn't kept
+	complete("c", 50)
+	assert.False(t, kept("a"))
+	assert.True(t, kept("b"))
+	assert.True(t, kept("c"))
+	complete("d", s.maxBytes)
+	assert.False(t, kept("d"))
+	assert.True(t, kept("c"))
+	assert.LessOrEqual(t, s.size, s.maxBytes)
+}
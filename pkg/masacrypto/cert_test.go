@@ -4,12 +4,14 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
 func TestGenerateSelfSignedCert(t *testing.T) {
-	certPath := "testCert.pem"
-	keyPath := "testKey.pem"
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "testCert.pem")
+	keyPath := filepath.Join(dir, "testKey.pem")
 
 	err := GenerateSelfSignedCert(certPath, keyPath)
 	if err != nil {
@@ -58,26 +60,26 @@ func TestGenerateSelfSignedCert(t *testing.T) {
 	if cert.PublicKey == key.Public() {
 		t.Fatal("[-] Certificate and key do not match")
 	}
-
-	// Cleanup test files
-	os.Remove(certPath)
-	os.Remove(keyPath)
 }
 
 func TestGenerateSelfSignedCertErrors(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+
 	// Invalid cert path
-	err := GenerateSelfSignedCert("/invalid/cert/path", "key.pem")
+	err := GenerateSelfSignedCert("/invalid/cert/path", keyPath)
 	if err == nil {
 		t.Fatal("[-] Expected error with invalid cert path")
 	}
 
 	// Invalid key path
-	err = GenerateSelfSignedCert("cert.pem", "/invalid/key/path")
+	err = GenerateSelfSignedCert(certPath, "/invalid/key/path")
 	if err == nil {
 		t.Fatal("[-] Expected error with invalid key path")
 	}
 
-	err = GenerateSelfSignedCert("cert.pem", "key.pem")
+	err = GenerateSelfSignedCert(certPath, keyPath)
 	if err != nil {
 		t.Fatal("[-] Expected error when ECDSA key generation fails")
 	}
@@ -111,7 +111,7 @@ func Discover(ctx context.Context, bootNodes []string, host host.Host, dht *dht.
 				logrus.Infof("[+] Available Peer: %s", availPeer.String())
 
 				if host.Network().Connectedness(availPeer.ID) != network.Connected {
-					if isConnectedToBootnode(host, bootNodes) {
+					if IsConnectedToBootnode(host, bootNodes) {
 						_, err := host.Network().DialPeer(ctx, availPeer.ID)
 						if err != nil {
 							logrus.Warningf("[-] Failed to connect to peer %s, will retry...", availPeer.ID.String())
@@ -134,8 +134,8 @@ func Discover(ctx context.Context, bootNodes []string, host host.Host, dht *dht.
 	}
 }
 
-// Function to check if connected to at least one bootnode
-func isConnectedToBootnode(host host.Host, bootnodes []string) bool {
+// IsConnectedToBootnode reports whether the host is connected to at least one of the bootnodes.
+func IsConnectedToBootnode(host host.Host, bootnodes []string) bool {
 	for _, bn := range bootnodes {
 		peerID, _ := peer.Decode(strings.Split(bn, "/")[len(strings.Split(bn, "/"))-1])
 		if host.Network().Connectedness(peerID) == network.Connected {
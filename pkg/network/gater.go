@@ -0,0 +1,161 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// Ban is a peer the node refuses connections to and from.
+type Ban struct {
+	PeerId    peer.ID    `json:"peerId"`
+	Reason    string     `json:"reason,omitempty"`
+	BannedAt  time.Time  `json:"bannedAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// Expired reports whether the ban has expired at the given time.
+func (b *Ban) Expired(now time.Time) bool {
+	return b.ExpiresAt != nil && !now.Before(*b.ExpiresAt)
+}
+
+// BanList is a libp2p connection gater that blocks the banned peers, both when dialing them and
+// when they connect to the node. Bans are saved to a file, if any, so that they survive restarts.
+type BanList struct {
+	path string
+	bans map[peer.ID]Ban
+	mu   sync.RWMutex
+	now  func() time.Time
+}
+
+// NewBanList creates a ban list saved to the given file, loading the bans it already contains.
+// An empty path keeps the bans in memory only.
+func NewBanList(path string) (*BanList, error) {
+	b := &BanList{path: path, bans: make(map[peer.ID]Ban), now: time.Now}
+	if path == "" {
+		return b, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading the ban list: %w", err)
+	}
+	var bans []Ban
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return nil, fmt.Errorf("error decoding the ban list %s: %w", path, err)
+	}
+	for _, ban := range bans {
+		b.bans[ban.PeerId] = ban
+	}
+	return b, nil
+}
+
+// Ban bans a peer. A zero ttl bans the peer until it is unbanned.
+func (b *BanList) Ban(id peer.ID, reason string, ttl time.Duration) (Ban, error) {
+	ban := Ban{PeerId: id, Reason: reason, BannedAt: b.now().UTC()}
+	if ttl > 0 {
+		expiresAt := ban.BannedAt.Add(ttl)
+		ban.ExpiresAt = &expiresAt
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bans[id] = ban
+	return ban, b.save()
+}
+
+// Unban lifts the ban of a peer. It returns false if the peer wasn't banned.
+func (b *BanList) Unban(id peer.ID) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.bans[id]; !ok {
+		return false, nil
+	}
+	delete(b.bans, id)
+	return true, b.save()
+}
+
+// IsBanned reports whether a peer is banned.
+func (b *BanList) IsBanned(id peer.ID) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	ban, ok := b.bans[id]
+	return ok && !ban.Expired(b.now())
+}
+
+// List returns the bans in effect, oldest first.
+func (b *BanList) List() []Ban {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	now := b.now()
+	bans := make([]Ban, 0, len(b.bans))
+	for _, ban := range b.bans {
+		if !ban.Expired(now) {
+			bans = append(bans, ban)
+		}
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].BannedAt.Before(bans[j].BannedAt)
+	})
+	return bans
+}
+
+// save writes the bans in effect to the file of the list. It must be called with the lock held.
+func (b *BanList) save() error {
+	if b.path == "" {
+		return nil
+	}
+	now := b.now()
+	bans := make([]Ban, 0, len(b.bans))
+	for id, ban := range b.bans {
+		if ban.Expired(now) {
+			delete(b.bans, id)
+			continue
+		}
+		bans = append(bans, ban)
+	}
+	data, err := json.MarshalIndent(bans, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(b.path, data, 0600); err != nil {
+		return fmt.Errorf("error saving the ban list: %w", err)
+	}
+	return nil
+}
+
+// InterceptPeerDial blocks dialing a banned peer.
+func (b *BanList) InterceptPeerDial(p peer.ID) bool {
+	return !b.IsBanned(p)
+}
+
+// InterceptAddrDial blocks dialing any address of a banned peer.
+func (b *BanList) InterceptAddrDial(p peer.ID, _ multiaddr.Multiaddr) bool {
+	return !b.IsBanned(p)
+}
+
+// InterceptAccept accepts every inbound connection, since the remote peer is only known once it is secured.
+func (b *BanList) InterceptAccept(network.ConnMultiaddrs) bool {
+	return true
+}
+
+// InterceptSecured blocks connections with a banned peer, once its identity has been verified.
+func (b *BanList) InterceptSecured(_ network.Direction, p peer.ID, _ network.ConnMultiaddrs) bool {
+	return !b.IsBanned(p)
+}
+
+// InterceptUpgraded accepts every upgraded connection, which has already been checked by InterceptSecured.
+func (b *BanList) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
@@ -0,0 +1,61 @@
+package network
+
+import (
+	"crypto/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPeerID(t *testing.T) peer.ID {
+	_, pubKey, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := peer.IDFromPublicKey(pubKey)
+	require.NoError(t, err)
+	return id
+}
+
+func TestBanList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "banned_peers.json")
+	bans, err := NewBanList(path)
+	require.NoError(t, err)
+
+	now := time.Now()
+	bans.now = func() time.Time { return now }
+
+	banned, temporary, other := newPeerID(t), newPeerID(t), newPeerID(t)
+	_, err = bans.Ban(banned, "spam", 0)
+	require.NoError(t, err)
+	_, err = bans.Ban(temporary, "", time.Hour)
+	require.NoError(t, err)
+
+	assert.False(t, bans.InterceptPeerDial(banned))
+	assert.False(t, bans.InterceptSecured(network.DirInbound, banned, nil))
+	assert.True(t, bans.InterceptPeerDial(other))
+	assert.True(t, bans.InterceptSecured(network.DirInbound, other, nil))
+	assert.Len(t, bans.List(), 2)
+
+	// Temporary bans expire
+	now = now.Add(2 * time.Hour)
+	assert.True(t, bans.InterceptPeerDial(temporary))
+	assert.Len(t, bans.List(), 1)
+
+	// Bans survive a restart
+	reloaded, err := NewBanList(path)
+	require.NoError(t, err)
+	assert.True(t, reloaded.IsBanned(banned))
+
+	found, err := reloaded.Unban(banned)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.False(t, reloaded.IsBanned(banned))
+	found, err = reloaded.Unban(banned)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
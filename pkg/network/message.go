@@ -0,0 +1,56 @@
+package network
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MaxMessageSize is the size of the largest message read from a protocol stream.
+const MaxMessageSize = 32 << 20
+
+// ErrMessageTooLarge is returned when a message is larger than MaxMessageSize.
+var ErrMessageTooLarge = errors.New("message too large")
+
+// WriteMessage writes a message to a protocol stream as JSON, prefixed with its length as a
+// big-endian 32-bit integer.
+func WriteMessage(w io.Writer, msg interface{}) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("error marshaling message: %w", err)
+	}
+	if len(data) > MaxMessageSize {
+		return fmt.Errorf("%w: %d bytes", ErrMessageTooLarge, len(data))
+	}
+	lengthBuf := make([]byte, 4)
+	binary.BigEndian.PutUint32(lengthBuf, uint32(len(data)))
+	if _, err := w.Write(lengthBuf); err != nil {
+		return fmt.Errorf("error writing message length: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("error writing message: %w", err)
+	}
+	return nil
+}
+
+// ReadMessage reads a message written by WriteMessage from a protocol stream.
+func ReadMessage(r io.Reader, msg interface{}) error {
+	lengthBuf := make([]byte, 4)
+	if _, err := io.ReadFull(r, lengthBuf); err != nil {
+		return fmt.Errorf("error reading message length: %w", err)
+	}
+	length := binary.BigEndian.Uint32(lengthBuf)
+	if length > MaxMessageSize {
+		return fmt.Errorf("%w: %d bytes", ErrMessageTooLarge, length)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return fmt.Errorf("error reading message: %w", err)
+	}
+	if err := json.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("error unmarshaling message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package network
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteMessage(&buf, map[string]int{"a": 1}))
+	require.NoError(t, WriteMessage(&buf, map[string]int{"b": 2}))
+
+	var msg map[string]int
+	require.NoError(t, ReadMessage(&buf, &msg))
+	assert.Equal(t, map[string]int{"a": 1}, msg)
+	msg = nil
+	require.NoError(t, ReadMessage(&buf, &msg))
+	assert.Equal(t, map[string]int{"b": 2}, msg)
+	assert.Error(t, ReadMessage(&buf, &msg))
+
+	// The length is checked before reading the message
+	lengthBuf := make([]byte, 4)
+	binary.BigEndian.PutUint32(lengthBuf, MaxMessageSize+1)
+	assert.ErrorIs(t, ReadMessage(bytes.NewReader(lengthBuf), &msg), ErrMessageTooLarge)
+}
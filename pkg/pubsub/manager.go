@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/masa-finance/masa-oracle/node/types"
 
@@ -21,6 +22,9 @@ type Manager struct {
 	handlers      map[string]types.SubscriptionHandler
 	gossipSub     *pubsub.PubSub
 	host          host.Host
+	topicsMu      sync.Mutex
+	streams       map[string]*topicStream
+	streamsMu     sync.Mutex
 }
 
 // NewPubSubManager creates a new PubSubManager instance.
@@ -40,6 +44,7 @@ func NewPubSubManager(ctx context.Context, host host.Host) (*Manager, error) { /
 		handlers:      make(map[string]types.SubscriptionHandler),
 		gossipSub:     gossipSub,
 		host:          host,
+		streams:       make(map[string]*topicStream),
 	}
 
 	return manager, nil
@@ -59,10 +64,23 @@ func (sm *Manager) createTopic(topicName string) (*pubsub.Topic, error) {
 	if err != nil {
 		return nil, err
 	}
+	sm.topicsMu.Lock()
 	sm.topics[topicName] = topic
+	sm.topicsMu.Unlock()
 	return topic, nil
 }
 
+// topic returns the PubSub topic with the given name, joining it if needed.
+func (sm *Manager) topic(topicName string) (*pubsub.Topic, error) {
+	sm.topicsMu.Lock()
+	t, ok := sm.topics[topicName]
+	sm.topicsMu.Unlock()
+	if ok {
+		return t, nil
+	}
+	return sm.createTopic(topicName)
+}
+
 // AddSubscription subscribes to the PubSub topic with the given topicName.
 // It creates the topic if needed, subscribes to it, and adds the subscription
 // and handler to the manager's maps. It launches a goroutine to handle incoming
@@ -190,15 +208,10 @@ func (sm *Manager) PublishMessage(topicName, message string) error {
 	// Convert the message string to a byte slice
 	data := []byte(message)
 
-	// Check if the topic exists
-	t, ok := sm.topics[topicName]
-	if !ok {
-		// Optionally, create the topic if it doesn't exist
-		var err error
-		t, err = sm.createTopic(topicName)
-		if err != nil {
-			return fmt.Errorf("[-] Failed to create topic %s: %w", topicName, err)
-		}
+	// Get the topic, creating it if it doesn't exist
+	t, err := sm.topic(topicName)
+	if err != nil {
+		return fmt.Errorf("[-] Failed to create topic %s: %w", topicName, err)
 	}
 
 	// Use the existing Publish method to publish the message
@@ -39,35 +39,38 @@ func (m *JSONMultiaddr) UnmarshalJSON(b []byte) error {
 }
 
 type NodeData struct {
-	Multiaddrs           []JSONMultiaddr `json:"multiaddrs,omitempty"`
-	MultiaddrsString     string          `json:"multiaddrsString,omitempty"`
-	PeerId               peer.ID         `json:"peerId"`
-	FirstJoinedUnix      int64           `json:"firstJoined,omitempty"`
-	LastJoinedUnix       int64           `json:"lastJoined,omitempty"`
-	LastLeftUnix         int64           `json:"-"`
-	LastUpdatedUnix      int64           `json:"lastUpdated,omitempty"`
-	CurrentUptime        time.Duration   `json:"uptime,omitempty"`
-	CurrentUptimeStr     string          `json:"uptimeStr,omitempty"`
-	AccumulatedUptime    time.Duration   `json:"accumulatedUptime,omitempty"`
-	AccumulatedUptimeStr string          `json:"accumulatedUptimeStr,omitempty"`
-	EthAddress           string          `json:"ethAddress,omitempty"`
-	Activity             int             `json:"activity,omitempty"`
-	IsActive             bool            `json:"isActive"`
-	IsStaked             bool            `json:"isStaked"`
-	SelfIdentified       bool            `json:"-"`
-	IsValidator          bool            `json:"isValidator"`
-	IsTwitterScraper     bool            `json:"isTwitterScraper"`
-	IsWebScraper         bool            `json:"isWebScraper"`
-	Records              any             `json:"records,omitempty"`
-	Version              string          `json:"version"`
-	WorkerTimeout        time.Time       `json:"workerTimeout,omitempty"`
-	ReturnedTweets       int             `json:"returnedTweets"` // a running count of the number of tweets returned
-	LastReturnedTweet    time.Time       `json:"lastReturnedTweet"`
-	TweetTimeout         bool            `json:"tweetTimeout"`
-	TweetTimeouts        int             `json:"tweetTimeouts"` // a running countthe number of times a tweet request times out
-	LastTweetTimeout     time.Time       `json:"lastTweetTimeout"`
-	LastNotFoundTime     time.Time       `json:"lastNotFoundTime"`
-	NotFoundCount        int             `json:"notFoundCount"` // a running count of the number of times a node is not found
+	Multiaddrs             []JSONMultiaddr `json:"multiaddrs,omitempty"`
+	MultiaddrsString       string          `json:"multiaddrsString,omitempty"`
+	PeerId                 peer.ID         `json:"peerId"`
+	FirstJoinedUnix        int64           `json:"firstJoined,omitempty"`
+	LastJoinedUnix         int64           `json:"lastJoined,omitempty"`
+	LastLeftUnix           int64           `json:"-"`
+	LastUpdatedUnix        int64           `json:"lastUpdated,omitempty"`
+	CurrentUptime          time.Duration   `json:"uptime,omitempty"`
+	CurrentUptimeStr       string          `json:"uptimeStr,omitempty"`
+	AccumulatedUptime      time.Duration   `json:"accumulatedUptime,omitempty"`
+	AccumulatedUptimeStr   string          `json:"accumulatedUptimeStr,omitempty"`
+	EthAddress             string          `json:"ethAddress,omitempty"`
+	Activity               int             `json:"activity,omitempty"`
+	IsActive               bool            `json:"isActive"`
+	IsStaked               bool            `json:"isStaked"`
+	SelfIdentified         bool            `json:"-"`
+	IsValidator            bool            `json:"isValidator"`
+	IsTwitterScraper       bool            `json:"isTwitterScraper"`
+	IsWebScraper           bool            `json:"isWebScraper"`
+	Records                any             `json:"records,omitempty"`
+	Version                string          `json:"version"`
+	WorkerTimeout          time.Time       `json:"workerTimeout,omitempty"`
+	ReturnedTweets         int             `json:"returnedTweets"` // a running count of the number of tweets returned
+	LastReturnedTweet      time.Time       `json:"lastReturnedTweet"`
+	TweetTimeout           bool            `json:"tweetTimeout"`
+	TweetTimeouts          int             `json:"tweetTimeouts"` // a running countthe number of times a tweet request times out
+	LastTweetTimeout       time.Time       `json:"lastTweetTimeout"`
+	LastNotFoundTime       time.Time       `json:"lastNotFoundTime"`
+	NotFoundCount          int             `json:"notFoundCount"`       // a running count of the number of times a node is not found
+	AttestedResults        int             `json:"attestedResults"`     // a running count of results with a verified attestation
+	AttestationFailures    int             `json:"attestationFailures"` // a running count of results whose attestation failed verification
+	LastAttestationFailure time.Time       `json:"lastAttestationFailure"`
 }
 
 // NewNodeData creates a new NodeData struct initialized with the given
@@ -273,3 +276,14 @@ func (nd *NodeData) UpdateTwitterFields(fields NodeData) {
 	}
 	nd.LastUpdatedUnix = time.Now().Unix()
 }
+
+// UpdateAttestationFields records the outcome of verifying the attestation of a result returned by the node.
+func (nd *NodeData) UpdateAttestationFields(verified bool) {
+	if verified {
+		nd.AttestedResults++
+	} else {
+		nd.AttestationFailures++
+		nd.LastAttestationFailure = time.Now()
+	}
+	nd.LastUpdatedUnix = time.Now().Unix()
+}
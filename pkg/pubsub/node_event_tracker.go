@@ -48,12 +48,13 @@ func (s NodeSorter) Less(i, j int) bool { return s.less(s.nodes[i], s.nodes[j])
 
 // SortNodesByTwitterReliability sorts the given nodes based on their Twitter reliability.
 // It uses multiple criteria to determine the reliability and performance of nodes:
-//  1. Prioritizes nodes with more recent last returned tweet
-//  2. Then by higher number of returned tweets
-//  3. Considers the time since last timeout (longer time is better)
-//  4. Then by lower number of timeouts
-//  5. Deprioritizes nodes with more recent last not found time
-//  6. Finally, sorts by PeerId for stability when no performance data is available
+//  1. Deprioritizes nodes with a more recent attestation failure
+//  2. Prioritizes nodes with more recent last returned tweet
+//  3. Then by higher number of returned tweets
+//  4. Considers the time since last timeout (longer time is better)
+//  5. Then by lower number of timeouts
+//  6. Deprioritizes nodes with more recent last not found time
+//  7. Finally, sorts by PeerId for stability when no performance data is available
 //
 // The function modifies the input slice in-place, sorting the nodes from most to least reliable.
 func SortNodesByTwitterReliability(nodes []NodeData) {
@@ -61,6 +62,10 @@ func SortNodesByTwitterReliability(nodes []NodeData) {
 	sorter := NodeSorter{
 		nodes: nodes,
 		less: func(i, j NodeData) bool {
+			// Attestation failures outweigh any performance data
+			if !i.LastAttestationFailure.Equal(j.LastAttestationFailure) {
+				return i.LastAttestationFailure.Before(j.LastAttestationFailure)
+			}
 			// Primary sort: More recent last returned tweet
 			if !i.LastReturnedTweet.Equal(j.LastReturnedTweet) {
 				return i.LastReturnedTweet.After(j.LastReturnedTweet)
@@ -599,3 +604,33 @@ func (net *NodeEventTracker) UpdateNodeDataTwitter(peerID string, updates NodeDa
 	}
 	return nil
 }
+
+// UpdateNodeDataCapabilities records which work the node advertises it can do. The node data is
+// replaced with an updated copy, so that the goroutines reading it aren't raced.
+func (net *NodeEventTracker) UpdateNodeDataCapabilities(peerID string, isTwitterScraper, isWebScraper bool) error {
+	updated := net.nodeData.Update(peerID, func(nodeData *NodeData) {
+		nodeData.IsTwitterScraper = isTwitterScraper
+		nodeData.IsWebScraper = isWebScraper
+		nodeData.LastUpdatedUnix = time.Now().Unix()
+	})
+	if !updated {
+		return fmt.Errorf("node data not found for peer ID: %s", peerID)
+	}
+	return nil
+}
+
+// UpdateNodeDataAttestation records whether the attestation of a result returned by the peer was verified.
+func (net *NodeEventTracker) UpdateNodeDataAttestation(peerID string, verified bool) error {
+	nodeData, exists := net.nodeData.Get(peerID)
+	if !exists {
+		return fmt.Errorf("node data not found for peer ID: %s", peerID)
+	}
+
+	nodeData.UpdateAttestationFields(verified)
+
+	err := net.AddOrUpdateNodeData(nodeData, true)
+	if err != nil {
+		return fmt.Errorf("error updating node data: %v", err)
+	}
+	return nil
+}
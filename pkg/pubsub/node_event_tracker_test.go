@@ -170,6 +170,24 @@ func TestNodeEventTracker(t *testing.T) {
 	})
 }
 
+func TestUpdateNodeDataCapabilities(t *testing.T) {
+	tracker := NewNodeEventTracker("1.0.0", "test", "host1")
+	testPeerID, _ := peer.Decode("QmcgpsyWgH8Y8ajJz1Cu72KnS5uo2Aa2LpzU7kinSupNKC")
+	assert.Error(t, tracker.UpdateNodeDataCapabilities(testPeerID.String(), true, false))
+
+	previous := &NodeData{PeerId: testPeerID, IsTwitterScraper: true}
+	tracker.nodeData.Set(testPeerID.String(), previous)
+	assert.NoError(t, tracker.UpdateNodeDataCapabilities(testPeerID.String(), false, true))
+
+	// Readers of the previous node data keep an unchanged copy
+	updated := tracker.GetNodeData(testPeerID.String())
+	assert.False(t, updated.IsTwitterScraper)
+	assert.True(t, updated.IsWebScraper)
+	assert.NotZero(t, updated.LastUpdatedUnix)
+	assert.True(t, previous.IsTwitterScraper)
+	assert.False(t, previous.IsWebScraper)
+}
+
 func TestSortNodesByTwitterReliability(t *testing.T) {
 	testPeerID1, _ := peer.Decode("QmcgpsyWgH8Y8ajJz1Cu72KnS5uo2Aa2LpzU7kinSupNKC")
 	testPeerID2, _ := peer.Decode("QmcgpsyWgH8Y8ajJz1Cu72KnS5uo2Aa2LpzU7kinSupNKD")
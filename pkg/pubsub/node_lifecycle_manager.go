@@ -3,25 +3,89 @@ package pubsub
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	EventTypeJoin  = "join"
+	EventTypeLeave = "leave"
+
+	// maxLifecycleEventAge is how old a lifecycle event can be before it is ignored, so that
+	// a replayed leave event cannot evict a node from the directory.
+	maxLifecycleEventAge = 5 * time.Minute
+)
+
+// ErrInvalidEventSignature is returned when a lifecycle event isn't signed by the node it is about.
+var ErrInvalidEventSignature = errors.New("invalid lifecycle event signature")
+
 // NodeLifecycleEvent represents a join or leave event
 type NodeLifecycleEvent struct {
 	EventType string `json:"eventType"` // "join" or "leave"
 	NodeID    string `json:"nodeID"`
 	Nonce     int64  `json:"nonce"`
 	Timestamp int64  `json:"timestamp"`
+	Signature []byte `json:"signature,omitempty"` // signature of the event by the key of NodeID
+}
+
+// signedBytes returns the bytes covered by the signature: the JSON encoding of the event without its signature.
+func (e NodeLifecycleEvent) signedBytes() ([]byte, error) {
+	e.Signature = nil
+	return json.Marshal(e)
+}
+
+// Sign signs the event with the private key of the node it is about.
+func (e *NodeLifecycleEvent) Sign(key crypto.PrivKey) error {
+	data, err := e.signedBytes()
+	if err != nil {
+		return err
+	}
+	signature, err := key.Sign(data)
+	if err != nil {
+		return fmt.Errorf("failed to sign node lifecycle event: %w", err)
+	}
+	e.Signature = signature
+	return nil
+}
+
+// Verify checks that the event is signed by the key of the node it is about.
+func (e *NodeLifecycleEvent) Verify() error {
+	id, err := peer.Decode(e.NodeID)
+	if err != nil {
+		return fmt.Errorf("invalid node ID %q: %w", e.NodeID, err)
+	}
+	pubKey, err := id.ExtractPublicKey()
+	if err != nil {
+		return fmt.Errorf("failed to extract the public key of %s: %w", e.NodeID, err)
+	}
+	data, err := e.signedBytes()
+	if err != nil {
+		return err
+	}
+	ok, err := pubKey.Verify(data, e.Signature)
+	if err != nil || !ok {
+		return ErrInvalidEventSignature
+	}
+	return nil
 }
 
-// BroadcastEvent marshals a NodeLifecycleEvent into JSON, publishes it
-// to the given PubSub topic, and logs the operation. Returns any error.
+// BroadcastEvent signs a NodeLifecycleEvent with the given key, marshals it into JSON,
+// publishes it to the given PubSub topic, and logs the operation. Returns any error.
 // This allows broadcasting node join/leave events to other nodes.
-func BroadcastEvent(ctx context.Context, ps *pubsub.PubSub, topicName string, event NodeLifecycleEvent) error {
-	event.Timestamp = time.Now().Unix()
+func BroadcastEvent(ctx context.Context, sm *Manager, topicName string, event NodeLifecycleEvent, key crypto.PrivKey) error {
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().Unix()
+	}
+	if err := event.Sign(key); err != nil {
+		logrus.WithError(err).Error("[-] Failed to sign node lifecycle event")
+		return err
+	}
 
 	eventBytes, err := json.Marshal(event)
 	if err != nil {
@@ -29,7 +93,7 @@ func BroadcastEvent(ctx context.Context, ps *pubsub.PubSub, topicName string, ev
 		return err
 	}
 
-	topic, err := ps.Join(topicName)
+	topic, err := sm.topic(topicName)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
 			"topicName": topicName,
@@ -53,3 +117,59 @@ func BroadcastEvent(ctx context.Context, ps *pubsub.PubSub, topicName string, ev
 	}).Info("[+] Successfully broadcasted node lifecycle event")
 	return nil
 }
+
+// NodeLifecycleHandler marks nodes as left in the node tracker when they broadcast a leave event,
+// rather than waiting for their connections to time out.
+type NodeLifecycleHandler struct {
+	tracker *NodeEventTracker
+	now     func() time.Time
+}
+
+// NewNodeLifecycleHandler creates a handler of the lifecycle events for the node tracker.
+func NewNodeLifecycleHandler(tracker *NodeEventTracker) *NodeLifecycleHandler {
+	return &NodeLifecycleHandler{tracker: tracker, now: time.Now}
+}
+
+// HandleMessage processes a lifecycle event. Leave events are only applied if they are signed by
+// the node that published them, are recent, and are newer than the last time the node joined.
+func (h *NodeLifecycleHandler) HandleMessage(msg *pubsub.Message) {
+	var event NodeLifecycleEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		logrus.Errorf("[-] Failed to unmarshal node lifecycle event: %v", err)
+		return
+	}
+	if err := h.apply(event, msg.GetFrom()); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"eventType": event.EventType,
+			"nodeID":    event.NodeID,
+			"from":      msg.GetFrom().String(),
+		}).Warnf("[-] Ignoring node lifecycle event: %v", err)
+	}
+}
+
+func (h *NodeLifecycleHandler) apply(event NodeLifecycleEvent, from peer.ID) error {
+	if event.EventType != EventTypeLeave {
+		// Joins are announced with the node data on the gossip topic
+		return nil
+	}
+	if event.NodeID != from.String() {
+		return fmt.Errorf("event about %s published by another node", event.NodeID)
+	}
+	if err := event.Verify(); err != nil {
+		return err
+	}
+	age := h.now().Sub(time.Unix(event.Timestamp, 0))
+	if age > maxLifecycleEventAge || age < -maxLifecycleEventAge {
+		return fmt.Errorf("stale event from %s", time.Unix(event.Timestamp, 0).UTC().Format(time.RFC3339))
+	}
+
+	nodeData := h.tracker.GetNodeData(event.NodeID)
+	if nodeData == nil {
+		return nil
+	}
+	if event.Timestamp < nodeData.LastJoinedUnix {
+		return fmt.Errorf("event predates the last join of the node")
+	}
+	nodeData.Left()
+	return nil
+}
@@ -0,0 +1,91 @@
+package pubsub
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIdentity(t *testing.T) (crypto.PrivKey, peer.ID) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+	return key, id
+}
+
+func TestNodeLifecycleEventSignature(t *testing.T) {
+	key, id := newTestIdentity(t)
+	otherKey, _ := newTestIdentity(t)
+
+	event := NodeLifecycleEvent{EventType: EventTypeLeave, NodeID: id.String(), Nonce: 1, Timestamp: time.Now().Unix()}
+	require.NoError(t, event.Sign(key))
+	assert.NoError(t, event.Verify())
+
+	tampered := event
+	tampered.Timestamp++
+	assert.ErrorIs(t, tampered.Verify(), ErrInvalidEventSignature)
+
+	forged := event
+	require.NoError(t, forged.Sign(otherKey))
+	assert.ErrorIs(t, forged.Verify(), ErrInvalidEventSignature)
+}
+
+func TestNodeLifecycleHandlerLeave(t *testing.T) {
+	key, id := newTestIdentity(t)
+	_, other := newTestIdentity(t)
+	now := time.Now()
+
+	newHandler := func() (*NodeLifecycleHandler, *NodeData) {
+		tracker := NewNodeEventTracker("1.0.0", "test", "host1")
+		nodeData := &NodeData{PeerId: id, IsActive: true, Activity: ActivityJoined, LastJoinedUnix: now.Add(-time.Hour).Unix()}
+		tracker.nodeData.Set(id.String(), nodeData)
+		handler := NewNodeLifecycleHandler(tracker)
+		handler.now = func() time.Time { return now }
+		return handler, nodeData
+	}
+	leave := func(timestamp time.Time) NodeLifecycleEvent {
+		event := NodeLifecycleEvent{EventType: EventTypeLeave, NodeID: id.String(), Timestamp: timestamp.Unix()}
+		require.NoError(t, event.Sign(key))
+		return event
+	}
+
+	t.Run("signed and recent", func(t *testing.T) {
+		handler, nodeData := newHandler()
+		require.NoError(t, handler.apply(leave(now), id))
+		assert.False(t, nodeData.IsActive)
+		assert.Equal(t, ActivityLeft, nodeData.Activity)
+	})
+
+	t.Run("relayed for another node", func(t *testing.T) {
+		handler, nodeData := newHandler()
+		assert.Error(t, handler.apply(leave(now), other))
+		assert.True(t, nodeData.IsActive)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		handler, nodeData := newHandler()
+		event := leave(now)
+		event.Signature = nil
+		assert.ErrorIs(t, handler.apply(event, id), ErrInvalidEventSignature)
+		assert.True(t, nodeData.IsActive)
+	})
+
+	t.Run("replayed", func(t *testing.T) {
+		handler, nodeData := newHandler()
+		assert.Error(t, handler.apply(leave(now.Add(-time.Hour)), id))
+		assert.True(t, nodeData.IsActive)
+	})
+
+	t.Run("before the last join", func(t *testing.T) {
+		handler, nodeData := newHandler()
+		nodeData.LastJoinedUnix = now.Unix()
+		assert.Error(t, handler.apply(leave(now.Add(-time.Minute)), id))
+		assert.True(t, nodeData.IsActive)
+	})
+}
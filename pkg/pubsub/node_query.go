@@ -0,0 +1,202 @@
+package pubsub
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NodeSortKey is a field the node directory can be sorted by.
+type NodeSortKey string
+
+const (
+	SortByPeerId      NodeSortKey = "peerId"
+	SortByVersion     NodeSortKey = "version"
+	SortByFirstJoined NodeSortKey = "firstJoined"
+	SortByLastJoined  NodeSortKey = "lastJoined"
+	SortByLastUpdated NodeSortKey = "lastUpdated"
+	SortByUptime      NodeSortKey = "uptime" // accumulated uptime
+	SortByReliability NodeSortKey = "reliability"
+)
+
+// NodeSortKeys lists the keys the node directory can be sorted by.
+var NodeSortKeys = []NodeSortKey{SortByPeerId, SortByVersion, SortByFirstJoined, SortByLastJoined, SortByLastUpdated, SortByUptime, SortByReliability}
+
+// ErrInvalidCursor is returned when a cursor is malformed or was issued for a different sort order.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// NodeQuery selects, sorts and pages through the node directory. Nil and zero filters match every node.
+type NodeQuery struct {
+	Staked         *bool
+	Active         *bool
+	Validator      *bool
+	Capability     *WorkerCategory // nodes that can do work of this category
+	Version        string
+	MinUptime      time.Duration // minimum accumulated uptime
+	MinReliability float64       // minimum ReliabilityScore
+	SortBy         NodeSortKey   // defaults to SortByPeerId
+	Descending     bool
+	Cursor         string // NextCursor of the previous page
+	Limit          int    // 0 returns every matching node
+}
+
+// NodeQueryResult is a page of the node directory.
+type NodeQueryResult struct {
+	Nodes      []NodeData
+	Total      int    // number of nodes matching the filters, across all pages
+	NextCursor string // empty on the last page
+}
+
+// nodeCursor is the position of the last node of a page. Pages continue after the position
+// rather than at an offset, so nodes joining or leaving the directory don't shift them.
+type nodeCursor struct {
+	SortBy     NodeSortKey `json:"k"`
+	Descending bool        `json:"d,omitempty"`
+	Number     float64     `json:"n,omitempty"`
+	String     string      `json:"s,omitempty"`
+	PeerId     string      `json:"p"`
+}
+
+// ReliabilityScore returns the share of successful outcomes among the recorded work outcomes of the
+// node, between 0 and 1. Returned tweets and attested results count as successes; tweet timeouts,
+// not found errors and attestation failures count as failures. A node without any recorded outcome scores 0.
+func (n *NodeData) ReliabilityScore() float64 {
+	successes := n.ReturnedTweets + n.AttestedResults
+	failures := n.TweetTimeouts + n.NotFoundCount + n.AttestationFailures
+	if successes+failures <= 0 {
+		return 0
+	}
+	return float64(successes) / float64(successes+failures)
+}
+
+// ParseNodeSortKey parses the name of a sort key.
+func ParseNodeSortKey(s string) (NodeSortKey, error) {
+	if s == "" {
+		return SortByPeerId, nil
+	}
+	for _, key := range NodeSortKeys {
+		if strings.EqualFold(s, string(key)) {
+			return key, nil
+		}
+	}
+	return "", fmt.Errorf("unknown sort key %q", s)
+}
+
+// QueryNodes filters, sorts and pages through the given nodes. Ties are broken by peer ID,
+// so the order is stable and a cursor always resumes where the previous page ended.
+func QueryNodes(nodes []NodeData, q NodeQuery) (NodeQueryResult, error) {
+	if q.SortBy == "" {
+		q.SortBy = SortByPeerId
+	}
+	if _, err := ParseNodeSortKey(string(q.SortBy)); err != nil {
+		return NodeQueryResult{}, err
+	}
+
+	matching := make([]NodeData, 0, len(nodes))
+	for _, nd := range nodes {
+		if q.matches(&nd) {
+			matching = append(matching, nd)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		return q.compare(q.position(&matching[i]), q.position(&matching[j])) < 0
+	})
+
+	result := NodeQueryResult{Total: len(matching)}
+	start := 0
+	if q.Cursor != "" {
+		after, err := q.decodeCursor()
+		if err != nil {
+			return NodeQueryResult{}, err
+		}
+		start = sort.Search(len(matching), func(i int) bool {
+			return q.compare(q.position(&matching[i]), after) > 0
+		})
+	}
+	end := len(matching)
+	if q.Limit > 0 && start+q.Limit < end {
+		end = start + q.Limit
+		result.NextCursor = encodeNodeCursor(q.position(&matching[end-1]))
+	}
+	result.Nodes = matching[start:end]
+	return result, nil
+}
+
+// QueryNodes queries the node directory of the tracker.
+func (net *NodeEventTracker) QueryNodes(q NodeQuery) (NodeQueryResult, error) {
+	return QueryNodes(net.GetAllNodeData(), q)
+}
+
+func (q *NodeQuery) matches(nd *NodeData) bool {
+	switch {
+	case q.Staked != nil && nd.IsStaked != *q.Staked,
+		q.Active != nil && nd.IsActive != *q.Active,
+		q.Validator != nil && nd.IsValidator != *q.Validator,
+		q.Capability != nil && !nd.CanDoWork(*q.Capability),
+		q.Version != "" && nd.Version != q.Version,
+		q.MinUptime > 0 && nd.GetAccumulatedUptime() < q.MinUptime,
+		q.MinReliability > 0 && nd.ReliabilityScore() < q.MinReliability:
+		return false
+	}
+	return true
+}
+
+// position returns the sort position of a node.
+func (q *NodeQuery) position(nd *NodeData) nodeCursor {
+	pos := nodeCursor{SortBy: q.SortBy, Descending: q.Descending, PeerId: nd.PeerId.String()}
+	switch q.SortBy {
+	case SortByVersion:
+		pos.String = nd.Version
+	case SortByFirstJoined:
+		pos.Number = float64(nd.FirstJoinedUnix)
+	case SortByLastJoined:
+		pos.Number = float64(nd.LastJoinedUnix)
+	case SortByLastUpdated:
+		pos.Number = float64(nd.LastUpdatedUnix)
+	case SortByUptime:
+		pos.Number = nd.GetAccumulatedUptime().Seconds()
+	case SortByReliability:
+		pos.Number = nd.ReliabilityScore()
+	}
+	return pos
+}
+
+// compare orders two positions by the sort key, then by peer ID, in the direction of the query.
+func (q *NodeQuery) compare(a, b nodeCursor) int {
+	var c int
+	switch {
+	case a.Number < b.Number:
+		c = -1
+	case a.Number > b.Number:
+		c = 1
+	case a.String != b.String:
+		c = strings.Compare(a.String, b.String)
+	default:
+		c = strings.Compare(a.PeerId, b.PeerId)
+	}
+	if q.Descending {
+		return -c
+	}
+	return c
+}
+
+func encodeNodeCursor(pos nodeCursor) string {
+	b, _ := json.Marshal(pos)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (q *NodeQuery) decodeCursor() (nodeCursor, error) {
+	var pos nodeCursor
+	b, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+	if err != nil || json.Unmarshal(b, &pos) != nil || pos.PeerId == "" {
+		return pos, ErrInvalidCursor
+	}
+	if pos.SortBy != q.SortBy || pos.Descending != q.Descending {
+		return pos, fmt.Errorf("%w: it was issued for a different sort order", ErrInvalidCursor)
+	}
+	return pos, nil
+}
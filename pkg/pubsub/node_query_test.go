@@ -0,0 +1,91 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDirectory() []NodeData {
+	return []NodeData{
+		{PeerId: peer.ID("node-a"), IsStaked: true, IsActive: true, IsTwitterScraper: true, Version: "v1", FirstJoinedUnix: 30, ReturnedTweets: 9, TweetTimeouts: 1},
+		{PeerId: peer.ID("node-b"), IsStaked: true, IsActive: false, IsWebScraper: true, Version: "v2", FirstJoinedUnix: 10},
+		{PeerId: peer.ID("node-c"), IsStaked: false, IsActive: true, IsTwitterScraper: true, Version: "v1", FirstJoinedUnix: 20, ReturnedTweets: 1, TweetTimeouts: 1},
+		{PeerId: peer.ID("node-d"), IsStaked: true, IsActive: true, IsValidator: true, Version: "v2", FirstJoinedUnix: 20},
+	}
+}
+
+func peerIds(nodes []NodeData) []peer.ID {
+	ids := make([]peer.ID, 0, len(nodes))
+	for _, nd := range nodes {
+		ids = append(ids, nd.PeerId)
+	}
+	return ids
+}
+
+func TestQueryNodesFilters(t *testing.T) {
+	yes, no := true, false
+	twitter := CategoryTwitter
+
+	tests := []struct {
+		name  string
+		query NodeQuery
+		want  []peer.ID
+	}{
+		{"all", NodeQuery{}, []peer.ID{"node-a", "node-b", "node-c", "node-d"}},
+		{"staked", NodeQuery{Staked: &yes}, []peer.ID{"node-a", "node-b", "node-d"}},
+		{"inactive", NodeQuery{Active: &no}, []peer.ID{"node-b"}},
+		{"validator", NodeQuery{Validator: &yes}, []peer.ID{"node-d"}},
+		{"capability requires stake", NodeQuery{Capability: &twitter}, []peer.ID{"node-a"}},
+		{"version", NodeQuery{Version: "v2"}, []peer.ID{"node-b", "node-d"}},
+		{"reliability", NodeQuery{MinReliability: 0.5}, []peer.ID{"node-a", "node-c"}},
+		{"combined", NodeQuery{Active: &yes, Version: "v1", MinReliability: 0.8}, []peer.ID{"node-a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := QueryNodes(testDirectory(), tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, peerIds(result.Nodes))
+			assert.Equal(t, len(tt.want), result.Total)
+			assert.Empty(t, result.NextCursor)
+		})
+	}
+}
+
+func TestQueryNodesSortAndCursor(t *testing.T) {
+	query := NodeQuery{SortBy: SortByFirstJoined, Descending: true, Limit: 2}
+
+	first, err := QueryNodes(testDirectory(), query)
+	require.NoError(t, err)
+	// Ties on the sort key are broken by peer ID, in the same direction
+	assert.Equal(t, []peer.ID{"node-a", "node-d"}, peerIds(first.Nodes))
+	assert.Equal(t, 4, first.Total)
+	require.NotEmpty(t, first.NextCursor)
+
+	// A node joining before the cursor doesn't shift the next page
+	directory := append(testDirectory(), NodeData{PeerId: peer.ID("node-0"), FirstJoinedUnix: 40})
+	query.Cursor = first.NextCursor
+	second, err := QueryNodes(directory, query)
+	require.NoError(t, err)
+	assert.Equal(t, []peer.ID{"node-c", "node-b"}, peerIds(second.Nodes))
+	assert.Empty(t, second.NextCursor)
+
+	query.Descending = false
+	_, err = QueryNodes(directory, query)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	query.Cursor = "not-a-cursor"
+	_, err = QueryNodes(directory, query)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = QueryNodes(directory, NodeQuery{SortBy: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestReliabilityScore(t *testing.T) {
+	assert.Zero(t, (&NodeData{}).ReliabilityScore())
+	assert.Equal(t, 0.75, (&NodeData{ReturnedTweets: 2, AttestedResults: 1, NotFoundCount: 1}).ReliabilityScore())
+	assert.Zero(t, (&NodeData{AttestationFailures: 3}).ReliabilityScore())
+}
@@ -40,6 +40,22 @@ func (sm *SafeMap) Get(key string) (*NodeData, bool) {
 	return value, ok
 }
 
+// Update replaces the value associated with the specified key with an updated copy, so that the
+// readers of the current value aren't raced. It acquires a write lock, so that concurrent updates
+// aren't lost, and returns false if the key does not exist.
+func (sm *SafeMap) Update(key string, update func(value *NodeData)) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	value, ok := sm.items[key]
+	if !ok {
+		return false
+	}
+	updated := *value
+	update(&updated)
+	sm.items[key] = &updated
+	return true
+}
+
 // Delete removes the item with the specified key from the SafeMap.
 // It acquires a write lock to ensure thread-safety while deleting the item.
 func (sm *SafeMap) Delete(key string) {
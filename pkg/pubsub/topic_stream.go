@@ -0,0 +1,184 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultStreamBuffer is the number of messages buffered for each streaming client.
+const DefaultStreamBuffer = 64
+
+// MaxStreamClients is the maximum number of streaming clients of a topic.
+const MaxStreamClients = 256
+
+// ErrTooManyStreamClients is returned when a topic already has MaxStreamClients streaming clients.
+var ErrTooManyStreamClients = errors.New("too many streaming clients for the topic")
+
+// StreamMessage is a topic message delivered to a streaming client.
+type StreamMessage struct {
+	Topic     string    `json:"topic"`
+	From      string    `json:"from"` // peer ID of the sender
+	Data      string    `json:"data"`
+	Timestamp time.Time `json:"timestamp"` // when this node received the message
+	// Dropped is the number of messages dropped for this client since the previous one,
+	// because the client didn't keep up.
+	Dropped uint64 `json:"dropped,omitempty"`
+}
+
+// StreamClient receives the messages of a topic until it is closed.
+type StreamClient struct {
+	messages chan StreamMessage
+	dropped  uint64 // only accessed by the stream's reader
+	stream   *topicStream
+	once     sync.Once
+}
+
+// Messages returns the channel the client's messages are delivered on. It is closed when the
+// client is closed or the topic subscription ends.
+func (c *StreamClient) Messages() <-chan StreamMessage {
+	return c.messages
+}
+
+// Close stops the delivery of messages to the client. The topic subscription is cancelled
+// when its last client is closed.
+func (c *StreamClient) Close() {
+	c.stream.remove(c)
+}
+
+// topicStream fans the messages of one topic subscription out to its clients. Messages are
+// never blocked on a slow client: they are dropped for that client instead, and counted.
+type topicStream struct {
+	topic   string
+	sub     *pubsub.Subscription
+	cancel  context.CancelFunc
+	clients map[*StreamClient]struct{}
+	mu      sync.Mutex
+	manager *Manager
+}
+
+// Stream subscribes a new client to the messages of the topic, joining it if needed. All the
+// clients of a topic share a single subscription. Each client has a buffer of bufferSize messages,
+// and a topic has at most MaxStreamClients clients.
+func (sm *Manager) Stream(topicName string, bufferSize int) (*StreamClient, error) {
+	if bufferSize <= 0 {
+		bufferSize = DefaultStreamBuffer
+	}
+
+	sm.streamsMu.Lock()
+	defer sm.streamsMu.Unlock()
+	stream, ok := sm.streams[topicName]
+	if !ok {
+		topic, err := sm.topic(topicName)
+		if err != nil {
+			return nil, err
+		}
+		sub, err := topic.Subscribe()
+		if err != nil {
+			return nil, err
+		}
+		ctx, cancel := context.WithCancel(sm.ctx)
+		stream = &topicStream{
+			topic:   topicName,
+			sub:     sub,
+			cancel:  cancel,
+			clients: make(map[*StreamClient]struct{}),
+			manager: sm,
+		}
+		sm.streams[topicName] = stream
+		go stream.run(ctx)
+	}
+
+	client := &StreamClient{messages: make(chan StreamMessage, bufferSize), stream: stream}
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	if len(stream.clients) >= MaxStreamClients {
+		return nil, ErrTooManyStreamClients
+	}
+	stream.clients[client] = struct{}{}
+	return client, nil
+}
+
+// StreamClients returns the number of streaming clients of the topic.
+func (sm *Manager) StreamClients(topicName string) int {
+	sm.streamsMu.Lock()
+	defer sm.streamsMu.Unlock()
+	stream, ok := sm.streams[topicName]
+	if !ok {
+		return 0
+	}
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	return len(stream.clients)
+}
+
+func (s *topicStream) run(ctx context.Context) {
+	defer s.close()
+	for {
+		msg, err := s.sub.Next(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logrus.Errorf("[-] Error reading from streamed topic %s: %v", s.topic, err)
+			}
+			return
+		}
+		message := StreamMessage{
+			Topic:     s.topic,
+			From:      msg.GetFrom().String(),
+			Data:      string(msg.Data),
+			Timestamp: time.Now().UTC(),
+		}
+
+		s.mu.Lock()
+		for client := range s.clients {
+			message.Dropped = client.dropped
+			select {
+			case client.messages <- message:
+				client.dropped = 0
+			default:
+				client.dropped++
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// remove removes a client and cancels the subscription when it was the last one.
+func (s *topicStream) remove(client *StreamClient) {
+	s.manager.streamsMu.Lock()
+	defer s.manager.streamsMu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.clients[client]; ok {
+		delete(s.clients, client)
+		client.once.Do(func() { close(client.messages) })
+	}
+	if len(s.clients) == 0 && s.manager.streams[s.topic] == s {
+		delete(s.manager.streams, s.topic)
+		s.cancel()
+	}
+}
+
+// close ends the subscription and closes the remaining clients.
+func (s *topicStream) close() {
+	s.sub.Cancel()
+
+	s.manager.streamsMu.Lock()
+	if s.manager.streams[s.topic] == s {
+		delete(s.manager.streams, s.topic)
+	}
+	s.manager.streamsMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for client := range s.clients {
+		delete(s.clients, client)
+		client.once.Do(func() { close(client.messages) })
+	}
+	s.cancel()
+}
@@ -0,0 +1,103 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	h, err := libp2p.New(libp2p.NoListenAddrs)
+	require.NoError(t, err)
+	t.Cleanup(func() { h.Close() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	manager, err := NewPubSubManager(ctx, h)
+	require.NoError(t, err)
+	return manager
+}
+
+func receive(t *testing.T, client *StreamClient) StreamMessage {
+	select {
+	case msg, ok := <-client.Messages():
+		require.True(t, ok, "stream closed")
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a message")
+		return StreamMessage{}
+	}
+}
+
+func TestStream(t *testing.T) {
+	manager := newTestManager(t)
+
+	first, err := manager.Stream("chat", 0)
+	require.NoError(t, err)
+	second, err := manager.Stream("chat", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, manager.StreamClients("chat"))
+
+	require.NoError(t, manager.PublishMessage("chat", "hello"))
+	for _, client := range []*StreamClient{first, second} {
+		msg := receive(t, client)
+		assert.Equal(t, "chat", msg.Topic)
+		assert.Equal(t, "hello", msg.Data)
+		assert.Equal(t, manager.host.ID().String(), msg.From)
+		assert.False(t, msg.Timestamp.IsZero())
+	}
+
+	first.Close()
+	first.Close() // closing twice is harmless
+	_, open := <-first.Messages()
+	assert.False(t, open)
+	assert.Equal(t, 1, manager.StreamClients("chat"))
+
+	second.Close()
+	assert.Equal(t, 0, manager.StreamClients("chat"))
+	manager.streamsMu.Lock()
+	assert.Empty(t, manager.streams)
+	manager.streamsMu.Unlock()
+}
+
+func TestStreamBackpressure(t *testing.T) {
+	manager := newTestManager(t)
+
+	slow, err := manager.Stream("chat", 1)
+	require.NoError(t, err)
+	defer slow.Close()
+	fast, err := manager.Stream("chat", 10)
+	require.NoError(t, err)
+	defer fast.Close()
+
+	for _, data := range []string{"1", "2", "3"} {
+		require.NoError(t, manager.PublishMessage("chat", data))
+		// The fast client keeps up, so it receives every message
+		assert.Equal(t, data, receive(t, fast).Data)
+	}
+
+	// The slow client only had room for the first message; the others were dropped
+	assert.Equal(t, "1", receive(t, slow).Data)
+	require.NoError(t, manager.PublishMessage("chat", "4"))
+	msg := receive(t, slow)
+	assert.Equal(t, "4", msg.Data)
+	assert.Equal(t, uint64(2), msg.Dropped)
+	assert.Equal(t, "4", receive(t, fast).Data)
+}
+
+func TestStreamClientLimit(t *testing.T) {
+	manager := newTestManager(t)
+
+	for i := 0; i < MaxStreamClients; i++ {
+		client, err := manager.Stream("chat", 1)
+		require.NoError(t, err)
+		defer client.Close()
+	}
+	_, err := manager.Stream("chat", 1)
+	assert.ErrorIs(t, err, ErrTooManyStreamClients)
+	assert.Equal(t, MaxStreamClients, manager.StreamClients("chat"))
+}
@@ -0,0 +1,214 @@
+// Package ratelimit enforces per-API-key request rates, concurrency and daily work unit
+// quotas, and accounts the work units each key uses.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits are applied to each API key separately. Zero values mean no limit.
+type Limits struct {
+	RequestsPerSecond float64        `json:"requestsPerSecond,omitempty"`
+	Burst             int            `json:"burst,omitempty"` // defaults to RequestsPerSecond rounded up
+	MaxConcurrent     int            `json:"maxConcurrent,omitempty"`
+	DailyUnits        map[string]int `json:"dailyUnits,omitempty"` // per work type, e.g. twitter or web
+}
+
+// Decision is the outcome of a rate limit check, as reported in the RateLimit-* headers.
+// A zero Limit means the request isn't subject to a limit.
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration // until the quota is replenished
+	Window    time.Duration // the period the limit applies to
+}
+
+// Limiter keeps the rate limiting state of every API key.
+type Limiter struct {
+	limits  Limits
+	usage   *UsageStore
+	clients map[string]*client
+	mu      sync.Mutex
+	now     func() time.Time
+}
+
+type client struct {
+	tokens  float64
+	updated time.Time
+	active  int
+	pending map[string]int // work units reserved by in-flight requests, per work type
+}
+
+// New creates a Limiter enforcing the limits and accounting usage in the store.
+func New(limits Limits, usage *UsageStore) *Limiter {
+	if limits.RequestsPerSecond > 0 && limits.Burst <= 0 {
+		limits.Burst = int(math.Ceil(limits.RequestsPerSecond))
+	}
+	return &Limiter{
+		limits:  limits,
+		usage:   usage,
+		clients: make(map[string]*client),
+		now:     time.Now,
+	}
+}
+
+// Limits returns the limits applied to each key.
+func (l *Limiter) Limits() Limits {
+	return l.limits
+}
+
+// Usage returns the usage store.
+func (l *Limiter) Usage() *UsageStore {
+	return l.usage
+}
+
+// Allow takes a request from the key's token bucket.
+func (l *Limiter) Allow(keyID string) Decision {
+	rate := l.limits.RequestsPerSecond
+	if rate <= 0 {
+		return Decision{Allowed: true}
+	}
+	burst := float64(l.limits.Burst)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c := l.client(keyID)
+	now := l.now()
+	if c.updated.IsZero() {
+		c.tokens = burst
+	} else {
+		c.tokens = math.Min(burst, c.tokens+now.Sub(c.updated).Seconds()*rate)
+	}
+	c.updated = now
+
+	d := Decision{
+		Limit:  l.limits.Burst,
+		Window: time.Duration(math.Ceil(burst/rate)) * time.Second,
+	}
+	if c.tokens >= 1 {
+		c.tokens--
+		d.Allowed = true
+	}
+	d.Remaining = int(c.tokens)
+	if d.Allowed {
+		d.Reset = time.Duration((burst - c.tokens) / rate * float64(time.Second))
+	} else {
+		d.Reset = time.Duration((1 - c.tokens) / rate * float64(time.Second))
+	}
+	return d
+}
+
+// Acquire takes one of the key's concurrent request slots. The returned function releases it.
+func (l *Limiter) Acquire(keyID string) (release func(), ok bool) {
+	if l.limits.MaxConcurrent <= 0 {
+		return func() {}, true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c := l.client(keyID)
+	if c.active >= l.limits.MaxConcurrent {
+		return nil, false
+	}
+	c.active++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			c.active--
+			l.mu.Unlock()
+		})
+	}, true
+}
+
+// Reserve reserves a work unit of the key's daily quota for the work type. Every reservation
+// must be followed by a call to Complete once the request is done.
+func (l *Limiter) Reserve(keyID, workType string) (Decision, error) {
+	now := l.now().UTC()
+	day := now.Format(DayFormat)
+	quota := l.limits.DailyUnits[workType]
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c := l.client(keyID)
+	if quota <= 0 {
+		c.pending[workType]++
+		return Decision{Allowed: true}, nil
+	}
+
+	usage, err := l.usage.Get(keyID, workType, day)
+	if err != nil {
+		return Decision{}, err
+	}
+	remaining := quota - int(usage.Units) - c.pending[workType]
+	d := Decision{
+		Limit:  quota,
+		Reset:  now.Truncate(24 * time.Hour).Add(24 * time.Hour).Sub(now),
+		Window: 24 * time.Hour,
+	}
+	if remaining <= 0 {
+		return d, nil
+	}
+	c.pending[workType]++
+	d.Allowed = true
+	d.Remaining = remaining - 1
+	return d, nil
+}
+
+// Complete releases a reservation and accounts the request. A work unit is only charged for
+// successful requests.
+func (l *Limiter) Complete(keyID, workType string, success bool) error {
+	l.mu.Lock()
+	c := l.client(keyID)
+	if c.pending[workType] > 0 {
+		c.pending[workType]--
+	}
+	l.mu.Unlock()
+
+	delta := Usage{KeyID: keyID, WorkType: workType, Day: l.now().UTC().Format(DayFormat), Requests: 1}
+	if success {
+		delta.Units = 1
+	}
+	return l.usage.Add(delta)
+}
+
+// Reject accounts a request rejected because the key's daily quota for the work type was used up.
+func (l *Limiter) Reject(keyID, workType string) error {
+	return l.usage.Add(Usage{KeyID: keyID, WorkType: workType, Day: l.now().UTC().Format(DayFormat), Rejected: 1})
+}
+
+// client returns the state of a key. The caller holds the lock.
+func (l *Limiter) client(keyID string) *client {
+	c, ok := l.clients[keyID]
+	if !ok {
+		c = &client{pending: make(map[string]int)}
+		l.clients[keyID] = c
+	}
+	return c
+}
+
+// ParseDailyUnits parses a comma-separated list of workType=units pairs, e.g. "twitter=1000,web=200".
+func ParseDailyUnits(s string) (map[string]int, error) {
+	result := make(map[string]int)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid daily units entry %q, expected workType=units", entry)
+		}
+		units, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || units < 0 {
+			return nil, fmt.Errorf("invalid daily units for %s: %q", name, value)
+		}
+		result[strings.TrimSpace(name)] = units
+	}
+	return result, nil
+}
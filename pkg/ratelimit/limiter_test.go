@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(limits Limits) (*Limiter, *time.Time) {
+	l := New(limits, NewUsageStore(dssync.MutexWrap(ds.NewMapDatastore())))
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestAllow(t *testing.T) {
+	l, now := newTestLimiter(Limits{RequestsPerSecond: 2, Burst: 3})
+
+	for i := 2; i >= 0; i-- {
+		d := l.Allow("a")
+		require.True(t, d.Allowed)
+		assert.Equal(t, 3, d.Limit)
+		assert.Equal(t, i, d.Remaining)
+	}
+	d := l.Allow("a")
+	assert.False(t, d.Allowed)
+	assert.Equal(t, 500*time.Millisecond, d.Reset)
+
+	// Keys have separate buckets
+	assert.True(t, l.Allow("b").Allowed)
+
+	*now = now.Add(500 * time.Millisecond)
+	assert.True(t, l.Allow("a").Allowed)
+	assert.False(t, l.Allow("a").Allowed)
+
+	unlimited, _ := newTestLimiter(Limits{})
+	d = unlimited.Allow("a")
+	assert.True(t, d.Allowed)
+	assert.Zero(t, d.Limit)
+}
+
+func TestAcquire(t *testing.T) {
+	l, _ := newTestLimiter(Limits{MaxConcurrent: 1})
+
+	release, ok := l.Acquire("a")
+	require.True(t, ok)
+	_, ok = l.Acquire("a")
+	assert.False(t, ok)
+	_, ok = l.Acquire("b")
+	assert.True(t, ok)
+
+	release()
+	release() // releasing twice is harmless
+	_, ok = l.Acquire("a")
+	assert.True(t, ok)
+	_, ok = l.Acquire("a")
+	assert.False(t, ok)
+}
+
+func TestDailyUnits(t *testing.T) {
+	l, now := newTestLimiter(Limits{DailyUnits: map[string]int{"web": 2}})
+
+	d, err := l.Reserve("a", "web")
+	require.NoError(t, err)
+	require.True(t, d.Allowed)
+	assert.Equal(t, 1, d.Remaining)
+	assert.Equal(t, 12*time.Hour, d.Reset)
+	require.NoError(t, l.Complete("a", "web", false)) // failures aren't charged
+
+	for i := 0; i < 2; i++ {
+		d, err = l.Reserve("a", "web")
+		require.NoError(t, err)
+		require.True(t, d.Allowed)
+	}
+	// Both units are reserved by in-flight requests
+	d, err = l.Reserve("a", "web")
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+	require.NoError(t, l.Reject("a", "web"))
+	require.NoError(t, l.Complete("a", "web", true))
+	require.NoError(t, l.Complete("a", "web", true))
+
+	d, err = l.Reserve("a", "web")
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+
+	// Work types without a quota and other keys are not limited
+	d, err = l.Reserve("a", "twitter")
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+	require.NoError(t, l.Complete("a", "twitter", true))
+	d, err = l.Reserve("b", "web")
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+	require.NoError(t, l.Complete("b", "web", true))
+
+	usage, err := l.Usage().Query(UsageFilter{KeyID: "a", WorkType: "web"})
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+	assert.Equal(t, Usage{KeyID: "a", Day: "2024-01-01", WorkType: "web", Requests: 3, Units: 2, Rejected: 1}, usage[0])
+
+	all, err := l.Usage().Query(UsageFilter{From: "2024-01-01", To: "2024-01-01"})
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+	none, err := l.Usage().Query(UsageFilter{From: "2024-01-02"})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	// The quota is replenished the next day
+	*now = now.Add(12 * time.Hour)
+	d, err = l.Reserve("a", "web")
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+}
+
+func TestParseDailyUnits(t *testing.T) {
+	units, err := ParseDailyUnits("twitter=1000, web=200")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"twitter": 1000, "web": 200}, units)
+
+	_, err = ParseDailyUnits("twitter")
+	assert.Error(t, err)
+	_, err = ParseDailyUnits("twitter=-1")
+	assert.Error(t, err)
+}
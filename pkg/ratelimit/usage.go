@@ -0,0 +1,177 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	leveldb "github.com/ipfs/go-ds-leveldb"
+)
+
+const (
+	usagePrefix = "/usage"
+	// DayFormat is the format of the days usage is accounted by, always in UTC.
+	DayFormat = "2006-01-02"
+)
+
+// Usage is the usage of one API key for one work type on one day.
+type Usage struct {
+	KeyID    string `json:"keyId"`
+	Day      string `json:"day"` // YYYY-MM-DD, UTC
+	WorkType string `json:"workType"`
+	Requests int64  `json:"requests"` // requests admitted
+	Units    int64  `json:"units"`    // work units charged, one per successful request
+	Rejected int64  `json:"rejected"` // requests rejected because the daily quota was used up
+}
+
+// UsageFilter selects usage records. Zero values match everything.
+type UsageFilter struct {
+	KeyID    string
+	WorkType string
+	From     string // first day, YYYY-MM-DD
+	To       string // last day, YYYY-MM-DD
+}
+
+// UsageStore accounts the usage of each API key per work type and day.
+// Today's counters are cached in memory; every change is written through to the datastore.
+type UsageStore struct {
+	datastore ds.Datastore
+	day       string
+	today     map[string]*Usage
+	mu        sync.Mutex
+}
+
+// OpenUsageStore opens (or creates) the usage store at the given path.
+func OpenUsageStore(path string) (*UsageStore, error) {
+	datastore, err := leveldb.NewDatastore(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error opening usage store: %w", err)
+	}
+	return NewUsageStore(datastore), nil
+}
+
+// NewUsageStore creates a UsageStore on top of the given datastore.
+func NewUsageStore(datastore ds.Datastore) *UsageStore {
+	return &UsageStore{datastore: datastore, today: make(map[string]*Usage)}
+}
+
+// Close closes the underlying datastore.
+func (s *UsageStore) Close() error {
+	return s.datastore.Close()
+}
+
+// Add adds the requests, units and rejections of delta to the usage of its key, work type and day.
+func (s *UsageStore) Add(delta Usage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	usage, err := s.get(delta.KeyID, delta.WorkType, delta.Day)
+	if err != nil {
+		return err
+	}
+	usage.Requests += delta.Requests
+	usage.Units += delta.Units
+	usage.Rejected += delta.Rejected
+
+	value, err := json.Marshal(usage)
+	if err != nil {
+		return fmt.Errorf("error encoding usage: %w", err)
+	}
+	if err := s.datastore.Put(context.Background(), usageKey(usage.Day, usage.KeyID, usage.WorkType), value); err != nil {
+		return fmt.Errorf("error storing usage: %w", err)
+	}
+	return nil
+}
+
+// Get returns the usage of a key for a work type on a day.
+func (s *UsageStore) Get(keyID, workType, day string) (Usage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	usage, err := s.get(keyID, workType, day)
+	if err != nil {
+		return Usage{}, err
+	}
+	return *usage, nil
+}
+
+// get returns the cached usage of today, or reads the usage of another day. The caller holds the lock.
+func (s *UsageStore) get(keyID, workType, day string) (*Usage, error) {
+	if today := time.Now().UTC().Format(DayFormat); s.day != today {
+		s.day = today
+		s.today = make(map[string]*Usage)
+	}
+	key := usageKey(day, keyID, workType)
+	if day == s.day {
+		if usage, ok := s.today[key.String()]; ok {
+			return usage, nil
+		}
+	}
+
+	usage := &Usage{KeyID: keyID, Day: day, WorkType: workType}
+	value, err := s.datastore.Get(context.Background(), key)
+	switch {
+	case err == ds.ErrNotFound:
+	case err != nil:
+		return nil, fmt.Errorf("error reading usage: %w", err)
+	default:
+		if err := json.Unmarshal(value, usage); err != nil {
+			return nil, fmt.Errorf("error decoding usage %s: %w", key, err)
+		}
+	}
+	if day == s.day {
+		s.today[key.String()] = usage
+	}
+	return usage, nil
+}
+
+// Query returns the usage records matching the filter, ordered by day.
+func (s *UsageStore) Query(filter UsageFilter) ([]Usage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results, err := s.datastore.Query(context.Background(), query.Query{Prefix: usagePrefix})
+	if err != nil {
+		return nil, fmt.Errorf("error querying usage store: %w", err)
+	}
+	defer results.Close()
+
+	records := make([]Usage, 0)
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, fmt.Errorf("error reading usage store: %w", result.Error)
+		}
+		var usage Usage
+		if err := json.Unmarshal(result.Value, &usage); err != nil {
+			return nil, fmt.Errorf("error decoding usage %s: %w", result.Key, err)
+		}
+		if filter.matches(usage) {
+			records = append(records, usage)
+		}
+	}
+	return records, nil
+}
+
+func (f UsageFilter) matches(u Usage) bool {
+	if f.KeyID != "" && u.KeyID != f.KeyID {
+		return false
+	}
+	if f.WorkType != "" && u.WorkType != f.WorkType {
+		return false
+	}
+	// Days in YYYY-MM-DD format compare chronologically as strings
+	if f.From != "" && strings.Compare(u.Day, f.From) < 0 {
+		return false
+	}
+	if f.To != "" && strings.Compare(u.Day, f.To) > 0 {
+		return false
+	}
+	return true
+}
+
+func usageKey(day, keyID, workType string) ds.Key {
+	return ds.NewKey(fmt.Sprintf("%s/%s/%s/%s", usagePrefix, day, keyID, workType))
+}
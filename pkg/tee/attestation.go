@@ -0,0 +1,133 @@
+package tee
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AttestationReport is the evidence that a result was produced inside an enclave. ReportData binds the report to a specific result (see ReportData).
+type AttestationReport struct {
+	Format      string    `json:"format"`
+	Quote       []byte    `json:"quote"`
+	Measurement string    `json:"measurement"`
+	ReportData  []byte    `json:"reportData"`
+	IssuedAt    time.Time `json:"issuedAt"`
+}
+
+// AttestationResult is the outcome of verifying an AttestationReport on the requesting node.
+type AttestationResult struct {
+	Verified    bool      `json:"verified"`
+	Format      string    `json:"format,omitempty"`
+	Measurement string    `json:"measurement,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	VerifiedAt  time.Time `json:"verifiedAt"`
+}
+
+// Attester obtains attestation reports that bind the given report data to an enclave.
+type Attester interface {
+	Attest(reportData []byte) (*AttestationReport, error)
+}
+
+// Verifier checks that an attestation report is genuine, i.e. that the quote was produced
+// by an enclave with the reported measurement over the given report data.
+// Measurement allowlisting is done separately by AttestationVerifier.
+type Verifier interface {
+	Verify(report *AttestationReport, reportData []byte) error
+}
+
+var (
+	verifiers   = map[string]Verifier{}
+	verifiersMu sync.RWMutex
+)
+
+// RegisterVerifier makes a Verifier available under the given name so it can be selected in the configuration.
+func RegisterVerifier(name string, v Verifier) {
+	verifiersMu.Lock()
+	defer verifiersMu.Unlock()
+	verifiers[name] = v
+}
+
+// GetVerifier returns the Verifier registered under the given name.
+func GetVerifier(name string) (Verifier, error) {
+	verifiersMu.RLock()
+	defer verifiersMu.RUnlock()
+	v, ok := verifiers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown attestation verifier %q", name)
+	}
+	return v, nil
+}
+
+// ReportData computes the data a worker binds into its attestation report for a result.
+// It is the SHA-256 of the request ID followed by the JSON encoding of the result data,
+// so a report cannot be replayed for another request or result.
+func ReportData(requestId string, data interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding result data: %w", err)
+	}
+	h := sha256.New()
+	h.Write([]byte(requestId))
+	h.Write(encoded)
+	return h.Sum(nil), nil
+}
+
+// AttestationVerifier verifies attestation reports with a Verifier and checks the
+// reported measurement against an allowlist.
+type AttestationVerifier struct {
+	verifier  Verifier
+	allowlist map[string]struct{}
+}
+
+// NewAttestationVerifier creates an AttestationVerifier. An empty allowlist rejects every report.
+func NewAttestationVerifier(v Verifier, measurements []string) *AttestationVerifier {
+	allowlist := make(map[string]struct{}, len(measurements))
+	for _, m := range measurements {
+		if m = strings.TrimSpace(m); m != "" {
+			allowlist[strings.ToLower(m)] = struct{}{}
+		}
+	}
+	return &AttestationVerifier{verifier: v, allowlist: allowlist}
+}
+
+// Measurements returns the allowlisted measurements.
+func (av *AttestationVerifier) Measurements() []string {
+	result := make([]string, 0, len(av.allowlist))
+	for m := range av.allowlist {
+		result = append(result, m)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Verify checks the report against the expected report data and the measurement allowlist.
+func (av *AttestationVerifier) Verify(report *AttestationReport, reportData []byte) AttestationResult {
+	result := AttestationResult{VerifiedAt: time.Now().UTC()}
+	if report == nil {
+		result.Error = "no attestation report"
+		return result
+	}
+	result.Format = report.Format
+	result.Measurement = report.Measurement
+
+	if !bytes.Equal(report.ReportData, reportData) {
+		result.Error = "report data does not match the result"
+		return result
+	}
+	if _, ok := av.allowlist[strings.ToLower(report.Measurement)]; !ok {
+		result.Error = fmt.Sprintf("measurement %s is not allowlisted", report.Measurement)
+		return result
+	}
+	if err := av.verifier.Verify(report, reportData); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Verified = true
+	return result
+}
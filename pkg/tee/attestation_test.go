@@ -0,0 +1,46 @@
+package tee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftwareAttestation(t *testing.T) {
+	reportData, err := ReportData("request-1", "sealed-result")
+	require.NoError(t, err)
+
+	report, err := SoftwareAttester{}.Attest(reportData)
+	require.NoError(t, err)
+
+	verifier := NewAttestationVerifier(SoftwareVerifier{}, []string{SoftwareMeasurement})
+	result := verifier.Verify(report, reportData)
+	assert.True(t, result.Verified, result.Error)
+	assert.Equal(t, SoftwareMeasurement, result.Measurement)
+
+	t.Run("Result mismatch", func(t *testing.T) {
+		other, err := ReportData("request-1", "another-result")
+		require.NoError(t, err)
+		result := verifier.Verify(report, other)
+		assert.False(t, result.Verified)
+	})
+
+	t.Run("Measurement not allowlisted", func(t *testing.T) {
+		result := NewAttestationVerifier(SoftwareVerifier{}, []string{"genuine-enclave"}).Verify(report, reportData)
+		assert.False(t, result.Verified)
+	})
+
+	t.Run("Tampered quote", func(t *testing.T) {
+		tampered := *report
+		tampered.Quote = append([]byte{}, report.Quote...)
+		tampered.Quote[0] ^= 0xff
+		result := verifier.Verify(&tampered, reportData)
+		assert.False(t, result.Verified)
+	})
+
+	t.Run("Missing report", func(t *testing.T) {
+		result := verifier.Verify(nil, reportData)
+		assert.False(t, result.Verified)
+	})
+}
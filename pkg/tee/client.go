@@ -1,13 +1,97 @@
 package tee
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
 
 	worker "github.com/masa-finance/tee-worker/pkg/client"
 )
 
+// DefaultEndpoint is the name of the decryption endpoint backed by TEE_WORKER_URL.
+const DefaultEndpoint = "default"
+
 var teeWorkerURL = os.Getenv("TEE_WORKER_URL")
 
+// decryptEndpoints maps endpoint names to tee-worker URLs that can be used to decrypt
+// sealed results. It is configured with TEE_DECRYPT_ENDPOINTS as a comma-separated
+// list of name=url pairs, e.g. "eu=http://tee-eu:8080,us=http://tee-us:8080".
+var decryptEndpoints = parseEndpoints(os.Getenv("TEE_DECRYPT_ENDPOINTS"))
+
 func NewClient() *worker.Client {
 	return worker.NewClient(teeWorkerURL)
 }
+
+// ErrNotConfigured is returned when no tee-worker is configured with TEE_WORKER_URL.
+var ErrNotConfigured = errors.New("no tee-worker configured")
+
+// CheckReachable checks that the tee-worker configured with TEE_WORKER_URL answers HTTP requests.
+// Any HTTP response counts as reachable, since the tee-worker has no dedicated health endpoint.
+func CheckReachable(ctx context.Context) error {
+	if teeWorkerURL == "" {
+		return ErrNotConfigured
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, teeWorkerURL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid tee-worker URL: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("tee-worker unreachable: %w", err)
+	}
+	return resp.Body.Close()
+}
+
+// NewDecryptClient returns a client for the named decryption endpoint.
+// An empty name selects the default endpoint (TEE_WORKER_URL).
+func NewDecryptClient(name string) (*worker.Client, error) {
+	url, err := DecryptEndpointURL(name)
+	if err != nil {
+		return nil, err
+	}
+	return worker.NewClient(url), nil
+}
+
+// DecryptEndpointURL resolves the URL of the named decryption endpoint.
+// Only endpoints configured by the node operator can be used, so that API callers
+// cannot make the node send sealed data to arbitrary hosts.
+func DecryptEndpointURL(name string) (string, error) {
+	if name == "" || name == DefaultEndpoint {
+		if teeWorkerURL == "" {
+			return "", fmt.Errorf("no default tee endpoint configured")
+		}
+		return teeWorkerURL, nil
+	}
+	url, ok := decryptEndpoints[name]
+	if !ok {
+		return "", fmt.Errorf("unknown tee endpoint %q", name)
+	}
+	return url, nil
+}
+
+// DecryptEndpoints returns the names of the configured decryption endpoints.
+func DecryptEndpoints() []string {
+	names := make([]string, 0, len(decryptEndpoints)+1)
+	if teeWorkerURL != "" {
+		names = append(names, DefaultEndpoint)
+	}
+	for name := range decryptEndpoints {
+		names = append(names, name)
+	}
+	return names
+}
+
+func parseEndpoints(s string) map[string]string {
+	endpoints := make(map[string]string)
+	for _, entry := range strings.Split(s, ",") {
+		name, url, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || name == "" || url == "" {
+			continue
+		}
+		endpoints[strings.TrimSpace(name)] = strings.TrimSpace(url)
+	}
+	return endpoints
+}
@@ -0,0 +1,325 @@
+package tee
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// SGXFormat is the attestation format of the SGX DCAP quotes served by the tee-worker.
+const SGXFormat = "sgx-dcap"
+
+// Layout of an SGX DCAP quote, version 3, with an ECDSA P-256 attestation key.
+const (
+	quoteHeaderSize     = 48
+	reportBodySize      = 384
+	signedQuoteSize     = quoteHeaderSize + reportBodySize
+	ecdsaSignatureSize  = 64
+	ecdsaPublicKeySize  = 64
+	quoteVersion        = 3
+	attestationKeyECDSA = 2
+	certDataPCKChain    = 5
+
+	// offsets in a report body
+	reportAttributesOffset = 48
+	reportMRENCLAVEOffset  = 64
+	reportDataOffset       = 320
+
+	// debugAttribute is set in the attributes of enclaves whose memory can be inspected.
+	debugAttribute = 0x02
+
+	// Header of the remote reports of Open Enclave, which ego returns before the quote.
+	oeReportHeaderSize = 16
+	oeReportVersion    = 1
+	oeReportTypeRemote = 2
+
+	// maxQuoteSize bounds the reports read from the tee-worker.
+	maxQuoteSize = 64 << 10
+)
+
+// SGXQuote is the content of an SGX DCAP quote needed to verify it.
+type SGXQuote struct {
+	MRENCLAVE  []byte
+	ReportData []byte
+	Debug      bool
+
+	signed            []byte // header and report body, signed with the attestation key
+	signature         []byte
+	attestationKey    []byte
+	qeReport          []byte
+	qeReportSignature []byte
+	qeAuthData        []byte
+	certChain         []*x509.Certificate // PCK certificate first
+}
+
+// ParseSGXQuote parses an SGX DCAP quote, version 3, with an ECDSA P-256 attestation key and the
+// PCK certificate chain as certification data. The Open Enclave header of the remote reports of
+// ego is skipped.
+func ParseSGXQuote(data []byte) (*SGXQuote, error) {
+	if len(data) > oeReportHeaderSize &&
+		binary.LittleEndian.Uint32(data) == oeReportVersion &&
+		binary.LittleEndian.Uint32(data[4:]) == oeReportTypeRemote &&
+		binary.LittleEndian.Uint64(data[8:]) == uint64(len(data)-oeReportHeaderSize) {
+		data = data[oeReportHeaderSize:]
+	}
+	r := quoteReader{data: data}
+	header := r.next(quoteHeaderSize)
+	body := r.next(reportBodySize)
+	signatureDataSize := r.uint32()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if version := binary.LittleEndian.Uint16(header); version != quoteVersion {
+		return nil, fmt.Errorf("unsupported quote version %d", version)
+	}
+	if keyType := binary.LittleEndian.Uint16(header[2:]); keyType != attestationKeyECDSA {
+		return nil, fmt.Errorf("unsupported attestation key type %d", keyType)
+	}
+	if int(signatureDataSize) != len(data)-signedQuoteSize-4 {
+		return nil, fmt.Errorf("invalid quote signature data size %d", signatureDataSize)
+	}
+
+	q := &SGXQuote{
+		MRENCLAVE:  body[reportMRENCLAVEOffset : reportMRENCLAVEOffset+32],
+		ReportData: body[reportDataOffset : reportDataOffset+64],
+		Debug:      body[reportAttributesOffset]&debugAttribute != 0,
+		signed:     data[:signedQuoteSize],
+	}
+	q.signature = r.next(ecdsaSignatureSize)
+	q.attestationKey = r.next(ecdsaPublicKeySize)
+	q.qeReport = r.next(reportBodySize)
+	q.qeReportSignature = r.next(ecdsaSignatureSize)
+	q.qeAuthData = r.next(int(r.uint16()))
+	certDataType := r.uint16()
+	certData := r.next(int(r.uint32()))
+	if r.err != nil {
+		return nil, r.err
+	}
+	if certDataType != certDataPCKChain {
+		return nil, fmt.Errorf("unsupported certification data type %d", certDataType)
+	}
+	for rest := certData; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PCK certificate chain: %w", err)
+		}
+		q.certChain = append(q.certChain, cert)
+	}
+	if len(q.certChain) == 0 {
+		return nil, errors.New("no PCK certificate chain in the quote")
+	}
+	return q, nil
+}
+
+// quoteReader reads the fields of a quote in order, remembering the first error.
+type quoteReader struct {
+	data []byte
+	err  error
+}
+
+func (r *quoteReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > len(r.data) {
+		r.err = errors.New("truncated quote")
+		return nil
+	}
+	field := r.data[:n]
+	r.data = r.data[n:]
+	return field
+}
+
+func (r *quoteReader) uint16() uint16 {
+	if field := r.next(2); field != nil {
+		return binary.LittleEndian.Uint16(field)
+	}
+	return 0
+}
+
+func (r *quoteReader) uint32() uint32 {
+	if field := r.next(4); field != nil {
+		return binary.LittleEndian.Uint32(field)
+	}
+	return 0
+}
+
+// SGXVerifier verifies SGX DCAP quotes: the PCK certificate chain must lead to the Intel SGX root
+// CA, the quoting enclave report must be signed with the PCK key and bind the attestation key, and
+// the enclave report must be signed with the attestation key. Debug enclaves are rejected. The
+// TCB level of the platform isn't checked against Intel's collateral.
+type SGXVerifier struct {
+	roots *x509.CertPool
+	now   func() time.Time
+}
+
+// NewSGXVerifier creates an SGXVerifier trusting the given root CA certificates, in PEM.
+func NewSGXVerifier(rootsPEM []byte) (*SGXVerifier, error) {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(rootsPEM) {
+		return nil, errors.New("no root CA certificate")
+	}
+	return &SGXVerifier{roots: roots, now: time.Now}, nil
+}
+
+// LoadSGXVerifier creates an SGXVerifier trusting the root CA certificates of a PEM file, i.e. the
+// Intel SGX Provisioning Certification Root CA.
+func LoadSGXVerifier(path string) (*SGXVerifier, error) {
+	if path == "" {
+		return nil, errors.New("no SGX root CA certificate configured")
+	}
+	rootsPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the SGX root CA certificate: %w", err)
+	}
+	return NewSGXVerifier(rootsPEM)
+}
+
+// Verify checks that the report is a genuine SGX quote of a production enclave with the reported
+// measurement, over the given report data.
+func (v *SGXVerifier) Verify(report *AttestationReport, data []byte) error {
+	if report.Format != SGXFormat {
+		return fmt.Errorf("unsupported attestation format %q", report.Format)
+	}
+	q, err := ParseSGXQuote(report.Quote)
+	if err != nil {
+		return err
+	}
+	if q.Debug {
+		return errors.New("the quote is from a debug enclave")
+	}
+	if !strings.EqualFold(hex.EncodeToString(q.MRENCLAVE), report.Measurement) {
+		return fmt.Errorf("the quote is from enclave %x, not %s", q.MRENCLAVE, report.Measurement)
+	}
+	if !bytes.Equal(q.ReportData, padReportData(data)) {
+		return errors.New("the quote doesn't bind the report data")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range q.certChain[1:] {
+		intermediates.AddCert(cert)
+	}
+	pck := q.certChain[0]
+	if _, err := pck.Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   v.now(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return fmt.Errorf("invalid PCK certificate: %w", err)
+	}
+	pckKey, ok := pck.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return errors.New("the PCK certificate doesn't have an ECDSA key")
+	}
+	if !verifyECDSA(pckKey, q.qeReport, q.qeReportSignature) {
+		return errors.New("invalid quoting enclave report signature")
+	}
+	binding := sha256.Sum256(append(append([]byte{}, q.attestationKey...), q.qeAuthData...))
+	if !bytes.Equal(q.qeReport[reportDataOffset:reportDataOffset+64], padReportData(binding[:])) {
+		return errors.New("the quoting enclave report doesn't bind the attestation key")
+	}
+	attestationKey := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(q.attestationKey[:32]),
+		Y:     new(big.Int).SetBytes(q.attestationKey[32:]),
+	}
+	if !attestationKey.Curve.IsOnCurve(attestationKey.X, attestationKey.Y) {
+		return errors.New("invalid attestation key")
+	}
+	if !verifyECDSA(attestationKey, q.signed, q.signature) {
+		return errors.New("invalid quote signature")
+	}
+	return nil
+}
+
+// verifyECDSA checks a raw r||s ECDSA P-256 signature over the SHA-256 of the data.
+func verifyECDSA(key *ecdsa.PublicKey, data, signature []byte) bool {
+	digest := sha256.Sum256(data)
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	return ecdsa.Verify(key, digest[:], r, s)
+}
+
+// padReportData returns the 64 bytes of report data of an SGX report carrying the given data.
+func padReportData(data []byte) []byte {
+	padded := make([]byte, 64)
+	copy(padded, data)
+	return padded
+}
+
+// HTTPAttester requests SGX quotes from the tee-worker. The tee-worker must serve the remote
+// reports of its enclave at GET /attestation?reportData=<hex>, as returned by ego's
+// enclave.GetRemoteReport; releases of the tee-worker without this endpoint can't attest results.
+type HTTPAttester struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewAttester returns an HTTPAttester for the tee-worker at TEE_WORKER_URL.
+func NewAttester() (*HTTPAttester, error) {
+	if teeWorkerURL == "" {
+		return nil, ErrNotConfigured
+	}
+	return &HTTPAttester{BaseURL: teeWorkerURL, HTTPClient: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+// Attest asks the tee-worker for a quote over the given report data.
+func (a *HTTPAttester) Attest(data []byte) (*AttestationReport, error) {
+	if a.BaseURL == "" {
+		return nil, ErrNotConfigured
+	}
+	if len(data) > 64 {
+		return nil, fmt.Errorf("report data must be at most 64 bytes")
+	}
+	resp, err := a.HTTPClient.Get(a.BaseURL + "/attestation?reportData=" + url.QueryEscape(hex.EncodeToString(data)))
+	if err != nil {
+		return nil, fmt.Errorf("error sending GET request to /attestation: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error: received status code %d from /attestation", resp.StatusCode)
+	}
+	quote, err := io.ReadAll(io.LimitReader(resp.Body, maxQuoteSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body from /attestation: %w", err)
+	}
+	if len(quote) > maxQuoteSize {
+		return nil, fmt.Errorf("the quote returned by /attestation is larger than %d bytes", maxQuoteSize)
+	}
+
+	q, err := ParseSGXQuote(quote)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quote returned by /attestation: %w", err)
+	}
+	if !bytes.Equal(q.ReportData, padReportData(data)) {
+		return nil, errors.New("the quote returned by /attestation doesn't bind the report data")
+	}
+	return &AttestationReport{
+		Format:      SGXFormat,
+		Quote:       quote,
+		Measurement: hex.EncodeToString(q.MRENCLAVE),
+		ReportData:  data,
+		IssuedAt:    time.Now().UTC(),
+	}, nil
+}
@@ -0,0 +1,197 @@
+package tee
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPlatform issues SGX quotes signed by a test PCK certificate chain.
+type testPlatform struct {
+	rootPEM  []byte
+	chainPEM []byte
+	pckKey   *ecdsa.PrivateKey
+	attKey   *ecdsa.PrivateKey
+}
+
+func newTestPlatform(t *testing.T) *testPlatform {
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	root := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test SGX Root CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	rootDER, err := x509.CreateCertificate(rand.Reader, root, root, &rootKey.PublicKey, rootKey)
+	require.NoError(t, err)
+
+	pckKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pck := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "Test SGX PCK Certificate"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	pckDER, err := x509.CreateCertificate(rand.Reader, pck, root, &pckKey.PublicKey, rootKey)
+	require.NoError(t, err)
+
+	attKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	rootPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rootDER})
+	return &testPlatform{
+		rootPEM:  rootPEM,
+		chainPEM: append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: pckDER}), rootPEM...),
+		pckKey:   pckKey,
+		attKey:   attKey,
+	}
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, data []byte) []byte {
+	digest := sha256.Sum256(data)
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return signature
+}
+
+// quote returns an SGX quote of the enclave over the given report data.
+func (p *testPlatform) quote(t *testing.T, mrenclave []byte, data []byte, debug bool) []byte {
+	header := make([]byte, quoteHeaderSize)
+	binary.LittleEndian.PutUint16(header, quoteVersion)
+	binary.LittleEndian.PutUint16(header[2:], attestationKeyECDSA)
+	body := make([]byte, reportBodySize)
+	if debug {
+		body[reportAttributesOffset] |= debugAttribute
+	}
+	copy(body[reportMRENCLAVEOffset:], mrenclave)
+	copy(body[reportDataOffset:], data)
+	signed := append(header, body...)
+
+	attKey := make([]byte, 64)
+	p.attKey.X.FillBytes(attKey[:32])
+	p.attKey.Y.FillBytes(attKey[32:])
+	qeAuthData := []byte("qe-auth-data")
+	binding := sha256.Sum256(append(append([]byte{}, attKey...), qeAuthData...))
+	qeReport := make([]byte, reportBodySize)
+	copy(qeReport[reportDataOffset:], binding[:])
+
+	var sigData []byte
+	sigData = append(sigData, sign(t, p.attKey, signed)...)
+	sigData = append(sigData, attKey...)
+	sigData = append(sigData, qeReport...)
+	sigData = append(sigData, sign(t, p.pckKey, qeReport)...)
+	sigData = binary.LittleEndian.AppendUint16(sigData, uint16(len(qeAuthData)))
+	sigData = append(sigData, qeAuthData...)
+	sigData = binary.LittleEndian.AppendUint16(sigData, certDataPCKChain)
+	sigData = binary.LittleEndian.AppendUint32(sigData, uint32(len(p.chainPEM)))
+	sigData = append(sigData, p.chainPEM...)
+
+	quote := binary.LittleEndian.AppendUint32(signed, uint32(len(sigData)))
+	return append(quote, sigData...)
+}
+
+func TestSGXAttestation(t *testing.T) {
+	platform := newTestPlatform(t)
+	mrenclave := sha256.Sum256([]byte("tee-worker"))
+	measurement := hex.EncodeToString(mrenclave[:])
+
+	reportData, err := ReportData("request-1", "sealed-result")
+	require.NoError(t, err)
+
+	sgx, err := NewSGXVerifier(platform.rootPEM)
+	require.NoError(t, err)
+	verifier := NewAttestationVerifier(sgx, []string{measurement})
+
+	report := func(quote []byte) *AttestationReport {
+		return &AttestationReport{Format: SGXFormat, Quote: quote, Measurement: measurement, ReportData: reportData}
+	}
+
+	t.Run("Valid quote", func(t *testing.T) {
+		result := verifier.Verify(report(platform.quote(t, mrenclave[:], reportData, false)), reportData)
+		assert.True(t, result.Verified, result.Error)
+		assert.Equal(t, measurement, result.Measurement)
+	})
+
+	t.Run("Result mismatch", func(t *testing.T) {
+		other, err := ReportData("request-1", "another-result")
+		require.NoError(t, err)
+		result := verifier.Verify(report(platform.quote(t, mrenclave[:], reportData, false)), other)
+		assert.False(t, result.Verified)
+	})
+
+	t.Run("Tampered quote", func(t *testing.T) {
+		quote := platform.quote(t, mrenclave[:], reportData, false)
+		quote[quoteHeaderSize+200] ^= 0xff
+		result := verifier.Verify(report(quote), reportData)
+		assert.False(t, result.Verified)
+	})
+
+	t.Run("Another enclave", func(t *testing.T) {
+		other := sha256.Sum256([]byte("another-enclave"))
+		result := verifier.Verify(report(platform.quote(t, other[:], reportData, false)), reportData)
+		assert.False(t, result.Verified)
+	})
+
+	t.Run("Debug enclave", func(t *testing.T) {
+		result := verifier.Verify(report(platform.quote(t, mrenclave[:], reportData, true)), reportData)
+		assert.False(t, result.Verified)
+	})
+
+	t.Run("Untrusted root", func(t *testing.T) {
+		other, err := NewSGXVerifier(newTestPlatform(t).rootPEM)
+		require.NoError(t, err)
+		result := NewAttestationVerifier(other, []string{measurement}).
+			Verify(report(platform.quote(t, mrenclave[:], reportData, false)), reportData)
+		assert.False(t, result.Verified)
+	})
+
+	t.Run("Software report", func(t *testing.T) {
+		software, err := SoftwareAttester{}.Attest(reportData)
+		require.NoError(t, err)
+		result := NewAttestationVerifier(sgx, []string{SoftwareMeasurement}).Verify(software, reportData)
+		assert.False(t, result.Verified)
+	})
+
+	t.Run("Attester", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/attestation", r.URL.Path)
+			data, err := hex.DecodeString(r.URL.Query().Get("reportData"))
+			require.NoError(t, err)
+			quote := platform.quote(t, mrenclave[:], data, false)
+			header := binary.LittleEndian.AppendUint32(nil, oeReportVersion)
+			header = binary.LittleEndian.AppendUint32(header, oeReportTypeRemote)
+			header = binary.LittleEndian.AppendUint64(header, uint64(len(quote)))
+			_, _ = w.Write(append(header, quote...))
+		}))
+		defer server.Close()
+
+		attested, err := (&HTTPAttester{BaseURL: server.URL, HTTPClient: server.Client()}).Attest(reportData)
+		require.NoError(t, err)
+		assert.Equal(t, measurement, attested.Measurement)
+		result := verifier.Verify(attested, reportData)
+		assert.True(t, result.Verified, result.Error)
+	})
+}
@@ -0,0 +1,60 @@
+package tee
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"time"
+)
+
+const (
+	// SoftwareFormat is the attestation format produced by the SoftwareAttester.
+	// It carries no hardware evidence and is only meant for tests and local development.
+	SoftwareFormat = "software"
+	// SoftwareMeasurement is the measurement reported by the SoftwareAttester.
+	SoftwareMeasurement = "software-enclave"
+)
+
+// SoftwareAttester produces software-only attestation reports. The quote is a plain hash,
+// so it proves nothing about the hardware; it exists to exercise the attestation flow in tests.
+type SoftwareAttester struct {
+	Measurement string
+}
+
+// Attest returns a software report over the given report data.
+func (a SoftwareAttester) Attest(reportData []byte) (*AttestationReport, error) {
+	measurement := a.Measurement
+	if measurement == "" {
+		measurement = SoftwareMeasurement
+	}
+	return &AttestationReport{
+		Format:      SoftwareFormat,
+		Quote:       softwareQuote(measurement, reportData),
+		Measurement: measurement,
+		ReportData:  reportData,
+		IssuedAt:    time.Now().UTC(),
+	}, nil
+}
+
+// SoftwareVerifier verifies reports produced by the SoftwareAttester. Anyone can forge these
+// reports, so it isn't registered as a verifier: nodes only use it in development mode.
+type SoftwareVerifier struct{}
+
+// Verify checks that the report is a software report whose quote matches its measurement and report data.
+func (SoftwareVerifier) Verify(report *AttestationReport, reportData []byte) error {
+	if report.Format != SoftwareFormat {
+		return fmt.Errorf("unsupported attestation format %q", report.Format)
+	}
+	if !bytes.Equal(report.Quote, softwareQuote(report.Measurement, reportData)) {
+		return fmt.Errorf("invalid software quote")
+	}
+	return nil
+}
+
+func softwareQuote(measurement string, reportData []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(SoftwareFormat))
+	h.Write([]byte(measurement))
+	h.Write(reportData)
+	return h.Sum(nil)
+}
@@ -15,7 +15,7 @@ import (
 
 var _ = Describe("Blockchain tests", func() {
 	Context("blockchain events", func() {
-		It("contains data published by nodes and propagates correctly", func() {
+		It("imports the blocks published by other validators", func() {
 			ctx := context.Background()
 			ctx, cancel := context.WithCancel(ctx)
 			defer cancel()
@@ -24,7 +24,8 @@ var _ = Describe("Blockchain tests", func() {
 			Expect(err).ToNot(HaveOccurred())
 			defer os.RemoveAll(tempDir)
 
-			blockChainEventTracker := NewBlockChain()
+			// Without a validator set, the first node only accepts its own blocks
+			blockChainEventTracker := NewBlockChain(nil, "")
 
 			// We create two nodes in this test - one publishes data over the blockchain
 			// and the other one should be able to receive the data
@@ -56,7 +57,8 @@ var _ = Describe("Blockchain tests", func() {
 
 			// We start the second node (receives the data)
 			// And we also set the first node as bootstrap node
-			blockChainEventTracker2 := NewBlockChain()
+			// The first node is the only validator allowed to produce blocks
+			blockChainEventTracker2 := NewBlockChain([]string{n.Host.ID().String()}, "")
 			n2, err := NewOracleNode(ctx,
 				EnableStaked,
 				WithBootNodes(bootNodes...),
@@ -70,10 +72,10 @@ var _ = Describe("Blockchain tests", func() {
 			err = n2.Start()
 			Expect(err).ToNot(HaveOccurred())
 
-			// Initially, there shouldn't be any events in the blockchain
-			Eventually(func() int {
-				return len(blockChainEventTracker2.BlockEvents)
-			}, "30s").Should(Equal(0))
+			// Initially, both chains only have the genesis block
+			Eventually(n.Blockchain.Check, "30s").Should(Succeed())
+			Eventually(n2.Blockchain.Check, "30s").Should(Succeed())
+			Expect(n2.Blockchain.LastHash).To(Equal(n.Blockchain.LastHash))
 
 			publishedData := map[string]interface{}{
 				"foo": interface{}("bar"),
@@ -82,17 +84,29 @@ var _ = Describe("Blockchain tests", func() {
 			publishBytes, err := json.Marshal(publishedData)
 			Expect(err).ToNot(HaveOccurred())
 
-			// Eventually we should have at least one event
-			Eventually(func() int {
-				// Publish data with the first node to kick off the first event
-				err = n.PublishTopic(config.BlockTopic, publishBytes)
+			// The first node builds a block on its chain and publishes it
+			block, err := n.Blockchain.BuildBlock(publishBytes, n.Host.Peerstore().PrivKey(n.Host.ID()))
+			Expect(err).ToNot(HaveOccurred())
+			_, err = n.Blockchain.ImportBlock(block)
+			Expect(err).ToNot(HaveOccurred())
+			blockBytes, err := json.Marshal(block)
+			Expect(err).ToNot(HaveOccurred())
+
+			// Eventually the second node imports the block
+			Eventually(func() []byte {
+				err = n.PublishTopic(config.BlockTopic, blockBytes)
 				Expect(err).ToNot(HaveOccurred())
 
-				return len(blockChainEventTracker2.BlockEvents)
-			}, "1m").ShouldNot(Equal(0))
+				last, err := n2.Blockchain.GetLastBlock()
+				Expect(err).ToNot(HaveOccurred())
+				return last.Hash
+			}, "1m").Should(Equal(block.Hash))
 
-			// Check that the event has the data that we published
-			Expect(blockChainEventTracker2.BlockEvents[0]).To(Equal(BlockEvents(publishedData)))
+			// Check that the block has the data that we published
+			last, err := n2.Blockchain.GetLastBlock()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(last.Data).To(Equal(publishBytes))
+			Expect(last.Producer).To(Equal(n.Host.ID().String()))
 		})
 	})
 })
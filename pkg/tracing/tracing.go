@@ -0,0 +1,100 @@
+// Package tracing records OpenTelemetry spans for the requests handled by the node and
+// propagates their context to the workers, so that a request can be followed across nodes.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ServiceName is the service the spans of the node are reported under.
+	ServiceName = "masa-node"
+
+	tracerName = "github.com/masa-finance/masa-oracle"
+)
+
+// Attributes set on the spans of work requests.
+const (
+	RequestIDKey = attribute.Key("masa.request.id")
+	WorkTypeKey  = attribute.Key("masa.work.type")
+	PeerIDKey    = attribute.Key("masa.peer.id")
+	WorkersKey   = attribute.Key("masa.workers")
+	APIKeyIDKey  = attribute.Key("masa.api_key.id")
+)
+
+// ShutdownFunc flushes the spans not exported yet and stops the exporter.
+type ShutdownFunc func(ctx context.Context) error
+
+// Init exports the spans of the node to the OTLP gRPC collector at endpoint, e.g. localhost:4317.
+// With an empty endpoint, spans are not recorded, though trace context received from other nodes
+// is still passed on to the workers.
+func Init(ctx context.Context, endpoint string, insecure bool, peerID, version string) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+	if insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the OTLP exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(version),
+		semconv.ServiceInstanceID(peerID),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("error creating the tracing resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start starts a span as a child of the span in ctx, if any.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// End records err on the span, if not nil, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Inject returns the trace context of ctx as a map, to be sent along with a request.
+// It returns nil when ctx is not part of a trace.
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract returns ctx with the trace context received along with a request.
+func Extract(ctx context.Context, traceContext map[string]string) context.Context {
+	if len(traceContext) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(traceContext))
+}
@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestPropagation(t *testing.T) {
+	shutdown, err := Init(context.Background(), "", false, "peer1", "test")
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, shutdown(context.Background())) }()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+
+	assert.Nil(t, Inject(context.Background()), "no trace context outside of a span")
+
+	ctx, requester := Start(context.Background(), "DistributeWork")
+	traceContext := Inject(ctx)
+	require.Contains(t, traceContext, "traceparent")
+
+	// The worker only receives the map, along with the work request
+	_, worker := Start(Extract(context.Background(), traceContext), "HandleWorkerStream")
+	End(worker, assert.AnError)
+	End(requester, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, requester.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+	assert.Equal(t, requester.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.True(t, spans[0].Parent().IsRemote())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
@@ -0,0 +1,71 @@
+package workers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/masa-finance/masa-oracle/node"
+	"github.com/masa-finance/masa-oracle/pkg/tee"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
+)
+
+// attest attaches an attestation report bound to the response's result, if this node has an attester.
+// A failure to attest is logged but doesn't fail the work; the requester decides whether it accepts
+// results without attestation.
+func (whm *WorkHandlerManager) attest(workRequest data_types.WorkRequest, response *data_types.WorkResponse) {
+	if whm.attester == nil || response.Error != "" {
+		return
+	}
+	reportData, err := tee.ReportData(workRequest.RequestId, response.Data)
+	if err != nil {
+		logrus.Warnf("[-] Unable to compute attestation report data for %s: %v", workRequest.RequestId, err)
+		return
+	}
+	report, err := whm.attester.Attest(reportData)
+	if err != nil {
+		logrus.Warnf("[-] Unable to attest result for %s: %v", workRequest.RequestId, err)
+		return
+	}
+	response.Attestation = report
+}
+
+// verifyAttestation verifies the attestation report of a result returned by a remote worker, records
+// the outcome in the response and in the worker's reliability data, and returns an error if the result
+// must be rejected. Without a configured verifier every result is accepted unverified.
+func (whm *WorkHandlerManager) verifyAttestation(node *node.OracleNode, worker data_types.Worker, workRequest data_types.WorkRequest, response *data_types.WorkResponse) error {
+	// Never trust a verification result sent by the worker itself
+	response.AttestationResult = nil
+	if whm.attestationVerifier == nil {
+		return nil
+	}
+
+	reportData, err := tee.ReportData(workRequest.RequestId, response.Data)
+	if err != nil {
+		return fmt.Errorf("unable to compute attestation report data: %v", err)
+	}
+	result := whm.attestationVerifier.Verify(response.Attestation, reportData)
+	response.AttestationResult = &result
+
+	peerId := worker.NodeData.PeerId.String()
+	if err := node.NodeTracker.UpdateNodeDataAttestation(peerId, result.Verified); err != nil {
+		logrus.Warnf("Failed to update node data for peer %s: %v", peerId, err)
+	}
+	if result.Verified {
+		return nil
+	}
+	logrus.Warnf("[-] Attestation of result from worker %s failed verification: %s", peerId, result.Error)
+	if whm.requireAttestation {
+		return fmt.Errorf("attestation verification failed: %s", result.Error)
+	}
+	return nil
+}
+
+// deprioritizeAttestationFailures moves workers with attestation failures behind the others,
+// the most recent failures last, while keeping the existing order otherwise.
+func deprioritizeAttestationFailures(workers []data_types.Worker) {
+	sort.SliceStable(workers, func(i, j int) bool {
+		return workers[i].NodeData.LastAttestationFailure.Before(workers[j].NodeData.LastAttestationFailure)
+	})
+}
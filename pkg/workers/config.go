@@ -15,6 +15,10 @@ type WorkerConfig struct {
 	MaxSpawnAttempts      int
 	WorkerBufferSize      int
 	MaxRemoteWorkers      int
+	LatencyProbeTimeout   time.Duration
+	LatencyProbeInterval  time.Duration
+	LatencyScale          time.Duration
+	LatencyWeight         float64
 }
 
 var DefaultConfig = WorkerConfig{
@@ -26,6 +30,10 @@ var DefaultConfig = WorkerConfig{
 	MaxSpawnAttempts:      1,
 	WorkerBufferSize:      100,
 	MaxRemoteWorkers:      10,
+	LatencyProbeTimeout:   500 * time.Millisecond,
+	LatencyProbeInterval:  1 * time.Minute,
+	LatencyScale:          1 * time.Second,
+	LatencyWeight:         1.0,
 }
 
 var workerConfig *WorkerConfig
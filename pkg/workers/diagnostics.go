@@ -0,0 +1,100 @@
+package workers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/masa-finance/masa-oracle/node"
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+)
+
+// CategoryDiagnostics describes the eligible remote workers for a category along with their latency data.
+type CategoryDiagnostics struct {
+	Category   string          `json:"category"`
+	MaxLatency time.Duration   `json:"maxLatency,omitempty"`
+	Workers    []WorkerLatency `json:"workers"`
+}
+
+// HandlerDiagnostics describes the execution metrics of a local work handler.
+type HandlerDiagnostics struct {
+	WorkType     string        `json:"workType"`
+	CallCount    int64         `json:"callCount"`
+	TotalRuntime time.Duration `json:"totalRuntime"`
+}
+
+// WorkerDiagnostics is the diagnostic view of worker selection on this node.
+type WorkerDiagnostics struct {
+	Categories []CategoryDiagnostics `json:"categories"`
+	Handlers   []HandlerDiagnostics  `json:"handlers"`
+}
+
+// GetWorkerDiagnostics returns the latency data of the eligible remote workers for every
+// category, together with the metrics of the work handlers registered on this node.
+// It does not trigger any probes; it only reports what is already known.
+func (whm *WorkHandlerManager) GetWorkerDiagnostics(node *node.OracleNode) WorkerDiagnostics {
+	diagnostics := WorkerDiagnostics{
+		Categories: make([]CategoryDiagnostics, 0),
+		Handlers:   make([]HandlerDiagnostics, 0),
+	}
+
+	for _, category := range latencyCategories {
+		peers := remoteWorkerPeers(node, category)
+		diagnostics.Categories = append(diagnostics.Categories, CategoryDiagnostics{
+			Category:   category.String(),
+			MaxLatency: whm.latency.MaxLatency(category),
+			Workers:    whm.latency.Diagnostics(node.Host, category, peers),
+		})
+	}
+
+	whm.mu.RLock()
+	defer whm.mu.RUnlock()
+	for wType, info := range whm.handlers {
+		diagnostics.Handlers = append(diagnostics.Handlers, HandlerDiagnostics{
+			WorkType:     string(wType),
+			CallCount:    info.CallCount,
+			TotalRuntime: info.TotalRuntime,
+		})
+	}
+	sort.Slice(diagnostics.Handlers, func(i, j int) bool {
+		return diagnostics.Handlers[i].WorkType < diagnostics.Handlers[j].WorkType
+	})
+
+	return diagnostics
+}
+
+// latencyCategories are the worker categories whose remote workers are probed and reported.
+var latencyCategories = []pubsub.WorkerCategory{pubsub.CategoryTwitter, pubsub.CategoryWeb}
+
+// remoteWorkerPeers returns the IDs of the eligible workers for the category, excluding this node.
+func remoteWorkerPeers(node *node.OracleNode, category pubsub.WorkerCategory) []peer.ID {
+	peers := make([]peer.ID, 0)
+	for _, nd := range node.NodeTracker.GetEligibleWorkerNodes(category) {
+		if nd.PeerId == node.Host.ID() {
+			continue
+		}
+		peers = append(peers, nd.PeerId)
+	}
+	return peers
+}
+
+// StartLatencyProbes periodically measures the RTT to every eligible remote worker, so that
+// work distribution can rank workers without pinging them. It blocks until the context is
+// cancelled.
+func (whm *WorkHandlerManager) StartLatencyProbes(ctx context.Context, node *node.OracleNode) {
+	whm.latency.StartProbing(ctx, node.Host, func() []peer.ID {
+		seen := make(map[peer.ID]bool)
+		peers := make([]peer.ID, 0)
+		for _, category := range latencyCategories {
+			for _, p := range remoteWorkerPeers(node, category) {
+				if !seen[p] {
+					seen[p] = true
+					peers = append(peers, p)
+				}
+			}
+		}
+		return peers
+	})
+}
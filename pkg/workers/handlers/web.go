@@ -1,41 +1,77 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/masa-finance/masa-oracle/pkg/tee"
-	"github.com/masa-finance/masa-oracle/pkg/utils"
+	"github.com/masa-finance/masa-oracle/pkg/workers/policy"
 	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
 	types "github.com/masa-finance/tee-worker/api/types"
 )
 
+const (
+	// policyCheckTimeout bounds the DNS lookups and robots.txt fetch of the content policy check.
+	policyCheckTimeout = 10 * time.Second
+	// policyMaxDepth is the depth of the requests when the operator configured content policy rules:
+	// only the requested page is checked, the tee-worker doesn't apply them to the pages it follows.
+	policyMaxDepth = 1
+)
+
 // WebHandler - All the web handlers implement the WorkHandler interface.
-type WebHandler struct{}
+// Policy is the operator's content policy, consulted before anything is fetched.
+type WebHandler struct {
+	Policy *policy.Policy
+}
 
 func (h *WebHandler) HandleWork(data []byte) data_types.WorkResponse {
 	logrus.Infof("[+] WebHandler %s", data)
 	client := tee.NewClient()
 
-	dataMap, err := utils.BytesToMap(data)
-	if err != nil {
+	var request data_types.WebRequest
+	if err := json.Unmarshal(data, &request); err != nil {
 		return data_types.WorkResponse{Error: fmt.Sprintf("unable to parse web data: %v", err)}
 	}
-	depth := int(dataMap["depth"].(float64))
+	// The request comes from another node, so validate it again
+	if err := request.Validate(); err != nil {
+		return data_types.WorkResponse{Error: fmt.Sprintf("invalid web request: %v", err)}
+	}
+
+	var limiter *policy.ResponseLimiter
+	if h.Policy != nil {
+		if h.Policy.HasRules() && request.Depth > policyMaxDepth {
+			return data_types.WorkResponse{Error: fmt.Sprintf("invalid web request: depth must be at most %d, only the requested page is checked by the content policy", policyMaxDepth)}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), policyCheckTimeout)
+		err := h.Policy.CheckURL(ctx, request.Url)
+		cancel()
+		if err != nil {
+			logrus.Warnf("[-] WebHandler rejected %s: %v", request.Url, err)
+			return data_types.WorkResponse{Error: err.Error()}
+		}
+		// Stop reading the result past the maximum response size
+		limiter = h.Policy.NewResponseLimiter(client.HTTPClient.Transport)
+		client.HTTPClient.Transport = limiter
+	}
 
 	res, err := client.SubmitJob(types.Job{
-		Type: "web-scraper",
-		Arguments: map[string]interface{}{
-			"url":   dataMap["url"].(string),
-			"depth": depth,
-		},
+		Type:      "web-scraper",
+		Arguments: request.Arguments(),
 	})
 	if err != nil {
 		return data_types.WorkResponse{Error: fmt.Sprintf("unable to parse web query data: %v", err)}
 	}
 
 	result, err := res.Get()
+	if limiter != nil {
+		if err := limiter.Err(); err != nil {
+			return data_types.WorkResponse{Error: err.Error()}
+		}
+	}
 	if err != nil {
 		return data_types.WorkResponse{Error: fmt.Sprintf("unable to parse twitter query data: %v", err)}
 	}
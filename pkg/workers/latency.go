@@ -0,0 +1,256 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
+	"github.com/sirupsen/logrus"
+
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
+)
+
+// WorkerLatency holds the latency information known for a single worker.
+type WorkerLatency struct {
+	PeerId     string        `json:"peerId"`
+	EWMA       time.Duration `json:"ewma"`
+	LastRTT    time.Duration `json:"lastRtt,omitempty"`
+	LastProbe  time.Time     `json:"lastProbe,omitempty"`
+	LastError  string        `json:"lastError,omitempty"`
+	WithinMax  bool          `json:"withinMax"`
+	MaxLatency time.Duration `json:"maxLatency,omitempty"`
+}
+
+type probeResult struct {
+	rtt  time.Duration
+	at   time.Time
+	err  string
+	peer peer.ID
+}
+
+// LatencyTracker measures the round-trip time to candidate workers and uses it to
+// rank them. It relies on the peerstore's LatencyEWMA, which is kept fresh by the
+// periodic pings of StartProbing.
+type LatencyTracker struct {
+	maxLatency map[pubsub.WorkerCategory]time.Duration
+	probes     map[peer.ID]probeResult
+	mu         sync.RWMutex
+}
+
+// NewLatencyTracker creates a LatencyTracker with the given maximum acceptable RTT per category.
+// A category without an entry (or with a zero value) has no latency limit.
+func NewLatencyTracker(maxLatency map[pubsub.WorkerCategory]time.Duration) *LatencyTracker {
+	limits := make(map[pubsub.WorkerCategory]time.Duration, len(maxLatency))
+	for category, d := range maxLatency {
+		limits[category] = d
+	}
+	return &LatencyTracker{
+		maxLatency: limits,
+		probes:     make(map[peer.ID]probeResult),
+	}
+}
+
+// MaxLatency returns the maximum acceptable RTT for the category, or 0 if there is no limit.
+func (lt *LatencyTracker) MaxLatency(category pubsub.WorkerCategory) time.Duration {
+	if lt == nil {
+		return 0
+	}
+	return lt.maxLatency[category]
+}
+
+// Latency returns the best known RTT for the peer. It returns 0 if the latency is unknown.
+func (lt *LatencyTracker) Latency(h host.Host, p peer.ID) time.Duration {
+	if ewma := h.Peerstore().LatencyEWMA(p); ewma > 0 {
+		return ewma
+	}
+	lt.mu.RLock()
+	defer lt.mu.RUnlock()
+	return lt.probes[p].rtt
+}
+
+// Probe pings the given peers concurrently, each bounded by the configured probe timeout,
+// and records the outcome. Successful pings are also recorded in the peerstore by libp2p.
+func (lt *LatencyTracker) Probe(h host.Host, peers []peer.ID) {
+	var wg sync.WaitGroup
+	for _, p := range peers {
+		wg.Add(1)
+		go func(p peer.ID) {
+			defer wg.Done()
+			lt.record(pingPeer(h, p, workerConfig.LatencyProbeTimeout))
+		}(p)
+	}
+	wg.Wait()
+}
+
+// StartProbing probes the peers returned by the peers function right away and then once
+// every configured probe interval, until the context is cancelled. It blocks, so it is
+// meant to run in its own goroutine.
+func (lt *LatencyTracker) StartProbing(ctx context.Context, h host.Host, peers func() []peer.ID) {
+	ticker := time.NewTicker(workerConfig.LatencyProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		lt.Probe(h, peers())
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (lt *LatencyTracker) record(res probeResult) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	if res.err != "" {
+		// Keep the last good RTT so a single failed ping doesn't make a worker look unmeasured
+		res.rtt = lt.probes[res.peer].rtt
+	}
+	lt.probes[res.peer] = res
+}
+
+// pingPeer sends a single ping to the peer and returns the outcome.
+func pingPeer(h host.Host, p peer.ID, timeout time.Duration) probeResult {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	res := probeResult{peer: p, at: time.Now()}
+	select {
+	case r, ok := <-ping.Ping(ctx, h, p):
+		if !ok {
+			res.err = "ping channel closed"
+		} else if r.Error != nil {
+			res.err = r.Error.Error()
+		} else {
+			res.rtt = r.RTT
+		}
+	case <-ctx.Done():
+		res.err = ctx.Err().Error()
+	}
+	if res.err != "" {
+		logrus.Debugf("[-] Latency probe to %s failed: %s", p, res.err)
+	}
+	return res
+}
+
+// Rank drops the given remote workers whose known RTT exceeds the maximum for the
+// category and orders the rest by a score that combines their current position (which
+// reflects reliability or round-robin order) with their measured latency.
+// Workers with unknown latency are kept, but scored as if they were at the limit.
+// Rank never pings, it only uses the latency measured so far.
+func (lt *LatencyTracker) Rank(h host.Host, category pubsub.WorkerCategory, workers []data_types.Worker) []data_types.Worker {
+	if lt == nil || len(workers) == 0 {
+		return workers
+	}
+
+	maxLatency := lt.MaxLatency(category)
+	scale := maxLatency
+	if scale <= 0 {
+		scale = workerConfig.LatencyScale
+	}
+
+	type scored struct {
+		worker data_types.Worker
+		score  float64
+	}
+	candidates := make([]scored, 0, len(workers))
+	for i, w := range workers {
+		rtt := lt.Latency(h, w.NodeData.PeerId)
+		if maxLatency > 0 && rtt > maxLatency {
+			logrus.Infof("[-] Skipping worker %s: RTT %s exceeds maximum %s for %s", w.NodeData.PeerId, rtt, maxLatency, category)
+			continue
+		}
+		candidates = append(candidates, scored{worker: w, score: latencyScore(i, len(workers), rtt, scale)})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score < candidates[j].score
+	})
+
+	ranked := make([]data_types.Worker, 0, len(candidates))
+	for _, c := range candidates {
+		ranked = append(ranked, c.worker)
+	}
+	logrus.Infof("Ranked %d of %d workers by latency for category: %s", len(ranked), len(workers), category)
+	return ranked
+}
+
+// latencyScore returns the selection score for a worker, lower is better. The position term
+// is in [0, 1) and the latency term is in [0, LatencyWeight], with unknown latency counting
+// as the full weight.
+func latencyScore(position, total int, rtt, scale time.Duration) float64 {
+	positionScore := float64(position) / float64(total)
+	latencyRatio := 1.0
+	if rtt > 0 && scale > 0 {
+		latencyRatio = float64(rtt) / float64(scale)
+		if latencyRatio > 1 {
+			latencyRatio = 1
+		}
+	}
+	return positionScore + workerConfig.LatencyWeight*latencyRatio
+}
+
+// Diagnostics returns the latency data known for each of the given peers in the category.
+func (lt *LatencyTracker) Diagnostics(h host.Host, category pubsub.WorkerCategory, peers []peer.ID) []WorkerLatency {
+	maxLatency := lt.MaxLatency(category)
+	result := make([]WorkerLatency, 0, len(peers))
+	for _, p := range peers {
+		lt.mu.RLock()
+		probe := lt.probes[p]
+		lt.mu.RUnlock()
+		rtt := lt.Latency(h, p)
+		result = append(result, WorkerLatency{
+			PeerId:     p.String(),
+			EWMA:       h.Peerstore().LatencyEWMA(p),
+			LastRTT:    probe.rtt,
+			LastProbe:  probe.at,
+			LastError:  probe.err,
+			WithinMax:  maxLatency <= 0 || rtt <= maxLatency,
+			MaxLatency: maxLatency,
+		})
+	}
+	return result
+}
+
+// ParseMaxLatencies parses a comma-separated list of category=duration pairs,
+// e.g. "twitter=300ms,web=1s", into a map of maximum RTT per worker category.
+func ParseMaxLatencies(s string) (map[pubsub.WorkerCategory]time.Duration, error) {
+	result := make(map[pubsub.WorkerCategory]time.Duration)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid max latency entry %q, expected category=duration", entry)
+		}
+		category, err := ParseWorkerCategory(name)
+		if err != nil {
+			return nil, err
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid max latency for %s: %w", name, err)
+		}
+		result[category] = d
+	}
+	return result, nil
+}
+
+// ParseWorkerCategory converts a category name such as "twitter" into a WorkerCategory.
+func ParseWorkerCategory(name string) (pubsub.WorkerCategory, error) {
+	for _, category := range []pubsub.WorkerCategory{pubsub.CategoryDiscord, pubsub.CategoryTelegram, pubsub.CategoryTwitter, pubsub.CategoryWeb} {
+		if strings.EqualFold(strings.TrimSpace(name), category.String()) {
+			return category, nil
+		}
+	}
+	return -1, fmt.Errorf("unknown worker category %q", name)
+}
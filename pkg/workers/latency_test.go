@@ -0,0 +1,46 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+)
+
+func TestParseMaxLatencies(t *testing.T) {
+	limits, err := ParseMaxLatencies("twitter=300ms, Web=1s")
+	require.NoError(t, err)
+	assert.Equal(t, 300*time.Millisecond, limits[pubsub.CategoryTwitter])
+	assert.Equal(t, time.Second, limits[pubsub.CategoryWeb])
+
+	limits, err = ParseMaxLatencies("")
+	require.NoError(t, err)
+	assert.Empty(t, limits)
+
+	_, err = ParseMaxLatencies("twitter")
+	assert.Error(t, err)
+
+	_, err = ParseMaxLatencies("unknown=1s")
+	assert.Error(t, err)
+
+	_, err = ParseMaxLatencies("web=fast")
+	assert.Error(t, err)
+}
+
+func TestLatencyScore(t *testing.T) {
+	scale := time.Second
+
+	// A nearby worker further down the list beats a distant worker at the top
+	near := latencyScore(3, 10, 20*time.Millisecond, scale)
+	far := latencyScore(0, 10, 900*time.Millisecond, scale)
+	assert.Less(t, near, far)
+
+	// With equal latency the original order is preserved
+	assert.Less(t, latencyScore(0, 10, 100*time.Millisecond, scale), latencyScore(1, 10, 100*time.Millisecond, scale))
+
+	// Unknown latency counts as the full latency weight
+	assert.Equal(t, latencyScore(0, 10, 2*scale, scale), latencyScore(0, 10, 0, scale))
+}
@@ -1,10 +1,25 @@
 package workers
 
+import (
+	"time"
+
+	"github.com/masa-finance/masa-oracle/pkg/audit"
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+	"github.com/masa-finance/masa-oracle/pkg/tee"
+	"github.com/masa-finance/masa-oracle/pkg/workers/policy"
+)
+
 type WorkerOption struct {
 	isTwitterWorker        bool
 	isWebScraperWorker     bool
 	isDiscordScraperWorker bool
 	masaDir                string
+	maxLatency             map[pubsub.WorkerCategory]time.Duration
+	attester               tee.Attester
+	attestationVerifier    *tee.AttestationVerifier
+	requireAttestation     bool
+	contentPolicy          *policy.Policy
+	auditStore             *audit.Store
 }
 
 type WorkerOptionFunc func(*WorkerOption)
@@ -27,6 +42,47 @@ func WithMasaDir(dir string) WorkerOptionFunc {
 	}
 }
 
+// WithMaxWorkerLatency sets the maximum acceptable round-trip time to remote workers of the given category.
+func WithMaxWorkerLatency(category pubsub.WorkerCategory, d time.Duration) WorkerOptionFunc {
+	return func(o *WorkerOption) {
+		if o.maxLatency == nil {
+			o.maxLatency = make(map[pubsub.WorkerCategory]time.Duration)
+		}
+		o.maxLatency[category] = d
+	}
+}
+
+// WithAttester makes the node attach an attestation report from the given attester to the results it returns to other nodes.
+func WithAttester(attester tee.Attester) WorkerOptionFunc {
+	return func(o *WorkerOption) {
+		o.attester = attester
+	}
+}
+
+// WithAttestationVerifier makes the node verify the attestation reports of results returned by remote workers.
+// If required is true, results without a verified attestation are rejected and the next worker is tried.
+func WithAttestationVerifier(verifier *tee.AttestationVerifier, required bool) WorkerOptionFunc {
+	return func(o *WorkerOption) {
+		o.attestationVerifier = verifier
+		o.requireAttestation = required
+	}
+}
+
+// WithContentPolicy sets the content policy the work handlers consult before fetching anything for other peers.
+// Without it, a default policy that only blocks private and local addresses is used.
+func WithContentPolicy(p *policy.Policy) WorkerOptionFunc {
+	return func(o *WorkerOption) {
+		o.contentPolicy = p
+	}
+}
+
+// WithAuditStore records every work request the node originates or executes in the given audit store.
+func WithAuditStore(store *audit.Store) WorkerOptionFunc {
+	return func(o *WorkerOption) {
+		o.auditStore = store
+	}
+}
+
 func (a *WorkerOption) Apply(opts ...WorkerOptionFunc) {
 	for _, opt := range opts {
 		opt(a)
@@ -0,0 +1,70 @@
+package policy
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+)
+
+// ResponseLimiter is an http.RoundTripper that stops reading response bodies past the maximum
+// response size of the policy, so oversized results are never held in memory. The truncated
+// bodies end normally; Err reports whether any of them was cut.
+type ResponseLimiter struct {
+	transport http.RoundTripper
+	max       int
+	exceeded  atomic.Bool
+}
+
+// NewResponseLimiter wraps transport, or http.DefaultTransport if it is nil, with the maximum
+// response size of the policy.
+func (p *Policy) NewResponseLimiter(transport http.RoundTripper) *ResponseLimiter {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &ResponseLimiter{transport: transport, max: p.MaxResponseSize}
+}
+
+func (l *ResponseLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := l.transport.RoundTrip(req)
+	if err != nil || l.max <= 0 {
+		return resp, err
+	}
+	resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: int64(l.max), limiter: l}
+	return resp, nil
+}
+
+// Err returns a *PolicyError if a response was larger than the maximum response size.
+func (l *ResponseLimiter) Err() error {
+	if !l.exceeded.Load() {
+		return nil
+	}
+	return &PolicyError{
+		Err:    ErrResponseTooLarge,
+		Target: fmt.Sprintf("more than %d bytes", l.max),
+		Detail: fmt.Sprintf("maximum is %d bytes", l.max),
+	}
+}
+
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	limiter   *ResponseLimiter
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, io.EOF
+	}
+	// Read at most one byte past the limit, to tell a body of exactly the maximum size apart
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		b.limiter.exceeded.Store(true)
+		return n - 1, io.EOF
+	}
+	return n, err
+}
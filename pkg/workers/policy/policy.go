@@ -0,0 +1,340 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Sentinel errors returned (wrapped in a *PolicyError) when the policy rejects a request.
+// Use errors.Is to check the reason of a rejection.
+var (
+	ErrInvalidURL           = errors.New("invalid URL")
+	ErrDomainDenied         = errors.New("domain is denied")
+	ErrDomainNotAllowed     = errors.New("domain is not allowed")
+	ErrAddressDenied        = errors.New("address is denied")
+	ErrPrivateAddress       = errors.New("private or local address")
+	ErrRobotsDisallowed     = errors.New("disallowed by robots.txt")
+	ErrResponseTooLarge     = errors.New("response too large")
+	ErrUnresolvableHostname = errors.New("unable to resolve hostname")
+)
+
+// PolicyError is returned when the content policy rejects a request or a response.
+type PolicyError struct {
+	Err    error  // one of the sentinel errors above
+	Target string // the URL, host or address that was rejected
+	Detail string
+}
+
+func (e *PolicyError) Error() string {
+	msg := fmt.Sprintf("content policy: %s: %s", e.Err, e.Target)
+	if e.Detail != "" {
+		msg += " (" + e.Detail + ")"
+	}
+	return msg
+}
+
+func (e *PolicyError) Unwrap() error {
+	return e.Err
+}
+
+// IsPolicyError reports whether err is a content policy rejection.
+func IsPolicyError(err error) bool {
+	var policyErr *PolicyError
+	return errors.As(err, &policyErr)
+}
+
+// privateRanges are the networks that are blocked unless AllowPrivate is set: loopback,
+// RFC 1918, carrier-grade NAT, link-local (including cloud metadata endpoints), unique
+// local IPv6 and other non-routable ranges.
+var privateRanges = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+// Policy is the worker operator's content policy. Handlers consult it before fetching
+// anything on behalf of other peers.
+type Policy struct {
+	// AllowDomains, if not empty, restricts requests to these domains and their subdomains.
+	AllowDomains []string
+	// DenyDomains rejects requests to these domains and their subdomains. It takes precedence over AllowDomains.
+	DenyDomains []string
+	// AllowNetworks, if not empty, restricts requests to hosts resolving into these networks.
+	AllowNetworks []*net.IPNet
+	// DenyNetworks rejects requests to hosts resolving into these networks.
+	DenyNetworks []*net.IPNet
+	// AllowPrivate allows requests to private, loopback and link-local addresses.
+	AllowPrivate bool
+	// RespectRobots makes the policy check robots.txt before a page is fetched.
+	RespectRobots bool
+	// MaxResponseSize is the maximum size of a result in bytes, 0 means no limit.
+	MaxResponseSize int
+
+	resolver func(ctx context.Context, host string) ([]net.IP, error)
+	robots   *robotsCache
+}
+
+// Option configures a Policy.
+type Option func(*Policy)
+
+// WithAllowDomains restricts requests to the given domains and their subdomains.
+func WithAllowDomains(domains ...string) Option {
+	return func(p *Policy) {
+		p.AllowDomains = append(p.AllowDomains, normalizeDomains(domains)...)
+	}
+}
+
+// WithDenyDomains rejects requests to the given domains and their subdomains.
+func WithDenyDomains(domains ...string) Option {
+	return func(p *Policy) {
+		p.DenyDomains = append(p.DenyDomains, normalizeDomains(domains)...)
+	}
+}
+
+// WithAllowNetworks restricts requests to hosts resolving into the given networks.
+func WithAllowNetworks(networks ...*net.IPNet) Option {
+	return func(p *Policy) {
+		p.AllowNetworks = append(p.AllowNetworks, networks...)
+	}
+}
+
+// WithDenyNetworks rejects requests to hosts resolving into the given networks.
+func WithDenyNetworks(networks ...*net.IPNet) Option {
+	return func(p *Policy) {
+		p.DenyNetworks = append(p.DenyNetworks, networks...)
+	}
+}
+
+// AllowPrivateAddresses allows requests to private, loopback and link-local addresses.
+var AllowPrivateAddresses = func(p *Policy) {
+	p.AllowPrivate = true
+}
+
+// RespectRobots makes the policy check robots.txt before a page is fetched.
+var RespectRobots = func(p *Policy) {
+	p.RespectRobots = true
+}
+
+// WithMaxResponseSize sets the maximum size of a result in bytes.
+func WithMaxResponseSize(size int) Option {
+	return func(p *Policy) {
+		p.MaxResponseSize = size
+	}
+}
+
+// WithResolver replaces the DNS resolver used to check the addresses of a host.
+func WithResolver(resolver func(ctx context.Context, host string) ([]net.IP, error)) Option {
+	return func(p *Policy) {
+		p.resolver = resolver
+	}
+}
+
+// New creates a Policy. Without options it only blocks private and local addresses.
+func New(opts ...Option) *Policy {
+	p := &Policy{
+		resolver: func(ctx context.Context, host string) ([]net.IP, error) {
+			return net.DefaultResolver.LookupIP(ctx, "ip", host)
+		},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.robots = newRobotsCache(time.Hour, p.CheckIP)
+	return p
+}
+
+// CheckURL checks whether the given URL may be fetched. robots.txt is checked with the agent of
+// the node, never one chosen by the requester. It returns a *PolicyError if the policy rejects it.
+func (p *Policy) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return &PolicyError{Err: ErrInvalidURL, Target: rawURL}
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+
+	if err := p.checkDomain(host); err != nil {
+		return err
+	}
+	if err := p.checkHost(ctx, host); err != nil {
+		return err
+	}
+	if p.RespectRobots {
+		allowed, err := p.robots.allowed(ctx, u)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return &PolicyError{Err: ErrRobotsDisallowed, Target: rawURL}
+		}
+	}
+	return nil
+}
+
+// CheckResponseSize checks a result of the given size in bytes against the maximum response size.
+func (p *Policy) CheckResponseSize(size int) error {
+	if p.MaxResponseSize > 0 && size > p.MaxResponseSize {
+		return &PolicyError{
+			Err:    ErrResponseTooLarge,
+			Target: fmt.Sprintf("%d bytes", size),
+			Detail: fmt.Sprintf("maximum is %d bytes", p.MaxResponseSize),
+		}
+	}
+	return nil
+}
+
+// HasRules reports whether the operator configured domain, network or robots.txt rules. They are
+// only checked on the requested page, not on the pages the backend follows from it.
+func (p *Policy) HasRules() bool {
+	return len(p.AllowDomains) > 0 || len(p.DenyDomains) > 0 ||
+		len(p.AllowNetworks) > 0 || len(p.DenyNetworks) > 0 || p.RespectRobots
+}
+
+func (p *Policy) checkDomain(host string) error {
+	// IP literals are only subject to the address checks
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+	for _, d := range p.DenyDomains {
+		if matchDomain(host, d) {
+			return &PolicyError{Err: ErrDomainDenied, Target: host}
+		}
+	}
+	if len(p.AllowDomains) == 0 {
+		return nil
+	}
+	for _, d := range p.AllowDomains {
+		if matchDomain(host, d) {
+			return nil
+		}
+	}
+	return &PolicyError{Err: ErrDomainNotAllowed, Target: host}
+}
+
+// checkHost resolves the host and checks every address it resolves to, so a public
+// name pointing at a private address is rejected as well.
+func (p *Policy) checkHost(ctx context.Context, host string) error {
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		var err error
+		ips, err = p.resolver(ctx, host)
+		if err != nil || len(ips) == 0 {
+			detail := "no addresses"
+			if err != nil {
+				detail = err.Error()
+			}
+			return &PolicyError{Err: ErrUnresolvableHostname, Target: host, Detail: detail}
+		}
+	}
+	for _, ip := range ips {
+		if err := p.CheckIP(ip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckIP checks a single address against the private ranges and the network allow and deny lists.
+func (p *Policy) CheckIP(ip net.IP) error {
+	if !p.AllowPrivate && inNetworks(ip, privateRanges) {
+		return &PolicyError{Err: ErrPrivateAddress, Target: ip.String()}
+	}
+	if inNetworks(ip, p.DenyNetworks) {
+		return &PolicyError{Err: ErrAddressDenied, Target: ip.String()}
+	}
+	if len(p.AllowNetworks) > 0 && !inNetworks(ip, p.AllowNetworks) {
+		return &PolicyError{Err: ErrAddressDenied, Target: ip.String(), Detail: "not in an allowed network"}
+	}
+	return nil
+}
+
+// ParseDomains parses a comma-separated list of domains.
+func ParseDomains(s string) []string {
+	return normalizeDomains(strings.Split(s, ","))
+}
+
+// ParseNetworks parses a comma-separated list of CIDRs or single addresses.
+func ParseNetworks(s string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address %q", entry)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", entry, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+func normalizeDomains(domains []string) []string {
+	result := make([]string, 0, len(domains))
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimSpace(d))
+		d = strings.TrimPrefix(d, "*.")
+		d = strings.Trim(d, ".")
+		if d != "" {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// matchDomain reports whether host is domain or one of its subdomains.
+func matchDomain(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+func inNetworks(ip net.IP, networks []*net.IPNet) bool {
+	for _, n := range networks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, n)
+	}
+	return networks
+}
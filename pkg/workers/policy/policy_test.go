@@ -0,0 +1,150 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func staticResolver(addrs map[string]string) Option {
+	return WithResolver(func(_ context.Context, host string) ([]net.IP, error) {
+		addr, ok := addrs[host]
+		if !ok {
+			return nil, errors.New("no such host")
+		}
+		return []net.IP{net.ParseIP(addr)}, nil
+	})
+}
+
+func TestCheckURL(t *testing.T) {
+	resolver := staticResolver(map[string]string{
+		"example.com":          "93.184.216.34",
+		"blog.example.com":     "93.184.216.35",
+		"internal.example.com": "10.0.0.5",
+		"evil.com":             "93.184.216.36",
+	})
+	ctx := context.Background()
+
+	t.Run("Default policy blocks private addresses", func(t *testing.T) {
+		p := New(resolver)
+		assert.NoError(t, p.CheckURL(ctx, "https://example.com/page"))
+		for _, u := range []string{
+			"http://127.0.0.1:8080/",
+			"http://169.254.169.254/latest/meta-data/",
+			"http://[::1]/",
+			"http://[::ffff:192.168.1.1]/",
+			"https://internal.example.com/",
+		} {
+			assert.ErrorIs(t, p.CheckURL(ctx, u), ErrPrivateAddress, u)
+		}
+		assert.ErrorIs(t, p.CheckURL(ctx, "file:///etc/passwd"), ErrInvalidURL)
+		assert.ErrorIs(t, p.CheckURL(ctx, "https://unknown.test/"), ErrUnresolvableHostname)
+	})
+
+	t.Run("Private addresses can be allowed", func(t *testing.T) {
+		p := New(resolver, AllowPrivateAddresses)
+		assert.NoError(t, p.CheckURL(ctx, "https://internal.example.com/"))
+	})
+
+	t.Run("Domain lists", func(t *testing.T) {
+		p := New(resolver, WithAllowDomains("example.com"), WithDenyDomains("*.blog.example.com", "blog.example.com"))
+		assert.NoError(t, p.CheckURL(ctx, "https://example.com/"))
+		assert.ErrorIs(t, p.CheckURL(ctx, "https://blog.example.com/"), ErrDomainDenied)
+		assert.ErrorIs(t, p.CheckURL(ctx, "https://evil.com/"), ErrDomainNotAllowed)
+		assert.ErrorIs(t, p.CheckURL(ctx, "https://notexample.com/"), ErrDomainNotAllowed)
+	})
+
+	t.Run("Network lists", func(t *testing.T) {
+		deny, err := ParseNetworks("93.184.216.36")
+		require.NoError(t, err)
+		p := New(resolver, WithDenyNetworks(deny...))
+		assert.NoError(t, p.CheckURL(ctx, "https://example.com/"))
+		err = p.CheckURL(ctx, "https://evil.com/")
+		assert.ErrorIs(t, err, ErrAddressDenied)
+		assert.True(t, IsPolicyError(err))
+	})
+}
+
+func TestCheckResponseSize(t *testing.T) {
+	assert.NoError(t, New().CheckResponseSize(1<<30))
+	p := New(WithMaxResponseSize(100))
+	assert.NoError(t, p.CheckResponseSize(100))
+	assert.ErrorIs(t, p.CheckResponseSize(101), ErrResponseTooLarge)
+}
+
+func TestResponseLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 100)))
+	}))
+	defer server.Close()
+
+	get := func(p *Policy) (string, error) {
+		limiter := p.NewResponseLimiter(nil)
+		resp, err := (&http.Client{Transport: limiter}).Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body), limiter.Err()
+	}
+
+	body, err := get(New())
+	assert.NoError(t, err)
+	assert.Len(t, body, 100)
+
+	body, err = get(New(WithMaxResponseSize(100)))
+	assert.NoError(t, err)
+	assert.Len(t, body, 100)
+
+	body, err = get(New(WithMaxResponseSize(10)))
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+	assert.Len(t, body, 10)
+}
+
+func TestRobotsCacheBound(t *testing.T) {
+	rc := newRobotsCache(time.Hour, nil)
+	rc.maxEntries = 2
+	now := time.Now()
+	rc.store("https://a.com", &robotsFile{fetched: now.Add(-3 * time.Minute)})
+	rc.store("https://b.com", &robotsFile{fetched: now.Add(-2 * time.Minute)})
+	rc.store("https://c.com", &robotsFile{fetched: now})
+	assert.Len(t, rc.files, 2)
+	assert.NotContains(t, rc.files, "https://a.com")
+
+	// Expired files are evicted first
+	rc.store("https://b.com", &robotsFile{fetched: now.Add(-2 * time.Hour)})
+	rc.store("https://d.com", &robotsFile{fetched: now})
+	assert.Len(t, rc.files, 2)
+	assert.Contains(t, rc.files, "https://c.com")
+	assert.Contains(t, rc.files, "https://d.com")
+}
+
+func TestRobots(t *testing.T) {
+	file := &robotsFile{groups: parseRobots(strings.NewReader(`
+User-agent: *
+Disallow: /private/
+Allow: /private/public$
+
+User-agent: masa-oracle
+User-agent: other
+Disallow: /no-bots
+Disallow: /*.pdf$
+`))}
+
+	assert.True(t, file.allowed("SomeBot/1.0", "/index.html"))
+	assert.False(t, file.allowed("SomeBot/1.0", "/private/data"))
+	assert.True(t, file.allowed("SomeBot/1.0", "/private/public"))
+	assert.False(t, file.allowed("masa-oracle", "/no-bots/page"))
+	assert.False(t, file.allowed("masa-oracle", "/docs/file.pdf"))
+	assert.True(t, file.allowed("masa-oracle", "/docs/file.pdf.html"))
+	assert.True(t, file.allowed("masa-oracle", "/private/data"), "the specific group replaces the * group")
+}
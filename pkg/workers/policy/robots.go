@@ -0,0 +1,267 @@
+package policy
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	robotsTimeout = 5 * time.Second
+	// robotsMaxSize is the amount of a robots.txt file that is parsed, as recommended by RFC 9309.
+	robotsMaxSize = 500 * 1024
+	// robotsUserAgent is the agent of the node, which selects the robots.txt group.
+	robotsUserAgent = "masa-oracle"
+	// robotsMaxEntries bounds the number of origins whose robots.txt is cached.
+	robotsMaxEntries = 1024
+)
+
+type robotsRule struct {
+	allow bool
+	path  string
+}
+
+type robotsGroup struct {
+	agents []string
+	rules  []robotsRule
+}
+
+type robotsFile struct {
+	groups  []robotsGroup
+	fetched time.Time
+}
+
+// robotsCache fetches and caches robots.txt files per origin. When it is full, the files
+// fetched the longest ago are evicted first.
+type robotsCache struct {
+	ttl        time.Duration
+	maxEntries int
+	files      map[string]*robotsFile
+	mu         sync.Mutex
+	client     *http.Client
+}
+
+// newRobotsCache creates a robotsCache whose connections, including redirects, are only made
+// to addresses accepted by checkIP.
+func newRobotsCache(ttl time.Duration, checkIP func(net.IP) error) *robotsCache {
+	dialer := &net.Dialer{
+		Timeout: robotsTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			return checkIP(net.ParseIP(host))
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &robotsCache{
+		ttl:        ttl,
+		maxEntries: robotsMaxEntries,
+		files:      make(map[string]*robotsFile),
+		client:     &http.Client{Timeout: robotsTimeout, Transport: transport},
+	}
+}
+
+// allowed reports whether the robots.txt of the URL's origin allows the node to fetch it.
+func (rc *robotsCache) allowed(ctx context.Context, u *url.URL) (bool, error) {
+	origin := u.Scheme + "://" + u.Host
+
+	rc.mu.Lock()
+	file, ok := rc.files[origin]
+	rc.mu.Unlock()
+	if !ok || time.Since(file.fetched) > rc.ttl {
+		var err error
+		file, err = rc.fetch(ctx, origin)
+		if err != nil {
+			return false, err
+		}
+		rc.store(origin, file)
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return file.allowed(robotsUserAgent, path), nil
+}
+
+// store caches the robots.txt of an origin, evicting the expired files, and then the oldest
+// one, when the cache is full.
+func (rc *robotsCache) store(origin string, file *robotsFile) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if _, ok := rc.files[origin]; !ok && len(rc.files) >= rc.maxEntries {
+		var oldest string
+		for o, f := range rc.files {
+			if time.Since(f.fetched) > rc.ttl {
+				delete(rc.files, o)
+			} else if oldest == "" || f.fetched.Before(rc.files[oldest].fetched) {
+				oldest = o
+			}
+		}
+		if len(rc.files) >= rc.maxEntries {
+			delete(rc.files, oldest)
+		}
+	}
+	rc.files[origin] = file
+}
+
+// fetch downloads and parses robots.txt. Following RFC 9309, a missing file (4xx) allows
+// everything and an unreachable one (5xx or network error) disallows everything.
+func (rc *robotsCache) fetch(ctx context.Context, origin string) (*robotsFile, error) {
+	ctx, cancel := context.WithTimeout(ctx, robotsTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+"/robots.txt", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating robots.txt request: %w", err)
+	}
+	req.Header.Set("User-Agent", robotsUserAgent)
+
+	file := &robotsFile{fetched: time.Now()}
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		if IsPolicyError(err) {
+			return nil, err
+		}
+		file.groups = disallowAll()
+		return file, nil
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		file.groups = parseRobots(io.LimitReader(resp.Body, robotsMaxSize))
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		// No robots.txt, everything is allowed
+	default:
+		file.groups = disallowAll()
+	}
+	return file, nil
+}
+
+func disallowAll() []robotsGroup {
+	return []robotsGroup{{agents: []string{"*"}, rules: []robotsRule{{allow: false, path: "/"}}}}
+}
+
+// parseRobots parses the groups and Allow/Disallow rules of a robots.txt file.
+func parseRobots(r io.Reader) []robotsGroup {
+	var groups []robotsGroup
+	var current *robotsGroup
+	inAgents := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inAgents {
+				groups = append(groups, robotsGroup{})
+				current = &groups[len(groups)-1]
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			inAgents = true
+		case "allow", "disallow":
+			inAgents = false
+			if current == nil || value == "" && key == "disallow" {
+				continue
+			}
+			current.rules = append(current.rules, robotsRule{allow: key == "allow", path: value})
+		}
+	}
+	return groups
+}
+
+// allowed applies the rules of the group matching the user agent: the longest matching
+// rule wins, and Allow wins a tie.
+func (f *robotsFile) allowed(userAgent, path string) bool {
+	group := f.group(strings.ToLower(userAgent))
+	if group == nil {
+		return true
+	}
+	allowed := true
+	longest := -1
+	for _, rule := range group.rules {
+		if !matchRobotsPath(rule.path, path) {
+			continue
+		}
+		if len(rule.path) > longest || (len(rule.path) == longest && rule.allow) {
+			longest = len(rule.path)
+			allowed = rule.allow
+		}
+	}
+	return allowed
+}
+
+// group returns the group whose user agent is the longest match for the given user agent,
+// falling back to the "*" group.
+func (f *robotsFile) group(userAgent string) *robotsGroup {
+	var best, wildcard *robotsGroup
+	bestLen := 0
+	for i := range f.groups {
+		for _, agent := range f.groups[i].agents {
+			if agent == "*" {
+				if wildcard == nil {
+					wildcard = &f.groups[i]
+				}
+				continue
+			}
+			if strings.Contains(userAgent, agent) && len(agent) > bestLen {
+				best = &f.groups[i]
+				bestLen = len(agent)
+			}
+		}
+	}
+	if best != nil {
+		return best
+	}
+	return wildcard
+}
+
+// matchRobotsPath matches a path against a robots.txt pattern supporting "*" and a trailing "$".
+func matchRobotsPath(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for _, part := range parts[1:] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+	if anchored {
+		return rest == "" || (len(parts) > 1 && strings.HasSuffix(path, parts[len(parts)-1]))
+	}
+	return true
+}
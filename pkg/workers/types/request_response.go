@@ -1,7 +1,8 @@
 package data_types
 
 import (
-	"os"
+	"fmt"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
@@ -50,30 +51,65 @@ type WorkRequest struct {
 	WorkType  WorkerType `json:"workType,omitempty"`
 	RequestId string     `json:"requestId,omitempty"`
 	Data      []byte     `json:"data,omitempty"`
+	// TraceContext carries the W3C trace context of the requester, so that the spans of the worker join its trace
+	TraceContext map[string]string `json:"traceContext,omitempty"`
 }
 
 type WorkResponse struct {
-	WorkRequest  *WorkRequest `json:"workRequest,omitempty"`
-	Data         interface{}  `json:"data,omitempty"`
-	Error        string       `json:"error,omitempty"`
-	WorkerPeerId string       `json:"workerPeerId,omitempty"`
+	WorkRequest       *WorkRequest           `json:"workRequest,omitempty"`
+	Data              interface{}            `json:"data,omitempty"`
+	Sealed            *SealedData            `json:"sealed,omitempty"`
+	Error             string                 `json:"error,omitempty"`
+	WorkerPeerId      string                 `json:"workerPeerId,omitempty"`
+	Attestation       *tee.AttestationReport `json:"attestation,omitempty"`
+	AttestationResult *tee.AttestationResult `json:"attestationResult,omitempty"`
 }
 
-func (wr *WorkResponse) UnsealDataIfNeeded() (err error) {
-	unsealData := os.Getenv("KEEP_SEALED_DATA") != "true"
-	if !unsealData {
+// ApplySealing prepares the response data according to the requester's sealing options.
+// In sealed mode Data keeps the encrypted result and Sealed describes how to decrypt it later.
+// In unsealed mode Data is decrypted with the selected tee endpoint. In both mode Data is
+// decrypted and Sealed also carries the encrypted payload. Responses that don't carry a
+// sealed string are left untouched. Nodes that only return sealed data refuse to decrypt.
+func (wr *WorkResponse) ApplySealing(opts SealingOptions, request *WorkRequest) (err error) {
+	sealed, ok := wr.Data.(string)
+	if !ok {
 		return
 	}
+	if opts.Mode != SealingSealed && SealedOnly() {
+		return ErrSealedOnly
+	}
 
-	switch v := wr.Data.(type) {
-	case string:
-		client := tee.NewClient()
-		var resData string
-		resData, err = client.Decrypt(v)
-		if err == nil {
-			wr.Data, err = utils.BytesToMap([]byte(resData))
+	if opts.Mode == SealingSealed || opts.Mode == SealingBoth {
+		endpoint := opts.TeeEndpoint
+		if endpoint == "" {
+			endpoint = tee.DefaultEndpoint
+		}
+		wr.Sealed = &SealedData{
+			Payload:      sealed,
+			Format:       SealedDataFormat,
+			TeeEndpoint:  endpoint,
+			WorkerPeerId: wr.WorkerPeerId,
+			SealedAt:     time.Now().UTC(),
+		}
+		if request != nil {
+			wr.Sealed.WorkType = request.WorkType
+			wr.Sealed.RequestId = request.RequestId
 		}
 	}
+	if opts.Mode == SealingSealed {
+		// The payload is already in Data, don't return it twice
+		wr.Sealed.Payload = ""
+		return
+	}
+
+	client, err := tee.NewDecryptClient(opts.TeeEndpoint)
+	if err != nil {
+		return fmt.Errorf("unable to decrypt result: %w", err)
+	}
+	resData, err := client.Decrypt(sealed)
+	if err == nil {
+		wr.Data, err = utils.BytesToMap([]byte(resData))
+	}
 
 	return
 }
@@ -0,0 +1,78 @@
+package data_types
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// SealingMode controls whether a requester receives the sealed (encrypted) result
+// produced by the tee-worker, the unsealed (decrypted) result, or both.
+type SealingMode string
+
+const (
+	SealingSealed   SealingMode = "sealed"
+	SealingUnsealed SealingMode = "unsealed"
+	SealingBoth     SealingMode = "both"
+
+	// SealedDataFormat identifies the encoding of SealedData.Payload
+	SealedDataFormat = "tee-worker/encrypted-result"
+)
+
+// ErrSealedOnly is returned when a requester asks for unsealed data from a node that only
+// returns sealed data.
+var ErrSealedOnly = errors.New("this node only returns sealed data")
+
+// SealingOptions are the per-request choices of how the result should be returned.
+type SealingOptions struct {
+	Mode SealingMode
+	// TeeEndpoint is the name of the configured tee endpoint used to decrypt the result.
+	// An empty value selects the default endpoint.
+	TeeEndpoint string
+}
+
+// SealedData is a sealed result along with the metadata needed to decrypt it later.
+// Payload is empty when the sealed result is returned as the response data itself.
+type SealedData struct {
+	Payload      string     `json:"payload,omitempty"`
+	Format       string     `json:"format"`
+	TeeEndpoint  string     `json:"teeEndpoint,omitempty"`
+	WorkType     WorkerType `json:"workType,omitempty"`
+	RequestId    string     `json:"requestId,omitempty"`
+	WorkerPeerId string     `json:"workerPeerId,omitempty"`
+	SealedAt     time.Time  `json:"sealedAt"`
+}
+
+// ParseSealingMode converts a string into a SealingMode. An empty string selects the node's default.
+// It returns ErrSealedOnly for the unsealed and both modes if the node only returns sealed data.
+func ParseSealingMode(s string) (SealingMode, error) {
+	switch SealingMode(s) {
+	case "":
+		return DefaultSealingMode(), nil
+	case SealingSealed:
+		return SealingSealed, nil
+	case SealingUnsealed, SealingBoth:
+		if SealedOnly() {
+			return "", ErrSealedOnly
+		}
+		return SealingMode(s), nil
+	default:
+		return "", fmt.Errorf("invalid sealing mode %q, expected one of %s, %s or %s", s, SealingSealed, SealingUnsealed, SealingBoth)
+	}
+}
+
+// DefaultSealingMode returns the sealing mode used when the requester does not choose one.
+// Nodes started with KEEP_SEALED_DATA=true or SEALED_DATA_ONLY=true return sealed data by default.
+func DefaultSealingMode() SealingMode {
+	if SealedOnly() || os.Getenv("KEEP_SEALED_DATA") == "true" {
+		return SealingSealed
+	}
+	return SealingUnsealed
+}
+
+// SealedOnly reports whether the node was started with SEALED_DATA_ONLY=true, in which case
+// it never decrypts results for requesters.
+func SealedOnly() bool {
+	return os.Getenv("SEALED_DATA_ONLY") == "true"
+}
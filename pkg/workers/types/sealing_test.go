@@ -0,0 +1,65 @@
+package data_types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/masa-finance/masa-oracle/pkg/tee"
+)
+
+func TestParseSealingMode(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		mode, err := ParseSealingMode("")
+		require.NoError(t, err)
+		assert.Equal(t, SealingUnsealed, mode)
+
+		t.Setenv("KEEP_SEALED_DATA", "true")
+		mode, err = ParseSealingMode("")
+		require.NoError(t, err)
+		assert.Equal(t, SealingSealed, mode)
+	})
+
+	t.Run("SealedOnly", func(t *testing.T) {
+		t.Setenv("SEALED_DATA_ONLY", "true")
+		mode, err := ParseSealingMode("")
+		require.NoError(t, err)
+		assert.Equal(t, SealingSealed, mode)
+
+		mode, err = ParseSealingMode("sealed")
+		require.NoError(t, err)
+		assert.Equal(t, SealingSealed, mode)
+
+		_, err = ParseSealingMode("unsealed")
+		assert.ErrorIs(t, err, ErrSealedOnly)
+		_, err = ParseSealingMode("both")
+		assert.ErrorIs(t, err, ErrSealedOnly)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseSealingMode("plain")
+		assert.Error(t, err)
+	})
+}
+
+func TestApplySealing(t *testing.T) {
+	request := &WorkRequest{WorkType: Web, RequestId: "req-1"}
+
+	t.Run("DefaultEndpoint", func(t *testing.T) {
+		response := WorkResponse{Data: "sealed-result", WorkerPeerId: "worker"}
+		require.NoError(t, response.ApplySealing(SealingOptions{Mode: SealingSealed}, request))
+		assert.Equal(t, "sealed-result", response.Data)
+		require.NotNil(t, response.Sealed)
+		assert.Equal(t, tee.DefaultEndpoint, response.Sealed.TeeEndpoint)
+		assert.Equal(t, "req-1", response.Sealed.RequestId)
+		assert.Empty(t, response.Sealed.Payload)
+	})
+
+	t.Run("SealedOnly", func(t *testing.T) {
+		t.Setenv("SEALED_DATA_ONLY", "true")
+		response := WorkResponse{Data: "sealed-result"}
+		assert.ErrorIs(t, response.ApplySealing(SealingOptions{Mode: SealingUnsealed}, request), ErrSealedOnly)
+		assert.Equal(t, "sealed-result", response.Data)
+	})
+}
@@ -0,0 +1,36 @@
+package data_types
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// WebRequest is the payload of a Web work request.
+type WebRequest struct {
+	Url   string `json:"url"`
+	Depth int    `json:"depth"`
+}
+
+// Validate checks the request and fills in the default depth.
+func (r *WebRequest) Validate() error {
+	if r.Url == "" {
+		return fmt.Errorf("URL parameter is missing")
+	}
+	u, err := url.Parse(r.Url)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("invalid URL %q, expected an absolute http or https URL", r.Url)
+	}
+
+	if r.Depth <= 0 {
+		r.Depth = 1 // Default depth
+	}
+	return nil
+}
+
+// Arguments returns the job arguments for the web scraper backend.
+func (r *WebRequest) Arguments() map[string]interface{} {
+	return map[string]interface{}{
+		"url":   r.Url,
+		"depth": r.Depth,
+	}
+}
@@ -0,0 +1,33 @@
+package data_types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebRequestValidate(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		r := WebRequest{Url: "https://example.com"}
+		assert.NoError(t, r.Validate())
+		assert.Equal(t, 1, r.Depth)
+		assert.Equal(t, map[string]interface{}{"url": "https://example.com", "depth": 1}, r.Arguments())
+	})
+
+	t.Run("Depth", func(t *testing.T) {
+		r := WebRequest{Url: "https://example.com", Depth: 3}
+		assert.NoError(t, r.Validate())
+		assert.Equal(t, 3, r.Depth)
+	})
+
+	invalid := map[string]WebRequest{
+		"Missing URL":     {},
+		"Relative URL":    {Url: "/blog"},
+		"Unsupported URL": {Url: "file:///etc/passwd"},
+	}
+	for name, r := range invalid {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, r.Validate())
+		})
+	}
+}
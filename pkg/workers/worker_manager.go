@@ -2,11 +2,8 @@ package workers
 
 import (
 	"context"
-	"encoding/binary"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"math/rand"
 	"strings"
 	"sync"
@@ -14,39 +11,115 @@ import (
 
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/masa-finance/masa-oracle/node"
+	"github.com/masa-finance/masa-oracle/pkg/audit"
 	"github.com/masa-finance/masa-oracle/pkg/event"
+	"github.com/masa-finance/masa-oracle/pkg/masacrypto"
+	myNetwork "github.com/masa-finance/masa-oracle/pkg/network"
 	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+	"github.com/masa-finance/masa-oracle/pkg/tee"
+	"github.com/masa-finance/masa-oracle/pkg/tracing"
 	"github.com/masa-finance/masa-oracle/pkg/workers/handlers"
+	"github.com/masa-finance/masa-oracle/pkg/workers/policy"
 	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
 )
 
 func NewWorkHandlerManager(opts ...WorkerOptionFunc) *WorkHandlerManager {
 	options := &WorkerOption{}
 	options.Apply(opts...)
+	if options.contentPolicy == nil {
+		options.contentPolicy = policy.New()
+	}
 
 	whm := &WorkHandlerManager{
 		handlers:     make(map[data_types.WorkerType]*WorkHandlerInfo),
 		eventTracker: event.NewEventTracker(nil),
+		latency:      NewLatencyTracker(options.maxLatency),
+
+		attester:            options.attester,
+		attestationVerifier: options.attestationVerifier,
+		requireAttestation:  options.requireAttestation,
+		audit:               options.auditStore,
+		masaDir:             options.masaDir,
+		contentPolicy:       options.contentPolicy,
 	}
 
 	if options.isTwitterWorker {
-		whm.addWorkHandler(data_types.Twitter, &handlers.TwitterQueryHandler{MasaDir: options.masaDir})
-		whm.addWorkHandler(data_types.TwitterFollowers, &handlers.TwitterFollowersHandler{MasaDir: options.masaDir})
-		whm.addWorkHandler(data_types.TwitterProfile, &handlers.TwitterProfileHandler{MasaDir: options.masaDir})
+		_ = whm.SetCapability(pubsub.CategoryTwitter, true)
 	}
 
 	if options.isWebScraperWorker {
-		whm.addWorkHandler(data_types.Web, &handlers.WebHandler{})
+		_ = whm.SetCapability(pubsub.CategoryWeb, true)
 	}
 
 	return whm
 }
 
+// ErrUnsupportedCapability is returned when toggling a worker category this node has no work handlers for.
+var ErrUnsupportedCapability = errors.New("unsupported worker capability")
+
+// Capabilities lists the worker categories this node can have work handlers for.
+var Capabilities = []pubsub.WorkerCategory{pubsub.CategoryTwitter, pubsub.CategoryWeb}
+
+// categoryHandlers returns the work handlers of a worker category.
+func (whm *WorkHandlerManager) categoryHandlers(category pubsub.WorkerCategory) (map[data_types.WorkerType]WorkHandler, error) {
+	switch category {
+	case pubsub.CategoryTwitter:
+		return map[data_types.WorkerType]WorkHandler{
+			data_types.Twitter:          &handlers.TwitterQueryHandler{MasaDir: whm.masaDir},
+			data_types.TwitterFollowers: &handlers.TwitterFollowersHandler{MasaDir: whm.masaDir},
+			data_types.TwitterProfile:   &handlers.TwitterProfileHandler{MasaDir: whm.masaDir},
+		}, nil
+	case pubsub.CategoryWeb:
+		return map[data_types.WorkerType]WorkHandler{
+			data_types.Web: &handlers.WebHandler{Policy: whm.contentPolicy},
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedCapability, category)
+	}
+}
+
+// SetCapability registers or unregisters the work handlers of a worker category, so that the
+// node starts or stops doing that work without a restart. Requests in flight are not affected.
+func (whm *WorkHandlerManager) SetCapability(category pubsub.WorkerCategory, enabled bool) error {
+	categoryHandlers, err := whm.categoryHandlers(category)
+	if err != nil {
+		return err
+	}
+	whm.mu.Lock()
+	defer whm.mu.Unlock()
+	for wType, handler := range categoryHandlers {
+		if !enabled {
+			delete(whm.handlers, wType)
+		} else if _, exists := whm.handlers[wType]; !exists {
+			whm.handlers[wType] = &WorkHandlerInfo{Handler: handler}
+		}
+	}
+	return nil
+}
+
+// HasCapability reports whether the work handlers of a worker category are registered.
+func (whm *WorkHandlerManager) HasCapability(category pubsub.WorkerCategory) bool {
+	categoryHandlers, err := whm.categoryHandlers(category)
+	if err != nil {
+		return false
+	}
+	for wType := range categoryHandlers {
+		if _, exists := whm.getWorkHandler(wType); !exists {
+			return false
+		}
+	}
+	return true
+}
+
 // ErrHandlerNotFound is an error returned when a work handler cannot be found.
 var ErrHandlerNotFound = errors.New("work handler not found")
 
+// ErrShuttingDown is the error of the work requests received while the node is draining.
+var ErrShuttingDown = errors.New("node is shutting down")
+
 // WorkHandler defines the interface for handling different types of work.
 type WorkHandler interface {
 	HandleWork(data []byte) data_types.WorkResponse
@@ -64,6 +137,18 @@ type WorkHandlerManager struct {
 	handlers     map[data_types.WorkerType]*WorkHandlerInfo
 	mu           sync.RWMutex
 	eventTracker *event.EventTracker
+	latency      *LatencyTracker
+
+	attester            tee.Attester
+	attestationVerifier *tee.AttestationVerifier
+	requireAttestation  bool
+	audit               *audit.Store
+	masaDir             string
+	contentPolicy       *policy.Policy
+
+	drainMu  sync.Mutex
+	draining bool
+	inFlight sync.WaitGroup
 }
 
 // addWorkHandler registers a new work handler under a specific name.
@@ -84,11 +169,75 @@ func (whm *WorkHandlerManager) getWorkHandler(wType data_types.WorkerType) (Work
 	return info.Handler, true
 }
 
+// AuditStore returns the audit store of the work requests handled by this node, or nil if auditing is disabled.
+func (whm *WorkHandlerManager) AuditStore() *audit.Store {
+	return whm.audit
+}
+
+// Drain stops accepting work requests and waits until the requests in flight have completed,
+// or the context is done.
+func (whm *WorkHandlerManager) Drain(ctx context.Context) error {
+	whm.drainMu.Lock()
+	whm.draining = true
+	whm.drainMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		whm.inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close closes the audit store. It should be called after Drain, so that the outcome of
+// every request in flight is recorded.
+func (whm *WorkHandlerManager) Close() error {
+	return whm.audit.Close()
+}
+
+// startWork registers a work request in flight, unless the manager is draining.
+func (whm *WorkHandlerManager) startWork() bool {
+	whm.drainMu.Lock()
+	defer whm.drainMu.Unlock()
+	if whm.draining {
+		return false
+	}
+	whm.inFlight.Add(1)
+	return true
+}
+
+// DistributeWork sends the work request to the best eligible worker, falling back to local execution,
+// and records the outcome in the audit store. Its spans join the trace in the request's TraceContext.
 func (whm *WorkHandlerManager) DistributeWork(node *node.OracleNode, workRequest data_types.WorkRequest) (response data_types.WorkResponse) {
+	ctx, span := tracing.Start(tracing.Extract(context.Background(), workRequest.TraceContext), "DistributeWork",
+		trace.WithAttributes(tracing.RequestIDKey.String(workRequest.RequestId), tracing.WorkTypeKey.String(string(workRequest.WorkType))))
+	defer func() {
+		tracing.End(span, responseError(response))
+	}()
+
+	if !whm.startWork() {
+		return data_types.WorkResponse{Error: ErrShuttingDown.Error()}
+	}
+	defer whm.inFlight.Done()
+
+	startTime := time.Now()
+	response = whm.distributeWork(ctx, node, workRequest)
+	whm.recordAudit(audit.RoleOriginated, workRequest, node.Host.ID().String(), response.WorkerPeerId, startTime, response.Error)
+	return response
+}
+
+func (whm *WorkHandlerManager) distributeWork(ctx context.Context, node *node.OracleNode, workRequest data_types.WorkRequest) (response data_types.WorkResponse) {
 	category := data_types.WorkerTypeToCategory(workRequest.WorkType)
 	var remoteWorkers []data_types.Worker
 	var localWorker *data_types.Worker
 
+	_, selectSpan := tracing.Start(ctx, "SelectWorkers")
+
 	if category == pubsub.CategoryTwitter {
 		// Use priority-based selection for Twitter work
 		remoteWorkers, localWorker = GetEligibleWorkers(node, category, workerConfig.MaxRemoteWorkers)
@@ -102,6 +251,10 @@ func (whm *WorkHandlerManager) DistributeWork(node *node.OracleNode, workRequest
 		})
 		logrus.Info("Starting round-robin worker selection for non-Twitter work")
 	}
+	deprioritizeAttestationFailures(remoteWorkers)
+	remoteWorkers = whm.latency.Rank(node.Host, category, remoteWorkers)
+	selectSpan.SetAttributes(tracing.WorkersKey.Int(len(remoteWorkers)))
+	selectSpan.End()
 
 	remoteWorkersAttempted := 0
 	var errorList []string
@@ -115,9 +268,11 @@ func (whm *WorkHandlerManager) DistributeWork(node *node.OracleNode, workRequest
 		remoteWorkersAttempted++
 
 		// Attempt to connect to the worker
-		ctx, cancel := context.WithTimeout(context.Background(), workerConfig.FindPeerTimeout)
-		peerInfo, err := node.DHT.FindPeer(ctx, worker.NodeData.PeerId)
+		findCtx, findSpan := tracing.Start(ctx, "FindPeer", trace.WithAttributes(tracing.PeerIDKey.String(worker.NodeData.PeerId.String())))
+		findCtx, cancel := context.WithTimeout(findCtx, workerConfig.FindPeerTimeout)
+		peerInfo, err := node.DHT.FindPeer(findCtx, worker.NodeData.PeerId)
 		cancel()
+		tracing.End(findSpan, err)
 		if err != nil {
 			if err == context.DeadlineExceeded {
 				logrus.Warnf("Timeout while finding peer %s in DHT", worker.NodeData.PeerId.String())
@@ -147,7 +302,7 @@ func (whm *WorkHandlerManager) DistributeWork(node *node.OracleNode, workRequest
 		worker.AddrInfo = &peerInfo
 
 		logrus.Infof("Attempting remote worker %s (attempt %d/%d)", worker.NodeData.PeerId, remoteWorkersAttempted, workerConfig.MaxRemoteWorkers)
-		response = whm.sendWorkToWorker(node, worker, workRequest)
+		response = whm.sendWorkToWorker(ctx, node, worker, workRequest)
 		if response.Error != "" {
 			errorMsg := fmt.Sprintf("Worker %s: %s", worker.NodeData.PeerId, response.Error)
 			errorList = append(errorList, errorMsg)
@@ -161,6 +316,12 @@ func (whm *WorkHandlerManager) DistributeWork(node *node.OracleNode, workRequest
 				logrus.Warnf("Worker %s failed due to Twitter authentication error. Skipping to the next worker.", worker.NodeData.PeerId)
 				continue
 			}
+		} else if err := whm.verifyAttestation(node, worker, workRequest, &response); err != nil {
+			errorList = append(errorList, fmt.Sprintf("Worker %s: %v", worker.NodeData.PeerId, err))
+			whm.eventTracker.TrackWorkerFailure(workRequest.WorkType, err.Error(), worker.AddrInfo.ID.String())
+			logrus.Infof("Remote worker %s returned an unverified result, moving to next worker", worker.NodeData.PeerId)
+			// Don't let the rejected result leak out if every other worker fails too
+			response = data_types.WorkResponse{WorkerPeerId: response.WorkerPeerId}
 		} else {
 			return response
 		}
@@ -176,7 +337,10 @@ func (whm *WorkHandlerManager) DistributeWork(node *node.OracleNode, workRequest
 		}
 		whm.eventTracker.TrackLocalWorkerFallback(workRequest.WorkType, reason, localWorker.AddrInfo.ID.String())
 
-		response = whm.ExecuteWork(workRequest)
+		localRequest := workRequest
+		localRequest.TraceContext = tracing.Inject(ctx)
+		response = whm.ExecuteWork(localRequest)
+		response.WorkerPeerId = localWorker.AddrInfo.ID.String()
 		whm.eventTracker.TrackWorkCompletion(workRequest.WorkType, response.Error == "", localWorker.AddrInfo.ID.String())
 
 		if response.Error != "" {
@@ -195,18 +359,25 @@ func (whm *WorkHandlerManager) DistributeWork(node *node.OracleNode, workRequest
 	return response
 }
 
-func (whm *WorkHandlerManager) sendWorkToWorker(node *node.OracleNode, worker data_types.Worker, workRequest data_types.WorkRequest) (response data_types.WorkResponse) {
-	ctxWithTimeout, cancel := context.WithTimeout(context.Background(), workerConfig.WorkerResponseTimeout)
+func (whm *WorkHandlerManager) sendWorkToWorker(ctx context.Context, node *node.OracleNode, worker data_types.Worker, workRequest data_types.WorkRequest) (response data_types.WorkResponse) {
+	ctx, span := tracing.Start(ctx, "SendWork", trace.WithAttributes(tracing.PeerIDKey.String(worker.AddrInfo.ID.String())))
+	defer func() {
+		tracing.End(span, responseError(response))
+	}()
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, workerConfig.WorkerResponseTimeout)
 	defer cancel() // Cancel the context when done to release resources
 
-	if err := node.Host.Connect(ctxWithTimeout, *worker.AddrInfo); err != nil {
+	openCtx, openSpan := tracing.Start(ctxWithTimeout, "OpenStream")
+	if err := node.Host.Connect(openCtx, *worker.AddrInfo); err != nil {
+		tracing.End(openSpan, err)
 		response.Error = fmt.Sprintf("failed to connect to remote peer %s: %v", worker.AddrInfo.ID.String(), err)
 		whm.eventTracker.TrackWorkerFailure(workRequest.WorkType, response.Error, worker.AddrInfo.ID.String())
 		return
 	} else {
 		//whm.eventTracker.TrackRemoteWorkerConnection(worker.AddrInfo.ID.String())
 		logrus.Debugf("[+] Connection established with node: %s", worker.AddrInfo.ID.String())
-		stream, err := node.ProtocolStream(ctxWithTimeout, worker.AddrInfo.ID, node.Options.WorkerProtocol)
+		stream, err := node.ProtocolStream(openCtx, worker.AddrInfo.ID, node.Options.WorkerProtocol)
+		tracing.End(openSpan, err)
 		if err != nil {
 			response.Error = fmt.Sprintf("error opening stream: %v", err)
 			whm.eventTracker.TrackWorkerFailure(workRequest.WorkType, response.Error, worker.AddrInfo.ID.String())
@@ -220,46 +391,23 @@ func (whm *WorkHandlerManager) sendWorkToWorker(node *node.OracleNode, worker da
 			}
 		}(stream) // Close the stream when done
 
-		// Write the request to the stream with length prefix
-		bytes, err := json.Marshal(workRequest)
+		// Write the request to the stream with length prefix, the spans of the worker being children of this one
+		workRequest.TraceContext = tracing.Inject(ctx)
+		_, writeSpan := tracing.Start(ctx, "WriteRequest")
+		err = myNetwork.WriteMessage(stream, workRequest)
+		tracing.End(writeSpan, err)
 		if err != nil {
-			response.Error = fmt.Sprintf("error marshaling work request: %v", err)
-			return
-		}
-		lengthBuf := make([]byte, 4)
-		binary.BigEndian.PutUint32(lengthBuf, uint32(len(bytes)))
-		_, err = stream.Write(lengthBuf)
-		if err != nil {
-			response.Error = fmt.Sprintf("error writing length to stream: %v", err)
-			return
-		}
-		_, err = stream.Write(bytes)
-		if err != nil {
-			response.Error = fmt.Sprintf("error writing to stream: %v", err)
+			response.Error = fmt.Sprintf("error writing work request: %v", err)
 			whm.eventTracker.TrackWorkerFailure(workRequest.WorkType, response.Error, worker.AddrInfo.ID.String())
 			return
 		}
 		whm.eventTracker.TrackWorkDistribution(workRequest.WorkType, true, worker.AddrInfo.ID.String())
-		// Read the response length
-		lengthBuf = make([]byte, 4)
-		_, err = io.ReadFull(stream, lengthBuf)
-		if err != nil {
-			response.Error = fmt.Sprintf("error reading response length: %v", err)
-			whm.eventTracker.TrackWorkerFailure(workRequest.WorkType, response.Error, worker.AddrInfo.ID.String())
-			return
-		}
-		responseLength := binary.BigEndian.Uint32(lengthBuf)
-
-		// Read the actual response
-		responseBuf := make([]byte, responseLength)
-		_, err = io.ReadFull(stream, responseBuf)
+		_, readSpan := tracing.Start(ctx, "ReadResponse")
+		err = myNetwork.ReadMessage(stream, &response)
+		tracing.End(readSpan, err)
 		if err != nil {
 			response.Error = fmt.Sprintf("error reading response: %v", err)
-			return
-		}
-		err = json.Unmarshal(responseBuf, &response)
-		if err != nil {
-			response.Error = fmt.Sprintf("error unmarshaling response: %v", err)
+			whm.eventTracker.TrackWorkerFailure(workRequest.WorkType, response.Error, worker.AddrInfo.ID.String())
 			return
 		}
 		// Update metrics only if the work category is Twitter
@@ -295,6 +443,10 @@ func (whm *WorkHandlerManager) ExecuteWork(workRequest data_types.WorkRequest) (
 	ctx, cancel := context.WithTimeout(context.Background(), workerConfig.WorkerResponseTimeout)
 	defer cancel()
 
+	// The span ends when the handler returns, even if the request timed out before
+	_, span := tracing.Start(tracing.Extract(ctx, workRequest.TraceContext), "HandleWork",
+		trace.WithAttributes(tracing.RequestIDKey.String(workRequest.RequestId), tracing.WorkTypeKey.String(string(workRequest.WorkType))))
+
 	// Channel to receive the work response
 	responseChan := make(chan data_types.WorkResponse, 1)
 
@@ -303,10 +455,13 @@ func (whm *WorkHandlerManager) ExecuteWork(workRequest data_types.WorkRequest) (
 		startTime := time.Now()
 		workResponse := handler.HandleWork(workRequest.Data)
 		duration := time.Since(startTime)
+		tracing.End(span, responseError(workResponse))
 		whm.mu.Lock()
-		handlerInfo := whm.handlers[workRequest.WorkType]
-		handlerInfo.CallCount++
-		handlerInfo.TotalRuntime += duration
+		// The handler may have been unregistered while the work was running
+		if handlerInfo, ok := whm.handlers[workRequest.WorkType]; ok {
+			handlerInfo.CallCount++
+			handlerInfo.TotalRuntime += duration
+		}
 		whm.mu.Unlock()
 
 		if workResponse.Error != "" {
@@ -336,58 +491,84 @@ func (whm *WorkHandlerManager) HandleWorkerStream(stream network.Stream) {
 		}
 	}(stream)
 
-	// Read the length of the message
-	lengthBuf := make([]byte, 4)
-	_, err := io.ReadFull(stream, lengthBuf)
-	if err != nil {
-		logrus.Errorf("error reading message length: %v", err)
-		return
-	}
-	messageLength := binary.BigEndian.Uint32(lengthBuf)
-
-	// Read the actual message
-	messageBuf := make([]byte, messageLength)
-	_, err = io.ReadFull(stream, messageBuf)
-	if err != nil {
-		logrus.Errorf("error reading message: %v", err)
-		return
-	}
+	// The trace context comes with the request, so the span is started once it has been read
+	readStart := time.Now()
 
 	var workRequest data_types.WorkRequest
-	err = json.Unmarshal(messageBuf, &workRequest)
-	if err != nil {
-		logrus.Errorf("error unmarshaling work request: %v", err)
+	if err := myNetwork.ReadMessage(stream, &workRequest); err != nil {
+		logrus.Errorf("error reading work request: %v", err)
 		return
 	}
+	ctx, span := tracing.Start(tracing.Extract(context.Background(), workRequest.TraceContext), "HandleWorkerStream", trace.WithTimestamp(readStart),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(tracing.RequestIDKey.String(workRequest.RequestId), tracing.WorkTypeKey.String(string(workRequest.WorkType)),
+			tracing.PeerIDKey.String(stream.Conn().RemotePeer().String())))
+	workRequest.TraceContext = tracing.Inject(ctx)
 	peerId := stream.Conn().LocalPeer().String()
+	if !whm.startWork() {
+		whm.writeWorkResponse(stream, data_types.WorkResponse{WorkerPeerId: peerId, Error: ErrShuttingDown.Error()})
+		tracing.End(span, ErrShuttingDown)
+		return
+	}
+	defer whm.inFlight.Done()
+
+	startTime := time.Now()
 	workResponse := whm.ExecuteWork(workRequest)
+	whm.recordAudit(audit.RoleExecuted, workRequest, stream.Conn().RemotePeer().String(), peerId, startTime, workResponse.Error)
 	if workResponse.Error != "" {
 		logrus.Errorf("error from remote worker %s: executing work: %s", peerId, workResponse.Error)
 	}
 	workResponse.WorkerPeerId = peerId
+	whm.attest(workRequest, &workResponse)
 	whm.eventTracker.TrackWorkCompletion(workRequest.WorkType, workResponse.Error == "", peerId)
+	_, writeSpan := tracing.Start(ctx, "WriteResponse")
+	whm.writeWorkResponse(stream, workResponse)
+	writeSpan.End()
+	tracing.End(span, responseError(workResponse))
+}
 
-	// Write the response to the stream
-	responseBytes, err := json.Marshal(workResponse)
-	if err != nil {
-		logrus.Errorf("error marshaling work response: %v", err)
-		return
+// writeWorkResponse writes a work response to the stream, prefixed with its length.
+func (whm *WorkHandlerManager) writeWorkResponse(stream network.Stream, workResponse data_types.WorkResponse) {
+	if err := myNetwork.WriteMessage(stream, workResponse); err != nil {
+		logrus.Errorf("error writing work response: %v", err)
 	}
+}
 
-	// Prefix the response with its length
-	responseLength := uint32(len(responseBytes))
-	lengthBuf = make([]byte, 4)
-	binary.BigEndian.PutUint32(lengthBuf, responseLength)
-
-	_, err = stream.Write(lengthBuf)
-	if err != nil {
-		logrus.Errorf("error writing response length to stream: %v", err)
+// recordAudit appends the outcome of a work request to the audit store, if auditing is enabled.
+func (whm *WorkHandlerManager) recordAudit(role audit.Role, workRequest data_types.WorkRequest, requester, worker string, startTime time.Time, workError string) {
+	if whm.audit == nil {
 		return
 	}
-
-	_, err = stream.Write(responseBytes)
+	payloadCid, err := masacrypto.ComputeSha256Cid(string(workRequest.Data))
 	if err != nil {
-		logrus.Errorf("error writing response to stream: %v", err)
-		return
+		logrus.Warnf("[-] Unable to compute the payload CID of request %s: %v", workRequest.RequestId, err)
+	}
+	outcome := audit.OutcomeSuccess
+	if workError != "" {
+		outcome = audit.OutcomeFailure
+	}
+	err = whm.audit.Record(audit.WorkEvent{
+		CID:             payloadCid,
+		RequestId:       workRequest.RequestId,
+		WorkType:        string(workRequest.WorkType),
+		Role:            role,
+		RequesterPeerId: requester,
+		WorkerPeerId:    worker,
+		Duration:        time.Since(startTime).Seconds(),
+		Outcome:         outcome,
+		ErrorCode:       audit.ErrorCode(workError),
+		Error:           workError,
+		Timestamp:       startTime.UnixNano(),
+	})
+	if err != nil {
+		logrus.Errorf("[-] Unable to record audit event for request %s: %v", workRequest.RequestId, err)
+	}
+}
+
+// responseError returns the error of a work response, if any, to be recorded on its span.
+func responseError(response data_types.WorkResponse) error {
+	if response.Error == "" {
+		return nil
 	}
+	return errors.New(response.Error)
 }
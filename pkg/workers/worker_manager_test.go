@@ -0,0 +1,48 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/masa-finance/masa-oracle/pkg/pubsub"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
+)
+
+func TestDrain(t *testing.T) {
+	whm := NewWorkHandlerManager()
+	require.True(t, whm.startWork())
+
+	// The request in flight holds the drain until the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, whm.Drain(ctx), context.DeadlineExceeded)
+
+	// New requests are rejected while draining
+	assert.False(t, whm.startWork())
+	response := whm.DistributeWork(nil, data_types.WorkRequest{WorkType: data_types.Web})
+	assert.Equal(t, ErrShuttingDown.Error(), response.Error)
+
+	whm.inFlight.Done()
+	assert.NoError(t, whm.Drain(context.Background()))
+	assert.NoError(t, whm.Close())
+}
+
+func TestSetCapability(t *testing.T) {
+	whm := NewWorkHandlerManager(EnableWebScraperWorker)
+	assert.True(t, whm.HasCapability(pubsub.CategoryWeb))
+	assert.False(t, whm.HasCapability(pubsub.CategoryTwitter))
+
+	require.NoError(t, whm.SetCapability(pubsub.CategoryTwitter, true))
+	require.NoError(t, whm.SetCapability(pubsub.CategoryWeb, false))
+	assert.True(t, whm.HasCapability(pubsub.CategoryTwitter))
+	assert.False(t, whm.HasCapability(pubsub.CategoryWeb))
+
+	response := whm.ExecuteWork(data_types.WorkRequest{WorkType: data_types.Web})
+	assert.Equal(t, ErrHandlerNotFound.Error(), response.Error)
+
+	assert.ErrorIs(t, whm.SetCapability(pubsub.CategoryDiscord, true), ErrUnsupportedCapability)
+}
@@ -2,81 +2,22 @@
 package masa_test
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
+	"context"
 	"os"
 
 	"github.com/masa-finance/tee-worker/pkg/client"
 
+	masa "github.com/masa-finance/masa-oracle/pkg/client"
+	data_types "github.com/masa-finance/masa-oracle/pkg/workers/types"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
-func getData(url string) ([]byte, error) {
-	// Create a new GET request
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		fmt.Println("Error creating request:", err)
-		return nil, err
-	}
-
-	// Send the request using http.DefaultClient
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		fmt.Println("Error sending request:", err)
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Read the response body
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		fmt.Println("Error reading response:", err)
-		return nil, err
-	}
-
-	fmt.Printf("Response status: %s\n", resp.Status)
-	fmt.Printf("Response body: %s\n", string(responseBody))
-
-	return responseBody, nil
-}
-
-func postData(url string, requestBody []byte) ([]byte, error) {
-	// Create a new POST request
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
-	if err != nil {
-		fmt.Println("Error creating request:", err)
-		return nil, err
-	}
-
-	// Set the headers
-	req.Header.Set("accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-
-	// Send the request using http.DefaultClient
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		fmt.Println("Error sending request:", err)
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	// Read the response body
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		fmt.Println("Error reading response:", err)
-		return nil, err
-	}
-
-	fmt.Printf("Response status: %s\n", resp.Status)
-	fmt.Printf("Response body: %s\n", string(responseBody))
-
-	return responseBody, nil
+func newClient(baseURL string) *masa.Client {
+	c, err := masa.New(baseURL)
+	Expect(err).ToNot(HaveOccurred())
+	return c
 }
 
 var _ = Describe("E2E tests", func() {
@@ -88,45 +29,30 @@ var _ = Describe("E2E tests", func() {
 		}
 
 		Eventually(func() bool {
-			data, err := getData("http://localhost:9092/api/v1/peers")
-			if err != nil {
-				return false
-			}
-
-			fmt.Println(string(data))
-			// {"data":[{"peerId":"16Uiu2HAmQTSU51k4kx2hjGoXfNKJJjFMKvq2xWmVYgDSujgHpqoR"},{"peerId":"16Uiu2HAm74aXSU3CeBD2XWSb42UR1J6pGGKGFt9dLkAqYCaaRpY5"}],"success":true,"totalCount":2}
-
-			return bytes.Contains(data, []byte(`"totalCount":2`))
+			peers, err := newClient("http://localhost:9092").Peers(context.Background())
+			return err == nil && len(peers) == 2
 		}, "1m").Should(BeTrue())
 	})
 
 	Context("can use the API", func() {
 		It("scrapes the web", func() {
-			response, err := postData("http://localhost:9092/api/v1/data/web", []byte(`{ "depth": 1, "url": "https://www.google.com"}`))
+			response, err := newClient("http://localhost:9092").WebData(context.Background(), data_types.WebRequest{Url: "https://www.google.com", Depth: 1}, nil)
 
 			Expect(err).ToNot(HaveOccurred())
-			Expect(response).ToNot(BeNil())
-			Expect(string(response)).To(ContainSubstring("google"))
+			Expect(response.Data).To(ContainSubstring("google"))
 		})
 
 		It("scrapes the web, return encrypted data", func() {
-			response, err := postData("http://localhost:9091/api/v1/data/web", []byte(`{ "depth": 1, "url": "https://www.google.com"}`))
+			response, err := newClient("http://localhost:9091").WebData(context.Background(), data_types.WebRequest{Url: "https://www.google.com", Depth: 1}, nil)
 
 			Expect(err).ToNot(HaveOccurred())
-			Expect(response).ToNot(BeNil())
-			Expect(string(response)).ToNot(ContainSubstring("google"))
-
-			dataResult := struct {
-				Data string `json:"data"`
-			}{}
-
-			err = json.Unmarshal(response, &dataResult)
-			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Data).To(BeAssignableToTypeOf(""))
+			Expect(response.Data).ToNot(ContainSubstring("google"))
 
 			// Decrypt the response
 			cli := client.NewClient("http://localhost:8081")
-			decrypted, err := cli.Decrypt(dataResult.Data)
-			Expect(err).ToNot(HaveOccurred(), string(response))
+			decrypted, err := cli.Decrypt(response.Data.(string))
+			Expect(err).ToNot(HaveOccurred())
 			Expect(decrypted).To(ContainSubstring("google"))
 		})
 	})